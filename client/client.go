@@ -0,0 +1,312 @@
+// Package client talks to the Constellix DNS and Sonar REST APIs on behalf of
+// the provider. It started out as a copy of
+// github.com/Constellix/constellix-go-client/client and is maintained here so
+// the provider can control how requests are sent and retried.
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// BaseURL is the default endpoint of the Constellix DNS API.
+const BaseURL = "https://api.dns.constellix.com/"
+
+// SonarURL is the default endpoint of the Constellix Sonar (checks) API.
+const SonarURL = "https://api.sonar.constellix.com/"
+
+type Client struct {
+	httpclient *http.Client
+	apiKey     string //Required
+	secretKey  string //Required
+	insecure   bool   //Optional
+	proxyurl   string //Optional
+	baseURL    string //Optional
+	sonarURL   string //Optional
+	retry      retryPolicy
+	pageSize   int
+}
+
+// singleton implementation of a client
+var clietnImpl *Client
+
+type Option func(*Client)
+
+func Insecure(insecure bool) Option {
+	return func(client *Client) {
+		client.insecure = insecure
+	}
+}
+
+func ProxyUrl(pUrl string) Option {
+	return func(client *Client) {
+		client.proxyurl = pUrl
+	}
+}
+
+// BaseUrl overrides the endpoint of the DNS API, e.g. to target a staging
+// environment or a local stand-in server.
+func BaseUrl(baseURL string) Option {
+	return func(client *Client) {
+		client.baseURL = withTrailingSlash(baseURL)
+	}
+}
+
+// SonarUrl overrides the endpoint of the Sonar API.
+func SonarUrl(sonarURL string) Option {
+	return func(client *Client) {
+		client.sonarURL = withTrailingSlash(sonarURL)
+	}
+}
+
+func withTrailingSlash(endpoint string) string {
+	if endpoint != "" && !strings.HasSuffix(endpoint, "/") {
+		return endpoint + "/"
+	}
+	return endpoint
+}
+
+func initClient(apiKey, secretKey string, options ...Option) (*Client, error) {
+	//existing information about client
+	client := &Client{
+		apiKey:    apiKey,
+		secretKey: secretKey,
+		baseURL:   BaseURL,
+		sonarURL:  SonarURL,
+		retry:     defaultRetryPolicy(),
+		pageSize:  DefaultPageSize,
+	}
+	for _, option := range options {
+		option(client)
+	}
+	if client.baseURL == "" {
+		client.baseURL = BaseURL
+	}
+	if client.sonarURL == "" {
+		client.sonarURL = SonarURL
+	}
+
+	//Setting up the HTTP client for the API call
+	var transport *http.Transport
+	transport = client.useInsecureHTTPClient(client.insecure)
+	if client.proxyurl != "" {
+		var err error
+		transport, err = client.configProxy(transport)
+		if err != nil {
+			return nil, err
+		}
+	}
+	client.httpclient = &http.Client{
+		Transport: newLoggingTransport(transport),
+	}
+	return client, nil
+}
+
+// GetClient returns a singleton. It fails if the options cannot be applied,
+// for example because the proxy URL is invalid.
+func GetClient(apiKey, secretKey string, options ...Option) (*Client, error) {
+	client, err := initClient(apiKey, secretKey, options...)
+	if err != nil {
+		return nil, err
+	}
+	clietnImpl = client
+	return clietnImpl, nil
+}
+
+func (c *Client) useInsecureHTTPClient(insecure bool) *http.Transport {
+	transport := &http.Transport{
+		TLSClientConfig: &tls.Config{
+			CipherSuites: []uint16{
+				tls.TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA,
+				tls.TLS_ECDHE_RSA_WITH_AES_256_CBC_SHA,
+				tls.TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA256,
+				tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+				tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+			},
+			PreferServerCipherSuites: true,
+			InsecureSkipVerify:       insecure,
+			MinVersion:               tls.VersionTLS11,
+			MaxVersion:               tls.VersionTLS12,
+		},
+	}
+
+	return transport
+}
+
+func (c *Client) configProxy(transport *http.Transport) (*http.Transport, error) {
+	pUrl, err := url.Parse(c.proxyurl)
+	if err != nil {
+		return nil, &ClientError{Op: "configure proxy", Err: err}
+	}
+	if pUrl.Scheme == "" || pUrl.Host == "" {
+		return nil, &ClientError{Op: "configure proxy", Err: fmt.Errorf("%q is not an absolute URL", c.proxyurl)}
+	}
+	transport.Proxy = http.ProxyURL(pUrl)
+	return transport, nil
+}
+
+// SonarEndpoint returns the absolute URL of the given Sonar API path, for
+// example "rest/api/http/1234". The result can be passed to any of the
+// request methods in place of a DNS API path.
+func (c *Client) SonarEndpoint(path string) string {
+	return c.sonarURL + strings.TrimPrefix(path, "/")
+}
+
+// resolveURL turns an endpoint into an absolute URL and reports whether it
+// targets the Sonar API. Relative endpoints belong to the DNS API.
+func (c *Client) resolveURL(endpoint string) (string, bool) {
+	if strings.HasPrefix(endpoint, c.sonarURL) {
+		return endpoint, true
+	}
+	return c.baseURL + endpoint, false
+}
+
+func getToken(apiKey, secretKey string) string {
+	//Extracts epoch time in miliseconds
+	time := strconv.FormatInt(time.Now().UnixNano()/int64(time.Millisecond), 10)
+
+	//Calculate hmac using secrest key and epoch time
+	h := hmac.New(sha1.New, []byte(secretKey))
+	h.Write([]byte(time))
+	sha := base64.StdEncoding.EncodeToString(h.Sum(nil))
+
+	//Building token as 'apikey:hmac:time'
+	token := string(apiKey) + ":" + string(sha) + ":" + string(time)
+	return token
+}
+
+func (c *Client) makeRequest(ctx context.Context, method, endpoint string, payload []byte) (*http.Request, error) {
+	//Defining http request
+	var req *http.Request
+	var err error
+	if method == "POST" || method == "PUT" {
+		req, err = http.NewRequestWithContext(ctx, method, endpoint, bytes.NewBuffer(payload))
+	} else {
+		req, err = http.NewRequestWithContext(ctx, method, endpoint, nil)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	//Calling for token and setting headers
+	token := getToken(c.apiKey, c.secretKey)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("x-cns-security-token", token)
+
+	return req, nil
+}
+
+func (c *Client) Save(obj interface{}, endpoint string) (responce *http.Response, err error) {
+	return c.SaveContext(context.Background(), obj, endpoint)
+}
+
+// SaveContext is like Save, but aborts the request, and any pending retry,
+// once ctx is done.
+func (c *Client) SaveContext(ctx context.Context, obj interface{}, endpoint string) (*http.Response, error) {
+	jsonPayload, err := json.Marshal(obj)
+	if err != nil {
+		return nil, &ClientError{Op: "encode request", Endpoint: endpoint, Err: err}
+	}
+
+	url, flag := c.resolveURL(endpoint)
+
+	resp, err := c.do(ctx, http.MethodPost, url, jsonPayload)
+	if err != nil {
+		return nil, err
+	}
+
+	if flag == false {
+		return resp, checkForErrors(resp)
+	}
+	return resp, checkForErrorsChecks(resp)
+}
+
+// checkForErrors returns a ConstellixAPIError unless the DNS API answered
+// with 200.
+func checkForErrors(resp *http.Response) error {
+	if resp.StatusCode != http.StatusOK {
+		return newAPIError(resp)
+	}
+	return nil
+}
+
+// checkForErrorsChecks returns a ConstellixAPIError unless the Sonar API
+// answered with 200, 201 or 202.
+func checkForErrorsChecks(resp *http.Response) error {
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != 201 && resp.StatusCode != 202 {
+		return newAPIError(resp)
+	}
+	return nil
+}
+
+func (c *Client) GetbyId(endpoint string) (response *http.Response, err error) {
+	return c.GetbyIdContext(context.Background(), endpoint)
+}
+
+// GetbyIdContext is like GetbyId, but aborts the request, and any pending
+// retry, once ctx is done.
+func (c *Client) GetbyIdContext(ctx context.Context, endpoint string) (*http.Response, error) {
+	url, flag := c.resolveURL(endpoint)
+
+	resp, err := c.do(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if flag == false {
+		return resp, checkForErrors(resp)
+	}
+	return resp, checkForErrorsChecks(resp)
+}
+
+func (c *Client) DeletebyId(endpoint string) error {
+	return c.DeletebyIdContext(context.Background(), endpoint)
+}
+
+// DeletebyIdContext is like DeletebyId, but aborts the request, and any
+// pending retry, once ctx is done.
+func (c *Client) DeletebyIdContext(ctx context.Context, endpoint string) error {
+	url, _ := c.resolveURL(endpoint)
+
+	resp, err := c.do(ctx, http.MethodDelete, url, nil)
+	if err != nil {
+		return err
+	}
+
+	return checkForErrorsChecks(resp)
+}
+
+func (c *Client) UpdatebyID(obj interface{}, endpoint string) (response *http.Response, err error) {
+	return c.UpdatebyIDContext(context.Background(), obj, endpoint)
+}
+
+// UpdatebyIDContext is like UpdatebyID, but aborts the request, and any
+// pending retry, once ctx is done.
+func (c *Client) UpdatebyIDContext(ctx context.Context, obj interface{}, endpoint string) (*http.Response, error) {
+	jsonPayload, err := json.Marshal(obj)
+	if err != nil {
+		return nil, &ClientError{Op: "encode request", Endpoint: endpoint, Err: err}
+	}
+	url, flag := c.resolveURL(endpoint)
+
+	resp, err := c.do(ctx, http.MethodPut, url, jsonPayload)
+	if err != nil {
+		return nil, err
+	}
+
+	if flag == false {
+		return resp, checkForErrors(resp)
+	}
+	return resp, checkForErrorsChecks(resp)
+}
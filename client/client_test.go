@@ -0,0 +1,125 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClientEndpoints(t *testing.T) {
+	var paths []string
+	dns := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, "dns:"+r.URL.Path)
+		w.Write([]byte(`{}`))
+	}))
+	defer dns.Close()
+	sonar := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, "sonar:"+r.URL.Path)
+		w.Write([]byte(`{}`))
+	}))
+	defer sonar.Close()
+
+	c, err := GetClient("apikey", "secretkey", BaseUrl(dns.URL), SonarUrl(sonar.URL+"/"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if _, err := c.GetbyId("v1/domains/1"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, err := c.GetbyId(c.SonarEndpoint("rest/api/http/2")); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := []string{"dns:/v1/domains/1", "sonar:/rest/api/http/2"}
+	if len(paths) != len(expected) {
+		t.Fatalf("expected requests %v, got %v", expected, paths)
+	}
+	for i := range expected {
+		if paths[i] != expected[i] {
+			t.Errorf("expected request %q, got %q", expected[i], paths[i])
+		}
+	}
+}
+
+func TestClientDefaultEndpoints(t *testing.T) {
+	c, err := GetClient("apikey", "secretkey")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if url, sonar := c.resolveURL("v1/domains"); url != BaseURL+"v1/domains" || sonar {
+		t.Errorf("unexpected DNS API URL %q (sonar %t)", url, sonar)
+	}
+	if url, sonar := c.resolveURL(c.SonarEndpoint("rest/api/tcp")); url != SonarURL+"rest/api/tcp" || !sonar {
+		t.Errorf("unexpected Sonar API URL %q (sonar %t)", url, sonar)
+	}
+}
+
+func TestClientInvalidProxyURL(t *testing.T) {
+	for _, proxy := range []string{"://proxy", "proxy-without-scheme"} {
+		_, err := GetClient("apikey", "secretkey", ProxyUrl(proxy))
+		if err == nil {
+			t.Errorf("expected an error for proxy URL %q", proxy)
+			continue
+		}
+		if _, ok := err.(*ClientError); !ok {
+			t.Errorf("expected a *ClientError for proxy URL %q, got %T", proxy, err)
+		}
+	}
+}
+
+func TestClientEncodeError(t *testing.T) {
+	c, err := GetClient("apikey", "secretkey", BaseUrl("http://127.0.0.1:1"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	// Channels cannot be encoded as JSON.
+	if _, err := c.Save(make(chan int), "v1/domains"); err == nil {
+		t.Errorf("expected an error from Save")
+	} else if _, ok := err.(*ClientError); !ok {
+		t.Errorf("expected a *ClientError from Save, got %T", err)
+	}
+	if _, err := c.UpdatebyID(make(chan int), "v1/domains/1"); err == nil {
+		t.Errorf("expected an error from UpdatebyID")
+	} else if _, ok := err.(*ClientError); !ok {
+		t.Errorf("expected a *ClientError from UpdatebyID, got %T", err)
+	}
+}
+
+func TestClientContextCancelAbortsRequest(t *testing.T) {
+	release := make(chan struct{})
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	c, err := GetClient("apikey", "secretkey", BaseUrl(server.URL))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	start := time.Now()
+	_, err = c.GetbyIdContext(ctx, "v1/domains")
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if time.Since(start) > 5*time.Second {
+		t.Errorf("expected the request to be aborted promptly, took %s", time.Since(start))
+	}
+	if calls != 1 {
+		t.Errorf("expected a cancelled request not to be retried, got %d attempts", calls)
+	}
+}
@@ -0,0 +1,165 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+// ClientError reports a failure that happened on the client side: while the
+// client was configured, while a request payload was encoded or while a
+// response body was read. No API error is involved.
+type ClientError struct {
+	// Op describes what the client was doing, e.g. "encode request".
+	Op string
+	// Endpoint is the API endpoint involved, if any.
+	Endpoint string
+	Err      error
+}
+
+func (e *ClientError) Error() string {
+	if e.Endpoint != "" {
+		return fmt.Sprintf("constellix client: %s %s: %s", e.Op, e.Endpoint, e.Err)
+	}
+	return fmt.Sprintf("constellix client: %s: %s", e.Op, e.Err)
+}
+
+func (e *ClientError) Unwrap() error {
+	return e.Err
+}
+
+// requestIDHeaders lists the response headers that may carry an identifier of
+// the request, in order of preference.
+var requestIDHeaders = []string{
+	"X-Request-Id",
+	"X-Correlation-Id",
+	"X-Amzn-Requestid",
+	"Request-Id",
+}
+
+// ConstellixAPIError is returned when the DNS or Sonar API answers a request
+// with a non-2xx status.
+type ConstellixAPIError struct {
+	StatusCode int
+	Method     string
+	Endpoint   string
+	// Messages holds the individual error messages returned by the API.
+	Messages []string
+	// RequestID identifies the request on the API side, if the API sent one.
+	RequestID string
+	// Body is the raw response body.
+	Body string
+}
+
+func (e *ConstellixAPIError) Error() string {
+	msg := strings.Join(e.Messages, "; ")
+	if msg == "" {
+		msg = http.StatusText(e.StatusCode)
+	}
+	out := fmt.Sprintf("%s %s: %d: %s", e.Method, e.Endpoint, e.StatusCode, msg)
+	if e.RequestID != "" {
+		out += fmt.Sprintf(" (request ID %s)", e.RequestID)
+	}
+	return out
+}
+
+// newAPIError builds a ConstellixAPIError from a failed response. The
+// response body is consumed.
+func newAPIError(resp *http.Response) error {
+	bodyBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return &ClientError{Op: "read response", Endpoint: resp.Request.URL.String(), Err: err}
+	}
+	apiErr := &ConstellixAPIError{
+		StatusCode: resp.StatusCode,
+		Method:     resp.Request.Method,
+		Endpoint:   resp.Request.URL.String(),
+		Messages:   parseErrorMessages(bodyBytes),
+		Body:       string(bodyBytes),
+	}
+	for _, header := range requestIDHeaders {
+		if id := resp.Header.Get(header); id != "" {
+			apiErr.RequestID = id
+			break
+		}
+	}
+	return apiErr
+}
+
+// parseErrorMessages extracts the error messages from a response body. The
+// DNS API sends {"errors": ["..."]}, the Sonar API either a JSON object with a
+// message or plain text.
+func parseErrorMessages(body []byte) []string {
+	messages := make([]string, 0, 1)
+	var data map[string]interface{}
+	if err := json.Unmarshal(body, &data); err != nil {
+		if text := strings.TrimSpace(string(body)); text != "" {
+			messages = append(messages, text)
+		}
+		return messages
+	}
+	if errs, ok := data["errors"].([]interface{}); ok {
+		for _, val := range errs {
+			if msg := errorMessage(val); msg != "" {
+				messages = append(messages, msg)
+			}
+		}
+	}
+	if len(messages) == 0 {
+		if msg := errorMessage(data); msg != "" {
+			messages = append(messages, msg)
+		}
+	}
+	return messages
+}
+
+func errorMessage(val interface{}) string {
+	switch v := val.(type) {
+	case string:
+		return strings.TrimSpace(v)
+	case map[string]interface{}:
+		for _, key := range []string{"message", "error", "errorMessage", "msg"} {
+			if msg, ok := v[key].(string); ok && msg != "" {
+				return strings.TrimSpace(msg)
+			}
+		}
+	}
+	return ""
+}
+
+// AsAPIError returns the ConstellixAPIError wrapped in err, if any.
+func AsAPIError(err error) (*ConstellixAPIError, bool) {
+	var apiErr *ConstellixAPIError
+	if errors.As(err, &apiErr) {
+		return apiErr, true
+	}
+	return nil, false
+}
+
+func hasStatus(err error, status int) bool {
+	apiErr, ok := AsAPIError(err)
+	return ok && apiErr.StatusCode == status
+}
+
+// IsNotFound reports whether err is an API error with status 404.
+func IsNotFound(err error) bool {
+	return hasStatus(err, http.StatusNotFound)
+}
+
+// IsConflict reports whether err is an API error with status 409.
+func IsConflict(err error) bool {
+	return hasStatus(err, http.StatusConflict)
+}
+
+// IsRateLimited reports whether err is an API error with status 429.
+func IsRateLimited(err error) bool {
+	return hasStatus(err, http.StatusTooManyRequests)
+}
+
+// IsBadRequest reports whether err is an API error with status 400.
+func IsBadRequest(err error) bool {
+	return hasStatus(err, http.StatusBadRequest)
+}
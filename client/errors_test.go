@@ -0,0 +1,89 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestConstellixAPIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-123")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"errors":["first problem","second problem"]}`))
+	}))
+	defer server.Close()
+
+	c, err := GetClient("apikey", "secretkey", BaseUrl(server.URL))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	_, err = c.Save(map[string]string{}, "v1/domains")
+	apiErr, ok := AsAPIError(err)
+	if !ok {
+		t.Fatalf("expected a *ConstellixAPIError, got %T", err)
+	}
+	if apiErr.StatusCode != http.StatusBadRequest || apiErr.Method != http.MethodPost || apiErr.RequestID != "req-123" {
+		t.Errorf("unexpected error details %+v", apiErr)
+	}
+	if apiErr.Endpoint != server.URL+"/v1/domains" {
+		t.Errorf("unexpected endpoint %q", apiErr.Endpoint)
+	}
+	if !reflect.DeepEqual(apiErr.Messages, []string{"first problem", "second problem"}) {
+		t.Errorf("unexpected messages %q", apiErr.Messages)
+	}
+	if !strings.Contains(err.Error(), "first problem; second problem") {
+		t.Errorf("expected messages to be separated in %q", err.Error())
+	}
+	if !IsBadRequest(err) || IsNotFound(err) {
+		t.Errorf("unexpected status helpers result for %s", err)
+	}
+}
+
+func TestStatusHelpers(t *testing.T) {
+	cases := []struct {
+		status int
+		check  func(error) bool
+	}{
+		{http.StatusNotFound, IsNotFound},
+		{http.StatusConflict, IsConflict},
+		{http.StatusTooManyRequests, IsRateLimited},
+		{http.StatusBadRequest, IsBadRequest},
+	}
+	for _, tc := range cases {
+		err := &ConstellixAPIError{StatusCode: tc.status}
+		if !tc.check(err) {
+			t.Errorf("expected helper to match status %d", tc.status)
+		}
+		if !tc.check(fmt.Errorf("wrapped: %w", err)) {
+			t.Errorf("expected helper to match wrapped status %d", tc.status)
+		}
+		if tc.check(&ConstellixAPIError{StatusCode: http.StatusInternalServerError}) {
+			t.Errorf("expected helper for status %d not to match status 500", tc.status)
+		}
+		if tc.check(nil) || tc.check(fmt.Errorf("network error")) {
+			t.Errorf("expected helper for status %d not to match non API errors", tc.status)
+		}
+	}
+}
+
+func TestParseErrorMessages(t *testing.T) {
+	cases := []struct {
+		body     string
+		expected []string
+	}{
+		{`{"errors":["a","b"]}`, []string{"a", "b"}},
+		{`{"errors":[{"message":"a"}]}`, []string{"a"}},
+		{`{"message":"check not found"}`, []string{"check not found"}},
+		{`plain text failure`, []string{"plain text failure"}},
+		{``, []string{}},
+	}
+	for _, tc := range cases {
+		if got := parseErrorMessages([]byte(tc.body)); !reflect.DeepEqual(got, tc.expected) {
+			t.Errorf("parseErrorMessages(%q) = %q, expected %q", tc.body, got, tc.expected)
+		}
+	}
+}
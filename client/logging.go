@@ -0,0 +1,132 @@
+package client
+
+import (
+	"bytes"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// LogBodyEnvVar is the environment variable that switches on full request
+// and response body dumps. Any value other than "", "0" or "false" enables
+// them; otherwise bodies are truncated to maxLoggedBodySize bytes.
+const LogBodyEnvVar = "TF_LOG_PROVIDER_CONSTELLIX"
+
+// maxLoggedBodySize caps how much of a request or response body is written to
+// the log when full body dumps are disabled.
+const maxLoggedBodySize = 1024
+
+// redactedHeaders lists the headers whose values never reach the log.
+var redactedHeaders = []string{
+	"X-Cns-Security-Token",
+	"Authorization",
+	"Proxy-Authorization",
+	"Cookie",
+	"Set-Cookie",
+}
+
+// loggingTransport logs every request sent to the API together with the
+// status and latency of its response. Credentials are redacted.
+type loggingTransport struct {
+	transport http.RoundTripper
+	fullBody  bool
+}
+
+func newLoggingTransport(transport http.RoundTripper) *loggingTransport {
+	return &loggingTransport{
+		transport: transport,
+		fullBody:  logFullBodies(),
+	}
+}
+
+func logFullBodies() bool {
+	switch strings.ToLower(strings.TrimSpace(os.Getenv(LogBodyEnvVar))) {
+	case "", "0", "false":
+		return false
+	}
+	return true
+}
+
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := peekRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	if t.fullBody {
+		log.Printf("[DEBUG] Constellix API request: %s %s headers=%v body=%s",
+			req.Method, req.URL, redactHeaders(req.Header), t.formatBody(reqBody))
+	} else {
+		log.Printf("[DEBUG] Constellix API request: %s %s body=%s", req.Method, req.URL, t.formatBody(reqBody))
+	}
+
+	start := time.Now()
+	resp, err := t.transport.RoundTrip(req)
+	latency := time.Since(start)
+	if err != nil {
+		log.Printf("[DEBUG] Constellix API request failed: %s %s latency=%s error=%s", req.Method, req.URL, latency, err)
+		return resp, err
+	}
+
+	respBody, err := peekResponseBody(resp)
+	if err != nil {
+		return nil, err
+	}
+	if t.fullBody {
+		log.Printf("[DEBUG] Constellix API response: %s %s status=%d latency=%s headers=%v body=%s",
+			req.Method, req.URL, resp.StatusCode, latency, redactHeaders(resp.Header), t.formatBody(respBody))
+	} else {
+		log.Printf("[DEBUG] Constellix API response: %s %s status=%d latency=%s body=%s",
+			req.Method, req.URL, resp.StatusCode, latency, t.formatBody(respBody))
+	}
+	return resp, nil
+}
+
+func (t *loggingTransport) formatBody(body []byte) string {
+	if !t.fullBody && len(body) > maxLoggedBodySize {
+		return string(body[:maxLoggedBodySize]) + "...(truncated)"
+	}
+	return string(body)
+}
+
+// peekRequestBody returns the body of req without consuming it.
+func peekRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	body, err := ioutil.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+// peekResponseBody returns the body of resp and replaces it with an unread
+// copy so callers can still consume it.
+func peekResponseBody(resp *http.Response) ([]byte, error) {
+	if resp.Body == nil {
+		return nil, nil
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+// redactHeaders returns a copy of header with credential values replaced.
+func redactHeaders(header http.Header) http.Header {
+	redacted := header.Clone()
+	for _, name := range redactedHeaders {
+		if redacted.Get(name) != "" {
+			redacted.Set(name, "REDACTED")
+		}
+	}
+	return redacted
+}
@@ -0,0 +1,88 @@
+package client
+
+import (
+	"bytes"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+func captureLog(t *testing.T) *bytes.Buffer {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	t.Cleanup(func() { log.SetOutput(os.Stderr) })
+	return &buf
+}
+
+func TestLoggingTransportRedactsToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("x-cns-security-token") == "" {
+			t.Errorf("security token was not sent to the server")
+		}
+		w.Write([]byte(`{"id":1}`))
+	}))
+	defer server.Close()
+
+	for _, fullBody := range []string{"", "1"} {
+		os.Setenv(LogBodyEnvVar, fullBody)
+		buf := captureLog(t)
+
+		c, err := GetClient("apikey", "secretkey", BaseUrl(server.URL))
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		resp, err := c.Save(map[string]string{"name": "example.com"}, "v1/domains")
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		resp.Body.Close()
+
+		out := buf.String()
+		if strings.Contains(out, "apikey:") {
+			t.Errorf("security token leaked into the log (%s=%q):\n%s", LogBodyEnvVar, fullBody, out)
+		}
+		for _, expected := range []string{"POST " + server.URL + "/v1/domains", "status=200", "latency=", `{"name":"example.com"}`, `{"id":1}`} {
+			if !strings.Contains(out, expected) {
+				t.Errorf("expected log to contain %q (%s=%q):\n%s", expected, LogBodyEnvVar, fullBody, out)
+			}
+		}
+	}
+	os.Unsetenv(LogBodyEnvVar)
+}
+
+func TestLoggingTransportTruncatesBody(t *testing.T) {
+	large := strings.Repeat("a", maxLoggedBodySize*2)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(large))
+	}))
+	defer server.Close()
+
+	os.Unsetenv(LogBodyEnvVar)
+	buf := captureLog(t)
+
+	c, err := GetClient("apikey", "secretkey", BaseUrl(server.URL))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	resp, err := c.GetbyId("v1/domains")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	defer resp.Body.Close()
+
+	if strings.Contains(buf.String(), large) {
+		t.Errorf("expected the logged body to be truncated")
+	}
+	if !strings.Contains(buf.String(), "...(truncated)") {
+		t.Errorf("expected the logged body to be marked as truncated")
+	}
+
+	body := new(bytes.Buffer)
+	body.ReadFrom(resp.Body)
+	if body.String() != large {
+		t.Errorf("expected the caller to receive the full body, got %d bytes", body.Len())
+	}
+}
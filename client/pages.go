@@ -0,0 +1,199 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"strings"
+)
+
+// DefaultPageSize is how many objects the client asks for per page of a
+// list unless configured otherwise.
+const DefaultPageSize = 100
+
+// PageSize sets how many objects the client asks for per page of a list.
+func PageSize(size int) Option {
+	return func(client *Client) {
+		if size > 0 {
+			client.pageSize = size
+		}
+	}
+}
+
+// Iterator walks the objects of a collection, fetching the pages the API
+// splits it into as it goes:
+//
+//	it := c.Iterate(ctx, "v1/domains")
+//	for it.Next() {
+//		domain := it.Object()
+//		...
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+//
+// A collection answered as an array is paged with the offset and limit
+// parameters, and ends with the first page holding fewer objects than
+// asked for, or repeating the previous page. A collection answered as an
+// object holding the array in data is paged by following the next link of
+// the answer, in links, meta.links or meta.pagination.links.
+type Iterator struct {
+	client   *Client
+	ctx      context.Context
+	endpoint string
+	sonar    bool
+
+	// next is the URL of the next page, or "" once the last page was
+	// fetched.
+	next   string
+	offset int
+	// previous is the last page, encoded, to detect an API that ignores the
+	// offset and answers with the same page again rather than loop forever.
+	// Whole pages are compared since some collections, such as the history
+	// of a domain, hold objects without IDs that may encode alike.
+	previous string
+
+	page   []map[string]interface{}
+	object map[string]interface{}
+	err    error
+}
+
+// Iterate returns an iterator over the objects of the collection at
+// endpoint. Nothing is requested until Next is called.
+func (c *Client) Iterate(ctx context.Context, endpoint string) *Iterator {
+	it := &Iterator{client: c, ctx: ctx, endpoint: endpoint}
+	it.next, it.sonar = c.resolveURL(endpoint)
+	it.next = it.pageURL(it.next)
+	return it
+}
+
+// Next advances to the next object, fetching the next page when the current
+// one is exhausted. It returns false at the end of the collection or after
+// a failure, which Err then returns.
+func (it *Iterator) Next() bool {
+	for len(it.page) == 0 {
+		if it.err != nil || it.next == "" {
+			it.object = nil
+			return false
+		}
+		it.err = it.fetch()
+	}
+	it.object, it.page = it.page[0], it.page[1:]
+	return true
+}
+
+// Object returns the object Next advanced to.
+func (it *Iterator) Object() map[string]interface{} {
+	return it.object
+}
+
+// Err returns the failure that ended the iteration, if any.
+func (it *Iterator) Err() error {
+	return it.err
+}
+
+// pageURL adds the offset and limit of the next page to rawURL.
+func (it *Iterator) pageURL(rawURL string) string {
+	sep := "?"
+	if strings.Contains(rawURL, "?") {
+		sep = "&"
+	}
+	return fmt.Sprintf("%s%soffset=%d&limit=%d", rawURL, sep, it.offset, it.client.pageSize)
+}
+
+// fetch requests the page at it.next and works out where the following one
+// is.
+func (it *Iterator) fetch() error {
+	current := it.next
+	it.next = ""
+
+	resp, err := it.client.do(it.ctx, http.MethodGet, current, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if it.sonar {
+		err = checkForErrorsChecks(resp)
+	} else {
+		err = checkForErrors(resp)
+	}
+	if err != nil {
+		return err
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return &ClientError{Op: "read response", Endpoint: it.endpoint, Err: err}
+	}
+
+	var page []map[string]interface{}
+	if err := json.Unmarshal(body, &page); err == nil {
+		encoded, _ := json.Marshal(page)
+		if it.offset > 0 && string(encoded) == it.previous {
+			log.Printf("[WARN] Constellix API answered %s at offset %d with the previous page, assuming it ignores the offset and the collection ended", it.endpoint, it.offset)
+			return nil
+		}
+		it.previous = string(encoded)
+		it.page = page
+		// A page holding fewer objects than asked for is the last one, and
+		// one holding more comes from an API that ignores the limit and
+		// answered with the whole collection.
+		if len(page) == it.client.pageSize {
+			it.offset += len(page)
+			base, _ := it.client.resolveURL(it.endpoint)
+			it.next = it.pageURL(base)
+		}
+		return nil
+	}
+
+	var wrapped struct {
+		Data  []map[string]interface{} `json:"data"`
+		Links pageLinks                `json:"links"`
+		Meta  struct {
+			Links      pageLinks `json:"links"`
+			Pagination struct {
+				Links pageLinks `json:"links"`
+			} `json:"pagination"`
+		} `json:"meta"`
+	}
+	if err := json.Unmarshal(body, &wrapped); err != nil || wrapped.Data == nil {
+		return &ClientError{Op: "decode list", Endpoint: it.endpoint, Err: fmt.Errorf("unexpected response %s", body)}
+	}
+	it.page = wrapped.Data
+	for _, links := range []pageLinks{wrapped.Links, wrapped.Meta.Links, wrapped.Meta.Pagination.Links} {
+		if links.Next == "" {
+			continue
+		}
+		next, err := resp.Request.URL.Parse(links.Next)
+		if err != nil {
+			return &ClientError{Op: "decode list", Endpoint: it.endpoint, Err: err}
+		}
+		if next.String() != current {
+			it.next = next.String()
+		}
+		break
+	}
+	return nil
+}
+
+type pageLinks struct {
+	Next string `json:"next"`
+}
+
+// List returns every object of the collection at endpoint.
+func (c *Client) List(endpoint string) ([]map[string]interface{}, error) {
+	return c.ListContext(context.Background(), endpoint)
+}
+
+// ListContext is like List, but aborts the requests, and any pending retry,
+// once ctx is done.
+func (c *Client) ListContext(ctx context.Context, endpoint string) ([]map[string]interface{}, error) {
+	objects := make([]map[string]interface{}, 0)
+	it := c.Iterate(ctx, endpoint)
+	for it.Next() {
+		objects = append(objects, it.Object())
+	}
+	return objects, it.Err()
+}
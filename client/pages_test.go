@@ -0,0 +1,234 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// objectIDs returns the IDs of objects.
+func objectIDs(objects []map[string]interface{}) []string {
+	ids := make([]string, 0, len(objects))
+	for _, obj := range objects {
+		ids = append(ids, fmt.Sprint(obj["id"]))
+	}
+	return ids
+}
+
+// newOffsetServer serves the objects with IDs 1 to n as arrays paged with
+// the offset and limit parameters, and records the queries it is sent.
+func newOffsetServer(n int, queries *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*queries = append(*queries, r.URL.RawQuery)
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		page := make([]map[string]interface{}, 0)
+		for id := offset + 1; id <= n && id <= offset+limit; id++ {
+			page = append(page, map[string]interface{}{"id": id})
+		}
+		json.NewEncoder(w).Encode(page)
+	}))
+}
+
+func TestListFollowsOffsets(t *testing.T) {
+	cases := []struct {
+		objects int
+		queries []string
+	}{
+		{5, []string{"offset=0&limit=2", "offset=2&limit=2", "offset=4&limit=2"}},
+		{4, []string{"offset=0&limit=2", "offset=2&limit=2", "offset=4&limit=2"}},
+		{0, []string{"offset=0&limit=2"}},
+	}
+	for _, tc := range cases {
+		var queries []string
+		server := newOffsetServer(tc.objects, &queries)
+		c := newRetryTestClient(t, server, PageSize(2))
+
+		objects, err := c.List("v1/domains")
+		server.Close()
+		if err != nil {
+			t.Errorf("%d objects: unexpected error: %s", tc.objects, err)
+			continue
+		}
+		expected := []string{}
+		for id := 1; id <= tc.objects; id++ {
+			expected = append(expected, strconv.Itoa(id))
+		}
+		if ids := objectIDs(objects); !reflect.DeepEqual(ids, expected) {
+			t.Errorf("%d objects: expected %v, got %v", tc.objects, expected, ids)
+		}
+		if !reflect.DeepEqual(queries, tc.queries) {
+			t.Errorf("%d objects: expected queries %v, got %v", tc.objects, tc.queries, queries)
+		}
+	}
+}
+
+func TestListObjectsWithoutIDs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		page := make([]map[string]interface{}, 0)
+		for version := offset + 1; version <= 3 && version <= offset+2; version++ {
+			page = append(page, map[string]interface{}{"version": version})
+		}
+		json.NewEncoder(w).Encode(page)
+	}))
+	defer server.Close()
+
+	c := newRetryTestClient(t, server, PageSize(2))
+	objects, err := c.List("v1/domains/1/history")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(objects) != 3 || objects[2]["version"] != float64(3) {
+		t.Errorf("expected versions 1 to 3, got %v", objects)
+	}
+}
+
+func TestListKeepsQuery(t *testing.T) {
+	var queries []string
+	server := newOffsetServer(3, &queries)
+	defer server.Close()
+	c := newRetryTestClient(t, server, PageSize(2))
+
+	if _, err := c.List("v1/domains?sort=name"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expected := []string{"sort=name&offset=0&limit=2", "sort=name&offset=2&limit=2"}
+	if !reflect.DeepEqual(queries, expected) {
+		t.Errorf("expected queries %v, got %v", expected, queries)
+	}
+}
+
+func TestListWithoutPaging(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Write([]byte(`[{"id":1},{"id":2},{"id":3}]`))
+	}))
+	defer server.Close()
+
+	// An API that ignores the limit answers with the whole collection at
+	// once.
+	c := newRetryTestClient(t, server, PageSize(2))
+	objects, err := c.List("v1/domains")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if ids := objectIDs(objects); !reflect.DeepEqual(ids, []string{"1", "2", "3"}) || calls != 1 {
+		t.Errorf("expected the 3 objects in one request, got %v in %d", ids, calls)
+	}
+
+	// An API that honors the limit but not the offset answers with the
+	// same page again, which ends the collection.
+	calls = 0
+	c = newRetryTestClient(t, server, PageSize(3))
+	objects, err = c.List("v1/domains")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if ids := objectIDs(objects); !reflect.DeepEqual(ids, []string{"1", "2", "3"}) || calls != 2 {
+		t.Errorf("expected the 3 objects in two requests, got %v in %d", ids, calls)
+	}
+}
+
+func TestListPagesStartingAlike(t *testing.T) {
+	// Entries without IDs may encode alike, such as the first entries of
+	// two pages of a history.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("offset") {
+		case "0":
+			w.Write([]byte(`[{"action":"update"},{"action":"create"}]`))
+		case "2":
+			w.Write([]byte(`[{"action":"update"},{"action":"delete"}]`))
+		default:
+			w.Write([]byte(`[]`))
+		}
+	}))
+	defer server.Close()
+
+	c := newRetryTestClient(t, server, PageSize(2))
+	objects, err := c.List("v1/domains/1/history")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(objects) != 4 || objects[3]["action"] != "delete" {
+		t.Errorf("expected the 4 entries, got %v", objects)
+	}
+}
+
+func TestListFollowsLinks(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("page") {
+		case "":
+			w.Write([]byte(`{"data":[{"id":1},{"id":2}],"links":{"next":"/v4/domains?page=2"}}`))
+		case "2":
+			fmt.Fprintf(w, `{"data":[{"id":3}],"meta":{"pagination":{"links":{"next":"%s/v4/domains?page=3"}}}}`, server.URL)
+		case "3":
+			w.Write([]byte(`{"data":[{"id":4}],"meta":{"links":{"next":null}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	c := newRetryTestClient(t, server, PageSize(2))
+	objects, err := c.List("v4/domains")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if ids := objectIDs(objects); !reflect.DeepEqual(ids, []string{"1", "2", "3", "4"}) {
+		t.Errorf("expected the objects of the 3 pages, got %v", ids)
+	}
+}
+
+func TestIteratorStopsOnError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("offset") != "0" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"errors":["offset out of range"]}`))
+			return
+		}
+		w.Write([]byte(`[{"id":1},{"id":2}]`))
+	}))
+	defer server.Close()
+
+	c := newRetryTestClient(t, server, PageSize(2))
+	it := c.Iterate(context.Background(), "v1/domains")
+	var ids []string
+	for it.Next() {
+		ids = append(ids, fmt.Sprint(it.Object()["id"]))
+	}
+	if !reflect.DeepEqual(ids, []string{"1", "2"}) {
+		t.Errorf("expected the objects of the first page, got %v", ids)
+	}
+	if !IsBadRequest(it.Err()) {
+		t.Errorf("expected the error of the second page, got %v", it.Err())
+	}
+	if it.Next() {
+		t.Errorf("expected the iteration to stay over")
+	}
+
+	server.Close()
+	if _, err := c.List("v1/domains"); err == nil {
+		t.Errorf("expected an error from a closed server")
+	}
+}
+
+func TestListUnexpectedResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id":1}`))
+	}))
+	defer server.Close()
+
+	c := newRetryTestClient(t, server)
+	if _, err := c.List("v1/domains/1"); err == nil || !strings.Contains(err.Error(), "unexpected response") {
+		t.Errorf("expected an unexpected response error, got %v", err)
+	}
+}
@@ -0,0 +1,182 @@
+package client
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultMaxRetries is how many times a failed request is retried
+	// unless configured otherwise.
+	DefaultMaxRetries = 5
+
+	// DefaultRetryMaxWait caps a single backoff between two attempts.
+	DefaultRetryMaxWait = 30 * time.Second
+
+	// DefaultRetryDeadline bounds the total time spent on one request,
+	// including all of its retries.
+	DefaultRetryDeadline = 5 * time.Minute
+
+	defaultRetryMinWait = time.Second
+)
+
+// retryPolicy decides whether and when a request is sent again.
+type retryPolicy struct {
+	maxRetries int
+	minWait    time.Duration
+	maxWait    time.Duration
+	deadline   time.Duration
+}
+
+func defaultRetryPolicy() retryPolicy {
+	return retryPolicy{
+		maxRetries: DefaultMaxRetries,
+		minWait:    defaultRetryMinWait,
+		maxWait:    DefaultRetryMaxWait,
+		deadline:   DefaultRetryDeadline,
+	}
+}
+
+// MaxRetries sets how many times a failed request is retried. Zero disables
+// retries.
+func MaxRetries(retries int) Option {
+	return func(client *Client) {
+		if retries >= 0 {
+			client.retry.maxRetries = retries
+		}
+	}
+}
+
+// RetryMaxWait caps the backoff between two attempts of the same request.
+func RetryMaxWait(wait time.Duration) Option {
+	return func(client *Client) {
+		if wait > 0 {
+			client.retry.maxWait = wait
+		}
+	}
+}
+
+var (
+	jitterMu   sync.Mutex
+	jitterRand = rand.New(rand.NewSource(time.Now().UnixNano()))
+)
+
+func jitter(n time.Duration) time.Duration {
+	if n <= 0 {
+		return 0
+	}
+	jitterMu.Lock()
+	defer jitterMu.Unlock()
+	return time.Duration(jitterRand.Int63n(int64(n)))
+}
+
+// isIdempotent reports whether a request can be repeated without the risk of
+// applying it twice.
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// shouldRetry reports whether the outcome of an attempt warrants another one.
+// Rate limited and conflicting requests were rejected before being applied,
+// so they are retried for every method. Network errors and gateway errors
+// may hide an applied request and are only retried for idempotent methods.
+func (p retryPolicy) shouldRetry(method string, resp *http.Response, err error) bool {
+	if err != nil {
+		return isIdempotent(method)
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusConflict:
+		return true
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return isIdempotent(method)
+	}
+	return false
+}
+
+// backoff returns how long to wait before the given (zero based) retry. A
+// server supplied Retry-After or Requestlimitrate header takes precedence over
+// the exponential backoff.
+func (p retryPolicy) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if wait, ok := retryAfter(resp); ok {
+			return wait
+		}
+	}
+	wait := p.maxWait
+	if attempt < 32 {
+		if exp := p.minWait << uint(attempt); exp > 0 && exp < p.maxWait {
+			wait = exp
+		}
+	}
+	// Equal jitter keeps at least half of the backoff while spreading
+	// parallel clients apart.
+	return wait/2 + jitter(wait/2)
+}
+
+// retryAfter extracts the wait requested by the server, if any.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	if value := resp.Header.Get("Retry-After"); value != "" {
+		if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+			return time.Duration(seconds) * time.Second, true
+		}
+		if date, err := http.ParseTime(value); err == nil {
+			wait := time.Until(date)
+			if wait < 0 {
+				wait = 0
+			}
+			return wait, true
+		}
+	}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		// Requestlimitrate is the number of requests per second the
+		// account may send.
+		rate, err := strconv.ParseFloat(resp.Header.Get("Requestlimitrate"), 64)
+		if err == nil && rate > 0 && !math.IsInf(rate, 0) && !math.IsNaN(rate) {
+			return time.Duration(float64(time.Second) / rate), true
+		}
+	}
+	return 0, false
+}
+
+// do sends the request described by method, url and payload, retrying it
+// according to the client's retry policy. A fresh security token is computed
+// for every attempt. The response of the last attempt is returned.
+func (c *Client) do(ctx context.Context, method, url string, payload []byte) (*http.Response, error) {
+	start := time.Now()
+	for attempt := 0; ; attempt++ {
+		req, err := c.makeRequest(ctx, method, url, payload)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := c.httpclient.Do(req)
+		if attempt >= c.retry.maxRetries || ctx.Err() != nil || !c.retry.shouldRetry(method, resp, err) {
+			return resp, err
+		}
+
+		wait := c.retry.backoff(attempt, resp)
+		if time.Since(start)+wait > c.retry.deadline {
+			return resp, err
+		}
+		if resp != nil {
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
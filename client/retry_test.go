@@ -0,0 +1,140 @@
+package client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newRetryTestClient returns a client for server whose backoff is short
+// enough for unit tests.
+func newRetryTestClient(t *testing.T, server *httptest.Server, options ...Option) *Client {
+	c, err := GetClient("apikey", "secretkey", append([]Option{BaseUrl(server.URL)}, options...)...)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	c.retry.minWait = time.Millisecond
+	c.retry.maxWait = 5 * time.Millisecond
+	return c
+}
+
+// newStatusServer answers the first len(statuses) requests with the given
+// status codes and every later request with 200.
+func newStatusServer(calls *int32, header http.Header, statuses ...int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(atomic.AddInt32(calls, 1))
+		if n <= len(statuses) {
+			for k, v := range header {
+				w.Header()[k] = v
+			}
+			w.WriteHeader(statuses[n-1])
+			w.Write([]byte(`{"errors":["try again"]}`))
+			return
+		}
+		w.Write([]byte(`[{"id":1}]`))
+	}))
+}
+
+func TestRetryIdempotentOnGatewayErrors(t *testing.T) {
+	var calls int32
+	server := newStatusServer(&calls, nil, 502, 503, 504)
+	defer server.Close()
+
+	c := newRetryTestClient(t, server)
+	if _, err := c.GetbyId("v1/domains"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if calls != 4 {
+		t.Errorf("expected 4 attempts, got %d", calls)
+	}
+}
+
+func TestRetryDoesNotRepeatNonIdempotentOnGatewayErrors(t *testing.T) {
+	var calls int32
+	server := newStatusServer(&calls, nil, 503)
+	defer server.Close()
+
+	c := newRetryTestClient(t, server)
+	if _, err := c.Save(map[string]string{}, "v1/domains"); err == nil {
+		t.Fatalf("expected an error")
+	}
+	if calls != 1 {
+		t.Errorf("expected a single attempt, got %d", calls)
+	}
+}
+
+func TestRetryRateLimitedAndConflictingWrites(t *testing.T) {
+	var calls int32
+	server := newStatusServer(&calls, nil, 429, 409)
+	defer server.Close()
+
+	c := newRetryTestClient(t, server)
+	if _, err := c.Save(map[string]string{}, "v1/domains"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if calls != 3 {
+		t.Errorf("expected 3 attempts, got %d", calls)
+	}
+}
+
+func TestRetryIsBounded(t *testing.T) {
+	var calls int32
+	server := newStatusServer(&calls, nil, 429, 429, 429, 429, 429)
+	defer server.Close()
+
+	c := newRetryTestClient(t, server, MaxRetries(2))
+	if err := c.DeletebyId("v1/domains/1"); err == nil {
+		t.Fatalf("expected an error once retries are exhausted")
+	}
+	if calls != 3 {
+		t.Errorf("expected 3 attempts, got %d", calls)
+	}
+}
+
+func TestRetryHonorsDeadline(t *testing.T) {
+	var calls int32
+	header := http.Header{"Retry-After": []string{"3600"}}
+	server := newStatusServer(&calls, header, 429)
+	defer server.Close()
+
+	c := newRetryTestClient(t, server)
+	start := time.Now()
+	if _, err := c.GetbyId("v1/domains"); err == nil {
+		t.Fatalf("expected an error when the wait exceeds the deadline")
+	}
+	if calls != 1 || time.Since(start) > time.Second {
+		t.Errorf("expected to give up immediately, got %d attempts after %s", calls, time.Since(start))
+	}
+}
+
+func TestRetryAfterHeaders(t *testing.T) {
+	cases := []struct {
+		header   http.Header
+		expected time.Duration
+		ok       bool
+	}{
+		{http.Header{"Retry-After": []string{"7"}}, 7 * time.Second, true},
+		{http.Header{"Requestlimitrate": []string{"4"}}, 250 * time.Millisecond, true},
+		{http.Header{"Requestlimitrate": []string{"0"}}, 0, false},
+		{http.Header{}, 0, false},
+	}
+	for _, tc := range cases {
+		resp := &http.Response{StatusCode: http.StatusTooManyRequests, Header: tc.header}
+		wait, ok := retryAfter(resp)
+		if wait != tc.expected || ok != tc.ok {
+			t.Errorf("retryAfter(%v) = %s, %t; expected %s, %t", tc.header, wait, ok, tc.expected, tc.ok)
+		}
+	}
+}
+
+func TestRetryBackoffIsCapped(t *testing.T) {
+	p := defaultRetryPolicy()
+	for attempt := 0; attempt < 64; attempt++ {
+		wait := p.backoff(attempt, nil)
+		if wait < 0 || wait > p.maxWait {
+			t.Fatalf("backoff(%d) = %s is outside [0, %s]", attempt, wait, p.maxWait)
+		}
+	}
+}
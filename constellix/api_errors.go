@@ -0,0 +1,10 @@
+package constellix
+
+import (
+	"github.com/terraform-providers/terraform-provider-constellix/client"
+)
+
+// isNotFound reports whether err is a 404 answer of the API. It is a shorthand
+// for client.IsNotFound: many resources name their API client variable
+// "client", which shadows the package.
+var isNotFound = client.IsNotFound
@@ -1,19 +1,19 @@
 package constellix
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"io/ioutil"
 	"strconv"
 
-	"github.com/Constellix/constellix-go-client/client"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/terraform-providers/terraform-provider-constellix/client"
 )
 
 func datasourceConstellixARecordPool() *schema.Resource {
 	return &schema.Resource{
 
-		Read: dataSourceARecordPoolRead,
+		ReadContext: dataSourceARecordPoolRead,
 
 		Schema: map[string]*schema.Schema{
 
@@ -75,7 +75,7 @@ func datasourceConstellixARecordPool() *schema.Resource {
 						},
 
 						"disable_flag": &schema.Schema{
-							Type:     schema.TypeString,
+							Type:     schema.TypeBool,
 							Optional: true,
 							Computed: true,
 						},
@@ -95,25 +95,16 @@ func datasourceConstellixARecordPool() *schema.Resource {
 	}
 }
 
-func dataSourceARecordPoolRead(d *schema.ResourceData, m interface{}) error {
+func dataSourceARecordPoolRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*client.Client)
 	name := d.Get("name").(string)
 
-	resp, err := client.GetbyId("v1/pools/A/")
-	if err != nil {
-		return err
-	}
-	bodybytes, err := ioutil.ReadAll(resp.Body)
+	data, err := client.ListContext(ctx, "v1/pools/A/")
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	bodystring := string(bodybytes)
 	var flag bool
-	var data []interface{}
-	var tp map[string]interface{}
-	json.Unmarshal([]byte(bodystring), &data)
-	for _, val := range data {
-		tp = val.(map[string]interface{})
+	for _, tp := range data {
 		if tp["name"] == name {
 			flag = true
 
@@ -124,7 +115,7 @@ func dataSourceARecordPoolRead(d *schema.ResourceData, m interface{}) error {
 			d.Set("note", tp["note"])
 			d.Set("version", tp["version"])
 			d.Set("failed_flag", tp["failedFlag"])
-			d.Set("disable_flag_1", tp["disableFlag"])
+			d.Set("disable_flag_1", toBool(tp["disableFlag"]))
 			resrr := (tp["values"]).([]interface{})
 			mapListRR := make([]interface{}, 0, 1)
 			for _, val := range resrr {
@@ -132,8 +123,8 @@ func dataSourceARecordPoolRead(d *schema.ResourceData, m interface{}) error {
 				inner := val.(map[string]interface{})
 				tpMap["value"] = fmt.Sprintf("%v", inner["value"])
 				tpMap["weight"], _ = strconv.Atoi(fmt.Sprintf("%v", inner["weight"]))
-				tpMap["disable_flag"] = fmt.Sprintf("%v", inner["disableFlag"])
-				tpMap["policy"] = fmt.Sprintf("%v", inner["policy"])
+				tpMap["disable_flag"] = toBool(inner["disableFlag"])
+				tpMap["policy"] = toStringValue(inner["policy"])
 				tpMap["check_id"], _ = strconv.Atoi(fmt.Sprintf("%v", inner["checkId"]))
 
 				mapListRR = append(mapListRR, tpMap)
@@ -143,7 +134,7 @@ func dataSourceARecordPoolRead(d *schema.ResourceData, m interface{}) error {
 	}
 
 	if flag == false {
-		return fmt.Errorf("ARecord Pool named:%v is not available", name)
+		return diag.Errorf("ARecord Pool named:%v is not available", name)
 	}
 	return nil
 
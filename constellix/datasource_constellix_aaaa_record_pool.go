@@ -1,18 +1,18 @@
 package constellix
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"io/ioutil"
 	"strconv"
 
-	"github.com/Constellix/constellix-go-client/client"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/terraform-providers/terraform-provider-constellix/client"
 )
 
 func datasourceConstellixAAAArecordpool() *schema.Resource {
 	return &schema.Resource{
-		Read: datasourceConstellixAAAArecordpoolRead,
+		ReadContext: datasourceConstellixAAAArecordpoolRead,
 
 		Schema: map[string]*schema.Schema{
 			"name": &schema.Schema{
@@ -89,27 +89,17 @@ func datasourceConstellixAAAArecordpool() *schema.Resource {
 	}
 }
 
-func datasourceConstellixAAAArecordpoolRead(d *schema.ResourceData, m interface{}) error {
+func datasourceConstellixAAAArecordpoolRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*client.Client)
 	name := d.Get("name").(string)
 
-	resp, err := client.GetbyId("v1/pools/AAAA")
+	data, err := client.ListContext(ctx, "v1/pools/AAAA")
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	bodybytes, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return err
-	}
-	bodystring := string(bodybytes)
-
-	var data []interface{}
-	json.Unmarshal([]byte(bodystring), &data)
 
-	var tp map[string]interface{}
 	var flag bool
-	for _, val := range data {
-		tp = val.(map[string]interface{})
+	for _, tp := range data {
 		if name == tp["name"].(string) {
 			flag = true
 			d.SetId(fmt.Sprintf("%v", tp["id"]))
@@ -117,7 +107,7 @@ func datasourceConstellixAAAArecordpoolRead(d *schema.ResourceData, m interface{
 			d.Set("num_return", tp["numReturn"])
 			d.Set("min_available_failover", tp["minAvailableFailover"])
 			d.Set("failed_flag", tp["failedFlag"])
-			d.Set("disable_flag", tp["disableFlag"])
+			d.Set("disable_flag", toBool(tp["disableFlag"]))
 			d.Set("note", tp["note"])
 
 			resrr := (tp["values"]).([]interface{})
@@ -127,8 +117,8 @@ func datasourceConstellixAAAArecordpoolRead(d *schema.ResourceData, m interface{
 				inner := val.(map[string]interface{})
 				tpMap["value"] = fmt.Sprintf("%v", inner["value"])
 				tpMap["weight"], _ = strconv.Atoi(fmt.Sprintf("%v", inner["weight"]))
-				tpMap["disable_flag"] = fmt.Sprintf("%v", inner["disableFlag"])
-				tpMap["policy"] = fmt.Sprintf("%v", inner["policy"])
+				tpMap["disable_flag"] = toBool(inner["disableFlag"])
+				tpMap["policy"] = toStringValue(inner["policy"])
 				tpMap["check_id"], _ = strconv.Atoi(fmt.Sprintf("%v", inner["checkId"]))
 
 				mapListRR = append(mapListRR, tpMap)
@@ -138,7 +128,7 @@ func datasourceConstellixAAAArecordpoolRead(d *schema.ResourceData, m interface{
 		}
 	}
 	if flag != true {
-		return fmt.Errorf("AAAA record pool of specified name is not available")
+		return diag.Errorf("AAAA record pool of specified name is not available")
 	}
 	return nil
 }
@@ -1,18 +1,18 @@
 package constellix
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"io/ioutil"
 	"strconv"
 
-	"github.com/Constellix/constellix-go-client/client"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/terraform-providers/terraform-provider-constellix/client"
 )
 
 func datasourceConstellixCnamerecordPool() *schema.Resource {
 	return &schema.Resource{
-		Read: datasourceConstellixCnamerecordPoolRead,
+		ReadContext: datasourceConstellixCnamerecordPoolRead,
 
 		Schema: map[string]*schema.Schema{
 			"name": &schema.Schema{
@@ -92,27 +92,17 @@ func datasourceConstellixCnamerecordPool() *schema.Resource {
 	}
 }
 
-func datasourceConstellixCnamerecordPoolRead(d *schema.ResourceData, m interface{}) error {
+func datasourceConstellixCnamerecordPoolRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*client.Client)
 	name := d.Get("name").(string)
 
-	resp, err := client.GetbyId("v1/pools/CNAME")
+	data, err := client.ListContext(ctx, "v1/pools/CNAME")
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	bodybytes, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return err
-	}
-	bodystring := string(bodybytes)
-
-	var data []interface{}
-	json.Unmarshal([]byte(bodystring), &data)
 
-	var tp map[string]interface{}
 	var flag bool
-	for _, val := range data {
-		tp = val.(map[string]interface{})
+	for _, tp := range data {
 		if name == tp["name"].(string) {
 			flag = true
 
@@ -123,7 +113,7 @@ func datasourceConstellixCnamerecordPoolRead(d *schema.ResourceData, m interface
 			d.Set("note", tp["note"])
 			d.Set("version", tp["version"])
 			d.Set("failed_flag", tp["failedFlag"])
-			d.Set("disable_flag", tp["disableFlag"])
+			d.Set("disable_flag", toBool(tp["disableFlag"]))
 			resrr := (tp["values"]).([]interface{})
 			mapListRR := make([]interface{}, 0, 1)
 			for _, val := range resrr {
@@ -131,8 +121,8 @@ func datasourceConstellixCnamerecordPoolRead(d *schema.ResourceData, m interface
 				inner := val.(map[string]interface{})
 				tpMap["value"] = fmt.Sprintf("%v", inner["value"])
 				tpMap["weight"], _ = strconv.Atoi(fmt.Sprintf("%v", inner["weight"]))
-				tpMap["disable_flag"] = fmt.Sprintf("%v", inner["disableFlag"])
-				tpMap["policy"] = fmt.Sprintf("%v", inner["policy"])
+				tpMap["disable_flag"] = toBool(inner["disableFlag"])
+				tpMap["policy"] = toStringValue(inner["policy"])
 				tpMap["check_id"], _ = strconv.Atoi(fmt.Sprintf("%v", inner["checkId"]))
 
 				mapListRR = append(mapListRR, tpMap)
@@ -143,7 +133,7 @@ func datasourceConstellixCnamerecordPoolRead(d *schema.ResourceData, m interface
 	}
 
 	if flag != true {
-		return fmt.Errorf("CNAME record pool of specified name is not available")
+		return diag.Errorf("CNAME record pool of specified name is not available")
 	}
 	return nil
 }
@@ -1,18 +1,18 @@
 package constellix
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"io/ioutil"
 
-	"github.com/Constellix/constellix-go-client/client"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/terraform-providers/terraform-provider-constellix/client"
 )
 
 func datasourceConstellixContactList() *schema.Resource {
 	return &schema.Resource{
 
-		Read: datasourceConstellixContactListRead,
+		ReadContext: datasourceConstellixContactListRead,
 
 		Schema: map[string]*schema.Schema{
 			"name": &schema.Schema{
@@ -29,26 +29,16 @@ func datasourceConstellixContactList() *schema.Resource {
 	}
 }
 
-func datasourceConstellixContactListRead(d *schema.ResourceData, m interface{}) error {
+func datasourceConstellixContactListRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*client.Client)
 	name := d.Get("name").(string)
-	resp, err := client.GetbyId("v2/contactLists")
+	data, err := client.ListContext(ctx, "v2/contactLists")
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	bodybytes, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return err
-	}
-	bodystring := string(bodybytes)
-	var data []interface{}
-	json.Unmarshal([]byte(bodystring), &data)
 	var flag bool
 
-	var tp map[string]interface{}
-
-	for _, val := range data {
-		tp = val.(map[string]interface{})
+	for _, tp := range data {
 		if tp["name"] == name {
 			flag = true
 			d.SetId(fmt.Sprintf("%.0f", tp["id"]))
@@ -58,7 +48,7 @@ func datasourceConstellixContactListRead(d *schema.ResourceData, m interface{})
 	}
 
 	if flag == false {
-		return (fmt.Errorf("Cert record with name:%v,is not present", name))
+		return diag.Errorf("Cert record with name:%v,is not present", name)
 	}
 	return nil
 
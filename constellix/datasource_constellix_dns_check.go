@@ -1,18 +1,18 @@
 package constellix
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"io/ioutil"
 
-	"github.com/Constellix/constellix-go-client/client"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/terraform-providers/terraform-provider-constellix/client"
 )
 
 func datasourceConstellixDNSCheck() *schema.Resource {
 	return &schema.Resource{
-		Read: datasourceConstellixDNSCheckRead,
+		ReadContext: datasourceConstellixDNSCheckRead,
 
 		Schema: map[string]*schema.Schema{
 			"name": &schema.Schema{
@@ -96,27 +96,18 @@ func datasourceConstellixDNSCheck() *schema.Resource {
 	}
 }
 
-func datasourceConstellixDNSCheckRead(d *schema.ResourceData, m interface{}) error {
+func datasourceConstellixDNSCheckRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	constellixClient := m.(*client.Client)
 	name := d.Get("name").(string)
 
-	resp, err := constellixClient.GetbyId("https://api.sonar.constellix.com/rest/api/dns/")
+	data, err := constellixClient.ListContext(ctx, constellixClient.SonarEndpoint("rest/api/dns/"))
 	if err != nil {
-		return err
-	}
-	bodyBytes, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	bodyString := string(bodyBytes)
-	var data []interface{}
 	var flag bool
-	var tp map[string]interface{}
-	json.Unmarshal([]byte(bodyString), &data)
 
-	for _, val := range data {
-		tp = val.(map[string]interface{})
+	for _, tp := range data {
 		if tp["name"].(string) == name {
 			flag = true
 
@@ -134,7 +125,7 @@ func datasourceConstellixDNSCheckRead(d *schema.ResourceData, m interface{}) err
 		}
 	}
 	if flag == false {
-		return (fmt.Errorf("DNS Check with specified name is not present"))
+		return diag.Errorf("DNS Check with specified name is not present")
 	}
 	return nil
 }
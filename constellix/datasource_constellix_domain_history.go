@@ -0,0 +1,94 @@
+package constellix
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/terraform-providers/terraform-provider-constellix/client"
+)
+
+func datasourceConstellixDomainHistory() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: datasourceConstellixDomainHistoryRead,
+
+		Schema: map[string]*schema.Schema{
+			"domain_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"latest_version": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"versions": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"version": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"timestamp": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"record_count": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"snapshot": &schema.Schema{
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func datasourceConstellixDomainHistoryRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	constellixClient := m.(*client.Client)
+	domainID := d.Get("domain_id").(string)
+
+	snapshots, err := constellixClient.ListContext(ctx, "v1/domains/"+domainID+"/snapshots")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	snapshotted := make(map[int]bool, len(snapshots))
+	for _, snapshot := range snapshots {
+		snapshotted[toIntValue(snapshot["version"])] = true
+	}
+
+	history, err := constellixClient.ListContext(ctx, "v1/domains/"+domainID+"/history")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	latest := 0
+	versions := make([]interface{}, 0, len(history))
+	for _, entry := range history {
+		version := toIntValue(entry["version"])
+		if version > latest {
+			latest = version
+		}
+		versions = append(versions, map[string]interface{}{
+			"version":      version,
+			"timestamp":    toStringValue(entry["updatedTs"]),
+			"record_count": toIntValue(entry["recordCount"]),
+			"snapshot":     snapshotted[version],
+		})
+	}
+
+	d.SetId(domainID)
+	if err := d.Set("latest_version", latest); err != nil {
+		return diag.Errorf("setting latest_version: %s", err)
+	}
+	if err := d.Set("versions", versions); err != nil {
+		return diag.Errorf("setting versions: %s", err)
+	}
+	return nil
+}
@@ -0,0 +1,188 @@
+package constellix
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/terraform-providers/terraform-provider-constellix/client"
+)
+
+func datasourceConstellixDomains() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: datasourceConstellixDomainsRead,
+
+		Schema: map[string]*schema.Schema{
+			"name_glob": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: func(v interface{}, k string) ([]string, []error) {
+					if _, err := path.Match(v.(string), ""); err != nil {
+						return nil, []error{fmt.Errorf("%s is not a valid glob: %s", k, err)}
+					}
+					return nil, nil
+				},
+			},
+
+			"tag": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"template": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+
+			"disabled": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+
+			"ids": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"names": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"domains": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"disabled": &schema.Schema{
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"note": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"tags": &schema.Schema{
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"template": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"vanity_nameserver": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"soa": computedSchema(soaSchema()),
+					},
+				},
+			},
+		},
+	}
+}
+
+func datasourceConstellixDomainsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	constellixClient := m.(*client.Client)
+	glob := strings.ToLower(d.Get("name_glob").(string))
+	template := d.Get("template").(int)
+
+	// disabled filters only when it is configured, since false is a filter
+	// too.
+	disabled := d.Get("disabled").(bool)
+	config := d.GetRawConfig()
+	disabledSet := !config.IsNull() && !config.GetAttr("disabled").IsNull()
+
+	tagID := ""
+	if tag, ok := d.GetOk("tag"); ok {
+		var err error
+		if tagID, err = findTagID(ctx, constellixClient, tag.(string)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	found, err := constellixClient.ListContext(ctx, "v1/domains")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	ids := make([]interface{}, 0)
+	names := make([]interface{}, 0)
+	domains := make([]interface{}, 0)
+	for _, domain := range found {
+		name := toStringValue(domain["name"])
+		if glob != "" {
+			if matched, _ := path.Match(glob, strings.ToLower(name)); !matched {
+				continue
+			}
+		}
+		if disabledSet && toBool(domain["disabled"]) != disabled {
+			continue
+		}
+		if template != 0 && toIntValue(domain["template"]) != template {
+			continue
+		}
+		tags := make([]interface{}, 0)
+		elems, _ := domain["tags"].([]interface{})
+		hasTag := false
+		for _, elem := range elems {
+			tags = append(tags, toStringValue(elem))
+			hasTag = hasTag || toStringValue(elem) == tagID
+		}
+		if tagID != "" && !hasTag {
+			continue
+		}
+
+		id := toStringValue(domain["id"])
+		ids = append(ids, id)
+		names = append(names, name)
+		domains = append(domains, map[string]interface{}{
+			"id":                id,
+			"name":              name,
+			"disabled":          toBool(domain["disabled"]),
+			"note":              toStringValue(domain["note"]),
+			"tags":              tags,
+			"template":          toIntValue(domain["template"]),
+			"vanity_nameserver": toStringValue(domain["vanityNameServer"]),
+			"soa":               flattenSOA(domain["soa"], ""),
+		})
+	}
+
+	d.SetId("domains")
+	for name, value := range map[string]interface{}{"ids": ids, "names": names, "domains": domains} {
+		if err := d.Set(name, value); err != nil {
+			return diag.Errorf("setting %s: %s", name, err)
+		}
+	}
+	return nil
+}
+
+// findTagID returns the ID of the tag named name.
+func findTagID(ctx context.Context, constellixClient *client.Client, name string) (string, error) {
+	it := constellixClient.Iterate(ctx, "v2/tags")
+	for it.Next() {
+		if tag := it.Object(); tag["name"] == name {
+			return toStringValue(tag["id"]), nil
+		}
+	}
+	if err := it.Err(); err != nil {
+		return "", err
+	}
+	return "", fmt.Errorf("tag %s is not present", name)
+}
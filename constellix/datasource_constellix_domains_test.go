@@ -0,0 +1,107 @@
+package constellix
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestConstellixDomainsDataSource(t *testing.T) {
+	f := newFakeAPI(t)
+	tag := func(name string) string {
+		if _, err := f.client.Save(map[string]interface{}{"name": name}, "v2/tags"); err != nil {
+			t.Fatalf("creating tag %s: %s", name, err)
+		}
+		tags := datasourceConstellixTags()
+		d := schema.TestResourceDataRaw(t, tags.Schema, map[string]interface{}{"name": name})
+		if diags := tags.ReadContext(context.Background(), d, f.client); diags.HasError() {
+			t.Fatalf("finding tag %s: %v", name, diags)
+		}
+		return d.Id()
+	}
+	tag("unused")
+	tagID := tag("team-web")
+	templateID, _ := strconv.Atoi(f.create("v1/templates", map[string]interface{}{"name": []string{"web"}}))
+
+	web := f.domain("web.example.com")
+	shop := f.domain("shop.example.com")
+	api := f.domain("api.example.net")
+	for id, update := range map[string]map[string]interface{}{
+		web:  {"tags": []interface{}{tagID}, "template": templateID},
+		shop: {"tags": []interface{}{tagID}, "disabled": true, "vanityNameServer": 5},
+	} {
+		if _, err := f.client.UpdatebyID(update, "v1/domains/"+id); err != nil {
+			t.Fatalf("updating domain %s: %s", id, err)
+		}
+	}
+
+	cases := map[string]struct {
+		config   map[string]interface{}
+		expected []string
+	}{
+		"all":          {map[string]interface{}{}, []string{web, shop, api}},
+		"glob":         {map[string]interface{}{"name_glob": "*.EXAMPLE.com"}, []string{web, shop}},
+		"tag":          {map[string]interface{}{"tag": "team-web"}, []string{web, shop}},
+		"unused tag":   {map[string]interface{}{"tag": "unused"}, []string{}},
+		"template":     {map[string]interface{}{"template": templateID}, []string{web}},
+		"disabled":     {map[string]interface{}{"disabled": true}, []string{shop}},
+		"not disabled": {map[string]interface{}{"disabled": false}, []string{web, api}},
+		"combined":     {map[string]interface{}{"tag": "team-web", "disabled": false, "name_glob": "w*"}, []string{web}},
+	}
+	ds := f.provider.DataSourcesMap["constellix_domains"]
+	for name, tc := range cases {
+		d, diags := f.read(ds, tc.config)
+		if diags.HasError() {
+			t.Errorf("%s: read failed: %v", name, diags)
+			continue
+		}
+		found := []string{}
+		for _, id := range d.Get("ids").([]interface{}) {
+			found = append(found, id.(string))
+		}
+		if !reflect.DeepEqual(found, tc.expected) {
+			t.Errorf("%s: expected %v, got %v", name, tc.expected, found)
+		}
+	}
+
+	d := schema.TestResourceDataRaw(t, ds.Schema, map[string]interface{}{"name_glob": "shop.*"})
+	if diags := ds.ReadContext(context.Background(), d, f.client); diags.HasError() {
+		t.Fatalf("read failed: %v", diags)
+	}
+	if names := d.Get("names").([]interface{}); !reflect.DeepEqual(names, []interface{}{"shop.example.com"}) {
+		t.Errorf("expected the name of shop.example.com, got %v", names)
+	}
+	for attr, expected := range map[string]string{
+		"domains.0.disabled":          "true",
+		"domains.0.tags.0":            tagID,
+		"domains.0.template":          "0",
+		"domains.0.vanity_nameserver": "5",
+		"domains.0.soa.0.email":       "dns.constellix.com.",
+		"domains.0.soa.0.negcache":    "180",
+	} {
+		if got := d.State().Attributes[attr]; got != expected {
+			t.Errorf("expected %s to be %q, got %q", attr, expected, got)
+		}
+	}
+
+	d = schema.TestResourceDataRaw(t, ds.Schema, map[string]interface{}{"tag": "missing"})
+	if diags := ds.ReadContext(context.Background(), d, f.client); !diags.HasError() {
+		t.Errorf("expected an unknown tag to be refused")
+	}
+}
+
+func TestConstellixDomainsDataSourceValidation(t *testing.T) {
+	ds := datasourceConstellixDomains()
+	for name, config := range map[string]map[string]interface{}{
+		"invalid glob":     {"name_glob": "[a-"},
+		"invalid template": {"template": 0},
+	} {
+		if diags := ds.Validate(terraform.NewResourceConfigRaw(config)); !diags.HasError() {
+			t.Errorf("%s: expected validation to fail", name)
+		}
+	}
+}
@@ -1,18 +1,18 @@
 package constellix
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"io/ioutil"
 	"log"
 
-	"github.com/Constellix/constellix-go-client/client"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/terraform-providers/terraform-provider-constellix/client"
 )
 
 func datasourceConstellixIPFilter() *schema.Resource {
 	return &schema.Resource{
-		Read: datasourceConstellixIPFilterRead,
+		ReadContext: datasourceConstellixIPFilterRead,
 
 		Schema: map[string]*schema.Schema{
 			"name": &schema.Schema{
@@ -71,25 +71,7 @@ func datasourceConstellixIPFilter() *schema.Resource {
 				Type:     schema.TypeMap,
 				Optional: true,
 				Computed: true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"continentcode": &schema.Schema{
-							Type:     schema.TypeString,
-							Optional: true,
-							Computed: true,
-						},
-						"countrycode": &schema.Schema{
-							Type:     schema.TypeString,
-							Optional: true,
-							Computed: true,
-						},
-						"regioncode": &schema.Schema{
-							Type:     schema.TypeString,
-							Optional: true,
-							Computed: true,
-						},
-					},
-				},
+				Elem:     &schema.Schema{Type: schema.TypeString},
 			},
 			"filterruleslimit": &schema.Schema{
 				Type:     schema.TypeInt,
@@ -100,26 +82,16 @@ func datasourceConstellixIPFilter() *schema.Resource {
 	}
 }
 
-func datasourceConstellixIPFilterRead(d *schema.ResourceData, m interface{}) error {
+func datasourceConstellixIPFilterRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	constellixClient := m.(*client.Client)
 	name1 := d.Get("name").(string)
 
-	resp, err := constellixClient.GetbyId("v1/geoFilters")
-	if err != nil {
-		return err
-	}
-
-	bodyBytes, err := ioutil.ReadAll(resp.Body)
+	data, err := constellixClient.ListContext(ctx, "v1/geoFilters")
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	bodyString := string(bodyBytes)
-	var data []interface{}
-	var temp map[string]interface{}
 	var flag bool
-	json.Unmarshal([]byte(bodyString), &data)
-	for _, val := range data {
-		temp = val.(map[string]interface{})
+	for _, temp := range data {
 		if temp["name"].(string) == name1 {
 			flag = true
 			resrr := temp["regions"].([]interface{})
@@ -172,7 +144,7 @@ func datasourceConstellixIPFilterRead(d *schema.ResourceData, m interface{}) err
 	}
 
 	if flag == false {
-		return fmt.Errorf("The ipfilter with the name %v is not present", name1)
+		return diag.Errorf("The ipfilter with the name %v is not present", name1)
 	}
 
 	return nil
@@ -1,18 +1,18 @@
 package constellix
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"io/ioutil"
 
-	"github.com/Constellix/constellix-go-client/client"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/terraform-providers/terraform-provider-constellix/client"
 )
 
 func datasourceConstellixGeoProximity() *schema.Resource {
 	return &schema.Resource{
 
-		Read: datasourceConstellixGeoProximityRead,
+		ReadContext: datasourceConstellixGeoProximityRead,
 
 		Schema: map[string]*schema.Schema{
 			"name": &schema.Schema{
@@ -53,27 +53,18 @@ func datasourceConstellixGeoProximity() *schema.Resource {
 	}
 }
 
-func datasourceConstellixGeoProximityRead(d *schema.ResourceData, m interface{}) error {
+func datasourceConstellixGeoProximityRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*client.Client)
 	name := d.Get("name").(string)
 
-	resp, err := client.GetbyId("v1/geoProximities")
+	data, err := client.ListContext(ctx, "v1/geoProximities")
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	bodybytes, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return err
-	}
-	bodystring := string(bodybytes)
 
-	var data []interface{}
-	var tp map[string]interface{}
 	var flag bool
-	json.Unmarshal([]byte(bodystring), &data)
 
-	for _, val := range data {
-		tp = val.(map[string]interface{})
+	for _, tp := range data {
 		if tp["name"] == name {
 			flag = true
 			d.SetId(fmt.Sprintf("%v", tp["id"]))
@@ -86,7 +77,7 @@ func datasourceConstellixGeoProximityRead(d *schema.ResourceData, m interface{})
 	}
 
 	if flag == false {
-		return (fmt.Errorf("Cert record with name:%v,is not present", name))
+		return diag.Errorf("Cert record with name:%v,is not present", name)
 	}
 	return nil
 }
@@ -1,18 +1,18 @@
 package constellix
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"io/ioutil"
 
-	"github.com/Constellix/constellix-go-client/client"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/terraform-providers/terraform-provider-constellix/client"
 )
 
 func datasourceConstellixHTTPCheck() *schema.Resource {
 	return &schema.Resource{
-		Read: datasourceConstellixHTTPCheckRead,
+		ReadContext: datasourceConstellixHTTPCheckRead,
 
 		Schema: map[string]*schema.Schema{
 			"name": &schema.Schema{
@@ -123,27 +123,17 @@ func datasourceConstellixHTTPCheck() *schema.Resource {
 	}
 }
 
-func datasourceConstellixHTTPCheckRead(d *schema.ResourceData, m interface{}) error {
+func datasourceConstellixHTTPCheckRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*client.Client)
 	name := d.Get("name").(string)
 
-	resp, err := client.GetbyId("https://api.sonar.constellix.com/rest/api/http")
+	data, err := client.ListContext(ctx, client.SonarEndpoint("rest/api/http"))
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	bodybytes, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return err
-	}
-	bodystring := string(bodybytes)
-
-	var data []interface{}
-	json.Unmarshal([]byte(bodystring), &data)
 
-	var tp map[string]interface{}
 	var flag bool
-	for _, val := range data {
-		tp = val.(map[string]interface{})
+	for _, tp := range data {
 		if name == tp["name"].(string) {
 			flag = true
 			d.SetId(fmt.Sprintf("%v", tp["id"]))
@@ -165,7 +155,7 @@ func datasourceConstellixHTTPCheckRead(d *schema.ResourceData, m interface{}) er
 		}
 	}
 	if flag != true {
-		return fmt.Errorf("HTTP check of specified name is not found")
+		return diag.Errorf("HTTP check of specified name is not found")
 	}
 	return nil
 }
@@ -0,0 +1,224 @@
+package constellix
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/terraform-providers/terraform-provider-constellix/client"
+)
+
+// recordTypeNames are the record types of the provider by the name of the
+// type in the API, in the order the records data source lists them.
+var recordTypeNames = []struct {
+	name string
+	rt   *recordType
+}{
+	{"A", aRecord},
+	{"AAAA", aaaaRecord},
+	{"ANAME", anameRecord},
+	{"CAA", caaRecord},
+	{"CERT", certRecord},
+	{"CNAME", cnameRecord},
+	{"HINFO", hinfoRecord},
+	{"HTTPRedirection", httpRedirectionRecord},
+	{"HTTPS", httpsRecord},
+	{"MX", mxRecord},
+	{"NAPTR", naptrRecord},
+	{"NS", nsRecord},
+	{"PTR", ptrRecord},
+	{"RP", rpRecord},
+	{"SPF", spfRecord},
+	{"SRV", srvRecord},
+	{"SSHFP", sshfpRecord},
+	{"SVCB", svcbRecord},
+	{"TLSA", tlsaRecord},
+	{"TXT", txtRecord},
+}
+
+func datasourceConstellixRecords() *schema.Resource {
+	typeNames := make([]string, 0, len(recordTypeNames))
+	for _, t := range recordTypeNames {
+		typeNames = append(typeNames, t.name)
+	}
+
+	return &schema.Resource{
+		ReadContext: datasourceConstellixRecordsRead,
+
+		Schema: map[string]*schema.Schema{
+			"domain_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"source_type": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{"domains", "templates"}, false),
+			},
+
+			"type": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice(typeNames, true),
+			},
+
+			"name_regex": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+
+			"ttl_min": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+
+			"ttl_max": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+
+			"note_contains": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"records": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"import_id": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"ttl": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"note": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"noanswer": &schema.Schema{
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func datasourceConstellixRecordsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	constellixClient := m.(*client.Client)
+	sourceType := d.Get("source_type").(string)
+	domainID := d.Get("domain_id").(string)
+	typeName := d.Get("type").(string)
+	noteContains := d.Get("note_contains").(string)
+
+	var nameRegex *regexp.Regexp
+	if expr, ok := d.GetOk("name_regex"); ok {
+		nameRegex = regexp.MustCompile(expr.(string))
+	}
+	// A TTL bound of 0 is a bound too, so it is told from an unset one in
+	// the configuration.
+	config := d.GetRawConfig()
+	hasTTLMin := !config.IsNull() && !config.GetAttr("ttl_min").IsNull()
+	hasTTLMax := !config.IsNull() && !config.GetAttr("ttl_max").IsNull()
+	ttlMin, ttlMax := d.Get("ttl_min").(int), d.Get("ttl_max").(int)
+	if hasTTLMin && hasTTLMax && ttlMin > ttlMax {
+		return diag.Errorf("ttl_min (%d) is greater than ttl_max (%d)", ttlMin, ttlMax)
+	}
+
+	records := make([]interface{}, 0)
+	var notFound error
+	listed := 0
+	for _, t := range recordTypeNames {
+		if typeName != "" && !strings.EqualFold(typeName, t.name) {
+			continue
+		}
+		found, err := listRecords(ctx, constellixClient, "v1/"+sourceType+"/"+domainID+"/records/"+t.rt.path)
+		if err != nil {
+			// A type the API does not serve holds no records, unless it
+			// was asked for.
+			if typeName == "" && client.IsNotFound(err) {
+				notFound = err
+				continue
+			}
+			return diag.FromErr(err)
+		}
+		listed++
+		for _, record := range found {
+			name := toStringValue(record["name"])
+			ttl := toIntValue(record["ttl"])
+			note := toStringValue(record["note"])
+			if nameRegex != nil && !nameRegex.MatchString(name) {
+				continue
+			}
+			if hasTTLMin && ttl < ttlMin || hasTTLMax && ttl > ttlMax {
+				continue
+			}
+			if noteContains != "" && !strings.Contains(note, noteContains) {
+				continue
+			}
+			id := toStringValue(record["id"])
+			records = append(records, map[string]interface{}{
+				"id":        id,
+				"import_id": sourceType + ":" + domainID + ":" + id,
+				"type":      t.name,
+				"name":      name,
+				"ttl":       ttl,
+				"note":      note,
+				"noanswer":  toBool(record["noAnswer"]),
+			})
+		}
+	}
+
+	// No type at all is found when the domain itself is missing.
+	if listed == 0 && notFound != nil {
+		return diag.FromErr(notFound)
+	}
+
+	d.SetId(sourceType + ":" + domainID)
+	if err := d.Set("records", records); err != nil {
+		return diag.Errorf("setting records: %s", err)
+	}
+	return nil
+}
+
+// listRecords returns the records at endpoint, ordered by name and then ID.
+func listRecords(ctx context.Context, constellixClient *client.Client, endpoint string) ([]map[string]interface{}, error) {
+	records, err := constellixClient.ListContext(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(records, func(i, j int) bool {
+		ni, nj := toStringValue(records[i]["name"]), toStringValue(records[j]["name"])
+		if ni != nj {
+			return ni < nj
+		}
+		return toIntValue(records[i]["id"]) < toIntValue(records[j]["id"])
+	})
+	return records, nil
+}
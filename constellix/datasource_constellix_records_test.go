@@ -0,0 +1,148 @@
+package constellix
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestConstellixRecordsDataSource(t *testing.T) {
+	f := newFakeAPI(t)
+	domainID := f.domain("example.com")
+	records := "v1/domains/" + domainID + "/records/"
+	www := f.create(records+"a", map[string]interface{}{"name": "www", "ttl": 300, "note": "web frontend"})
+	api := f.create(records+"a", map[string]interface{}{"name": "api", "ttl": 60})
+	mx := f.create(records+"mx", map[string]interface{}{"name": "", "ttl": 3600, "note": "mail"})
+	txt := f.create(records+"txt", map[string]interface{}{"name": "www", "ttl": 86400})
+	templateID := f.create("v1/templates", map[string]interface{}{"name": []string{"web"}})
+	f.create("v1/templates/"+templateID+"/records/cname", map[string]interface{}{"name": "www", "ttl": 300})
+
+	cases := map[string]struct {
+		config   map[string]interface{}
+		expected []string
+	}{
+		"all":           {map[string]interface{}{}, []string{"A " + api, "A " + www, "MX " + mx, "TXT " + txt}},
+		"type":          {map[string]interface{}{"type": "a"}, []string{"A " + api, "A " + www}},
+		"name regex":    {map[string]interface{}{"name_regex": "^w+$"}, []string{"A " + www, "TXT " + txt}},
+		"apex":          {map[string]interface{}{"name_regex": "^$"}, []string{"MX " + mx}},
+		"ttl range":     {map[string]interface{}{"ttl_min": 100, "ttl_max": 3600}, []string{"A " + www, "MX " + mx}},
+		"ttl minimum":   {map[string]interface{}{"ttl_min": 3600}, []string{"MX " + mx, "TXT " + txt}},
+		"ttl zero":      {map[string]interface{}{"ttl_max": 0}, []string{}},
+		"note":          {map[string]interface{}{"note_contains": "web"}, []string{"A " + www}},
+		"combined":      {map[string]interface{}{"type": "TXT", "name_regex": "www"}, []string{"TXT " + txt}},
+		"nothing found": {map[string]interface{}{"type": "CAA"}, []string{}},
+	}
+	ds := f.provider.DataSourcesMap["constellix_records"]
+	for name, tc := range cases {
+		config := map[string]interface{}{"domain_id": domainID, "source_type": "domains"}
+		for k, v := range tc.config {
+			config[k] = v
+		}
+		d, diags := f.read(ds, config)
+		if diags.HasError() {
+			t.Errorf("%s: read failed: %v", name, diags)
+			continue
+		}
+		found := []string{}
+		for _, record := range d.Get("records").([]interface{}) {
+			record := record.(map[string]interface{})
+			found = append(found, record["type"].(string)+" "+record["id"].(string))
+		}
+		if !reflect.DeepEqual(found, tc.expected) {
+			t.Errorf("%s: expected %v, got %v", name, tc.expected, found)
+		}
+	}
+
+	d := schema.TestResourceDataRaw(t, ds.Schema, map[string]interface{}{
+		"domain_id":   templateID,
+		"source_type": "templates",
+	})
+	if diags := ds.ReadContext(context.Background(), d, f.client); diags.HasError() {
+		t.Fatalf("template: read failed: %v", diags)
+	}
+	found := d.Get("records").([]interface{})
+	if len(found) != 1 {
+		t.Fatalf("template: expected one record, got %v", found)
+	}
+	record := found[0].(map[string]interface{})
+	if record["type"] != "CNAME" || record["import_id"] != "templates:"+templateID+":"+record["id"].(string) {
+		t.Errorf("template: unexpected record %v", record)
+	}
+}
+
+func TestConstellixRecordsDataSourceSkipsMissingTypes(t *testing.T) {
+	f := newFakeAPI(t)
+	domainID := f.domain("example.com")
+	records := "v1/domains/" + domainID + "/records/"
+	www := f.create(records+"a", map[string]interface{}{"name": "www", "ttl": 300})
+	f.server.NotFound(records + "https")
+
+	ds := f.provider.DataSourcesMap["constellix_records"]
+	d := schema.TestResourceDataRaw(t, ds.Schema, map[string]interface{}{"domain_id": domainID, "source_type": "domains"})
+	if diags := ds.ReadContext(context.Background(), d, f.client); diags.HasError() {
+		t.Fatalf("read failed: %v", diags)
+	}
+	found := d.Get("records").([]interface{})
+	if len(found) != 1 || found[0].(map[string]interface{})["id"] != www {
+		t.Errorf("expected only A record %s, got %v", www, found)
+	}
+
+	// A type asked for must be served.
+	d = schema.TestResourceDataRaw(t, ds.Schema, map[string]interface{}{"domain_id": domainID, "source_type": "domains", "type": "HTTPS"})
+	if diags := ds.ReadContext(context.Background(), d, f.client); !diags.HasError() {
+		t.Errorf("expected reading missing type HTTPS to fail")
+	}
+
+	// So must the domain.
+	d = schema.TestResourceDataRaw(t, ds.Schema, map[string]interface{}{"domain_id": "999999", "source_type": "domains"})
+	if diags := ds.ReadContext(context.Background(), d, f.client); !diags.HasError() {
+		t.Errorf("expected reading the records of a missing domain to fail")
+	}
+}
+
+func TestConstellixRecordsDataSourceValidation(t *testing.T) {
+	ds := datasourceConstellixRecords()
+	cases := map[string]map[string]interface{}{
+		"unknown type":       {"type": "LOC"},
+		"invalid regex":      {"name_regex": "("},
+		"negative ttl":       {"ttl_min": -1},
+		"unknown sourcetype": {"source_type": "zones"},
+	}
+	for name, args := range cases {
+		config := map[string]interface{}{"domain_id": "1", "source_type": "domains"}
+		for k, v := range args {
+			config[k] = v
+		}
+		if diags := ds.Validate(terraform.NewResourceConfigRaw(config)); !diags.HasError() {
+			t.Errorf("%s: expected validation to fail", name)
+		}
+	}
+
+	f := newFakeAPI(t)
+	_, diags := f.read(ds, map[string]interface{}{
+		"domain_id":   f.domain("example.com"),
+		"source_type": "domains",
+		"ttl_min":     600,
+		"ttl_max":     60,
+	})
+	if !diags.HasError() {
+		t.Errorf("expected an inverted TTL range to be refused")
+	}
+}
+
+// TestRecordTypeNames checks that the records data source lists every record
+// type of the provider.
+func TestRecordTypeNames(t *testing.T) {
+	listed := make(map[*recordType]bool)
+	for _, named := range recordTypeNames {
+		listed[named.rt] = true
+	}
+	for name, rt := range recordTypes {
+		if !listed[rt] {
+			t.Errorf("%s is not listed by the records data source", name)
+		}
+	}
+}
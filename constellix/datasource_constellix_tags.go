@@ -1,17 +1,17 @@
 package constellix
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"io/ioutil"
 
-	"github.com/Constellix/constellix-go-client/client"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/terraform-providers/terraform-provider-constellix/client"
 )
 
 func datasourceConstellixTags() *schema.Resource {
 	return &schema.Resource{
-		Read: datasourceConstellixTagsRead,
+		ReadContext: datasourceConstellixTagsRead,
 
 		Schema: map[string]*schema.Schema{
 			"name": &schema.Schema{
@@ -22,27 +22,17 @@ func datasourceConstellixTags() *schema.Resource {
 	}
 }
 
-func datasourceConstellixTagsRead(d *schema.ResourceData, m interface{}) error {
+func datasourceConstellixTagsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*client.Client)
 	name := d.Get("name").(string)
 
-	resp, err := client.GetbyId("v2/tags")
+	data, err := client.ListContext(ctx, "v2/tags")
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	bodybytes, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return err
-	}
-	bodystring := string(bodybytes)
-
-	var data []interface{}
-	json.Unmarshal([]byte(bodystring), &data)
 
-	var tp map[string]interface{}
 	var flag bool
-	for _, val := range data {
-		tp = val.(map[string]interface{})
+	for _, tp := range data {
 		if name == tp["name"].(string) {
 			flag = true
 			d.SetId(fmt.Sprintf("%v", tp["id"]))
@@ -51,7 +41,7 @@ func datasourceConstellixTagsRead(d *schema.ResourceData, m interface{}) error {
 	}
 
 	if flag != true {
-		return fmt.Errorf("Tag record for the specified name is not available")
+		return diag.Errorf("Tag record for the specified name is not available")
 	}
 	return nil
 }
@@ -1,18 +1,18 @@
 package constellix
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"io/ioutil"
 
-	"github.com/Constellix/constellix-go-client/client"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/terraform-providers/terraform-provider-constellix/client"
 )
 
 func datasourceConstellixTCPCheck() *schema.Resource {
 	return &schema.Resource{
-		Read: datasourceConstellixTCPCheckRead,
+		ReadContext: datasourceConstellixTCPCheckRead,
 
 		Schema: map[string]*schema.Schema{
 			"name": &schema.Schema{
@@ -105,27 +105,17 @@ func datasourceConstellixTCPCheck() *schema.Resource {
 	}
 }
 
-func datasourceConstellixTCPCheckRead(d *schema.ResourceData, m interface{}) error {
+func datasourceConstellixTCPCheckRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*client.Client)
 	name := d.Get("name").(string)
 
-	resp, err := client.GetbyId("https://api.sonar.constellix.com/rest/api/tcp")
+	data, err := client.ListContext(ctx, client.SonarEndpoint("rest/api/tcp"))
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	bodybytes, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return err
-	}
-	bodystring := string(bodybytes)
-
-	var data []interface{}
-	json.Unmarshal([]byte(bodystring), &data)
 
-	var tp map[string]interface{}
 	var flag bool
-	for _, val := range data {
-		tp = val.(map[string]interface{})
+	for _, tp := range data {
 		if name == tp["name"].(string) {
 			flag = true
 			d.SetId(fmt.Sprintf("%v", tp["id"]))
@@ -144,7 +134,7 @@ func datasourceConstellixTCPCheckRead(d *schema.ResourceData, m interface{}) err
 		}
 	}
 	if flag != true {
-		return fmt.Errorf("TCP check of specified name is not found")
+		return diag.Errorf("TCP check of specified name is not found")
 	}
 	return nil
 }
@@ -1,17 +1,17 @@
 package constellix
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"io/ioutil"
 
-	"github.com/Constellix/constellix-go-client/client"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/terraform-providers/terraform-provider-constellix/client"
 )
 
 func datasourceConstellixTemplate() *schema.Resource {
 	return &schema.Resource{
-		Read: datasourceConstellixTemplateRead,
+		ReadContext: datasourceConstellixTemplateRead,
 
 		Schema: map[string]*schema.Schema{
 			"name": &schema.Schema{
@@ -43,27 +43,17 @@ func datasourceConstellixTemplate() *schema.Resource {
 	}
 }
 
-func datasourceConstellixTemplateRead(d *schema.ResourceData, m interface{}) error {
+func datasourceConstellixTemplateRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*client.Client)
 	name := d.Get("name").(string)
 
-	resp, err := client.GetbyId("v1/templates")
+	data, err := client.ListContext(ctx, "v1/templates")
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	bodyBytes, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return err
-	}
-	bodyString := string(bodyBytes)
-
-	var data []interface{}
-	json.Unmarshal([]byte(bodyString), &data)
 
-	var tp map[string]interface{}
 	var flag bool
-	for _, val := range data {
-		tp = val.(map[string]interface{})
+	for _, tp := range data {
 		if name == tp["name"].(string) {
 			flag = true
 			d.SetId(fmt.Sprintf("%v", tp["id"]))
@@ -75,7 +65,7 @@ func datasourceConstellixTemplateRead(d *schema.ResourceData, m interface{}) err
 		}
 	}
 	if flag != true {
-		return fmt.Errorf("Template of specified name is not available")
+		return diag.Errorf("Template of specified name is not available")
 	}
 	return nil
 }
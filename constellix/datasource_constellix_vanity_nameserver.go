@@ -1,17 +1,17 @@
 package constellix
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"io/ioutil"
 
-	"github.com/Constellix/constellix-go-client/client"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/terraform-providers/terraform-provider-constellix/client"
 )
 
 func datasourceConstellixVanityNameserver() *schema.Resource {
 	return &schema.Resource{
-		Read: datasourceConstellixVanityNameserverRead,
+		ReadContext: datasourceConstellixVanityNameserverRead,
 
 		Schema: map[string]*schema.Schema{
 			"name": &schema.Schema{
@@ -52,27 +52,17 @@ func datasourceConstellixVanityNameserver() *schema.Resource {
 	}
 }
 
-func datasourceConstellixVanityNameserverRead(d *schema.ResourceData, m interface{}) error {
+func datasourceConstellixVanityNameserverRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*client.Client)
 	name := d.Get("name").(string)
 
-	resp, err := client.GetbyId("v1/vanityNameservers")
+	data, err := client.ListContext(ctx, "v1/vanityNameservers")
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	bodybytes, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return err
-	}
-	bodystring := string(bodybytes)
-
-	var data []interface{}
-	json.Unmarshal([]byte(bodystring), &data)
 
-	var tp map[string]interface{}
 	var flag bool
-	for _, val := range data {
-		tp = val.(map[string]interface{})
+	for _, tp := range data {
 		if name == tp["name"].(string) {
 			flag = true
 			d.SetId(fmt.Sprintf("%v", tp["id"]))
@@ -86,7 +76,7 @@ func datasourceConstellixVanityNameserverRead(d *schema.ResourceData, m interfac
 	}
 
 	if flag != true {
-		return fmt.Errorf("Vanity Nameserver of specified name is not available")
+		return diag.Errorf("Vanity Nameserver of specified name is not available")
 	}
 	return nil
 }
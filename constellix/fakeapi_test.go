@@ -0,0 +1,277 @@
+package constellix
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/terraform-providers/terraform-provider-constellix/client"
+	"github.com/terraform-providers/terraform-provider-constellix/internal/fakeapi"
+)
+
+// fakeAPI is a provider configured against an in-memory Constellix API.
+type fakeAPI struct {
+	t        *testing.T
+	server   *fakeapi.Server
+	provider *schema.Provider
+	client   *client.Client
+}
+
+func newFakeAPI(t *testing.T) *fakeAPI {
+	server := fakeapi.NewServer()
+	t.Cleanup(server.Close)
+
+	provider := Provider()
+	diags := provider.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"apikey":         fakeapi.APIKey,
+		"secretkey":      fakeapi.SecretKey,
+		"api_endpoint":   server.URL,
+		"sonar_endpoint": server.URL,
+		"max_retries":    0,
+	}))
+	if diags.HasError() {
+		t.Fatalf("configuring the provider: %v", diags)
+	}
+	// Small pages make every list of the tests span several of them.
+	client.PageSize(2)(provider.Meta().(*client.Client))
+	return &fakeAPI{
+		t:        t,
+		server:   server,
+		provider: provider,
+		client:   provider.Meta().(*client.Client),
+	}
+}
+
+// create stores obj in the collection at endpoint and returns its ID, for
+// setting up objects a test case depends on.
+func (f *fakeAPI) create(endpoint string, obj interface{}) string {
+	resp, err := f.client.Save(obj, endpoint)
+	if err != nil {
+		f.t.Fatalf("creating %s: %s", endpoint, err)
+	}
+	defer resp.Body.Close()
+	body, _ := ioutil.ReadAll(resp.Body)
+	var created []map[string]interface{}
+	if err := json.Unmarshal(body, &created); err != nil || len(created) == 0 {
+		f.t.Fatalf("creating %s: unexpected response %s", endpoint, body)
+	}
+	return fmt.Sprintf("%.0f", created[0]["id"])
+}
+
+// domain creates a domain and returns its ID.
+func (f *fakeAPI) domain(name string) string {
+	return f.create("v1/domains", map[string]interface{}{"names": []string{name}})
+}
+
+// lifecycleTestCase describes one run of a resource through its whole life.
+type lifecycleTestCase struct {
+	resource string
+	// create and update are the configurations applied in turn.
+	create, update map[string]interface{}
+	// importID returns the ID to import the resource with; the ID of the
+	// resource is used when it is nil.
+	importID func(s *terraform.InstanceState) string
+	// dataSource, when set, holds the arguments of a data source of the
+	// same name that must find the resource after it is updated.
+	dataSource map[string]interface{}
+	// ignore lists attributes that are not compared with the configuration,
+	// because the API or the provider rewrites them.
+	ignore []string
+}
+
+// lifecycle creates, refreshes, updates, imports and deletes a resource and
+// checks after every step that the state matches the configuration.
+func (f *fakeAPI) lifecycle(tc lifecycleTestCase) {
+	f.t.Helper()
+	r, ok := f.provider.ResourcesMap[tc.resource]
+	if !ok {
+		f.t.Fatalf("unknown resource %s", tc.resource)
+	}
+
+	state := f.apply(r, nil, tc.create)
+	if state == nil || state.ID == "" {
+		f.t.Fatalf("%s: expected an ID after create", tc.resource)
+	}
+	f.checkState("create", tc, state, tc.create)
+
+	state = f.refresh(r, state)
+	if state == nil {
+		f.t.Fatalf("%s: resource disappeared after create", tc.resource)
+	}
+	f.checkState("refresh", tc, state, tc.create)
+	f.checkNoChanges(r, state, tc.create)
+
+	id := state.ID
+	state = f.apply(r, state, tc.update)
+	if state.ID != id {
+		f.t.Errorf("%s: expected update in place, ID changed from %s to %s", tc.resource, id, state.ID)
+	}
+	f.checkState("update", tc, state, tc.update)
+	f.checkNoChanges(r, state, tc.update)
+
+	importID := state.ID
+	if tc.importID != nil {
+		importID = tc.importID(state)
+	}
+	imported, err := r.Importer.StateContext(context.Background(), r.Data(&terraform.InstanceState{ID: importID}), f.client)
+	if err != nil || len(imported) != 1 {
+		f.t.Fatalf("%s: import of %s failed: %v", tc.resource, importID, err)
+	}
+	importedState := f.refresh(r, imported[0].State())
+	if importedState == nil || importedState.ID != state.ID {
+		f.t.Fatalf("%s: expected import of %s to find %s, got %v", tc.resource, importID, state.ID, importedState)
+	}
+	f.checkState("import", tc, importedState, tc.update)
+
+	if tc.dataSource != nil {
+		ds := f.provider.DataSourcesMap[tc.resource]
+		d := schema.TestResourceDataRaw(f.t, ds.Schema, tc.dataSource)
+		if diags := ds.ReadContext(context.Background(), d, f.client); diags.HasError() {
+			f.t.Fatalf("%s: data source read failed: %v", tc.resource, diags)
+		}
+		if d.Id() != state.ID {
+			f.t.Errorf("%s: expected data source to find %s, got %q", tc.resource, state.ID, d.Id())
+		}
+	}
+
+	if state = f.apply(r, state, nil); state != nil {
+		f.t.Fatalf("%s: expected no state after destroy, got %v", tc.resource, state)
+	}
+	gone := f.refresh(r, importedState)
+	if gone != nil {
+		f.t.Errorf("%s: expected %s to be gone from the API after destroy", tc.resource, importedState.ID)
+	}
+}
+
+// apply plans config against state and applies the plan. A nil config
+// destroys the resource.
+func (f *fakeAPI) apply(r *schema.Resource, state *terraform.InstanceState, config map[string]interface{}) *terraform.InstanceState {
+	f.t.Helper()
+	ctx := context.Background()
+	var diff *terraform.InstanceDiff
+	if config == nil {
+		diff = &terraform.InstanceDiff{Destroy: true}
+	} else {
+		var err error
+		diff, err = f.plan(r, state, config)
+		if err != nil {
+			f.t.Fatalf("planning: %s", err)
+		}
+		if diff == nil {
+			return state
+		}
+	}
+	newState, diags := r.Apply(ctx, state, diff, f.client)
+	if diags.HasError() {
+		f.t.Fatalf("applying: %v", diags)
+	}
+	return newState
+}
+
+// plan diffs config against state. Like Terraform, it passes the
+// configuration as written along with the prior state, even of a resource
+// yet to be created.
+func (f *fakeAPI) plan(r *schema.Resource, state *terraform.InstanceState, config map[string]interface{}) (*terraform.InstanceDiff, error) {
+	f.t.Helper()
+	prior := &terraform.InstanceState{}
+	if state != nil {
+		prior = state.DeepCopy()
+	}
+	rawConfig, err := schema.JSONMapToStateValue(config, r.CoreConfigSchema())
+	if err != nil {
+		f.t.Fatalf("converting the configuration: %s", err)
+	}
+	prior.RawConfig = rawConfig
+	return r.Diff(context.Background(), prior, terraform.NewResourceConfigRaw(config), f.client)
+}
+
+// read reads the data source ds with config as Terraform does, passing the
+// configuration as written along.
+func (f *fakeAPI) read(ds *schema.Resource, config map[string]interface{}) (*schema.ResourceData, diag.Diagnostics) {
+	f.t.Helper()
+	diff, err := f.plan(ds, nil, config)
+	if err != nil {
+		f.t.Fatalf("planning: %s", err)
+	}
+	d, err := schema.InternalMap(ds.Schema).Data(nil, diff)
+	if err != nil {
+		f.t.Fatalf("reading the plan: %s", err)
+	}
+	return d, ds.ReadContext(context.Background(), d, f.client)
+}
+
+// checkNoChanges fails the test when planning config against state, as
+// Terraform does after every apply, would change anything.
+func (f *fakeAPI) checkNoChanges(r *schema.Resource, state *terraform.InstanceState, config map[string]interface{}) {
+	f.t.Helper()
+	diff, err := f.plan(r, state, config)
+	if err != nil {
+		f.t.Fatalf("planning: %s", err)
+	}
+	if diff != nil && !diff.Empty() {
+		changed := make([]string, 0, len(diff.Attributes))
+		for key, attr := range diff.Attributes {
+			changed = append(changed, fmt.Sprintf("%s: %q => %q", key, attr.Old, attr.New))
+		}
+		sort.Strings(changed)
+		f.t.Errorf("expected no changes, got:\n%s", strings.Join(changed, "\n"))
+	}
+}
+
+func (f *fakeAPI) refresh(r *schema.Resource, state *terraform.InstanceState) *terraform.InstanceState {
+	f.t.Helper()
+	newState, diags := r.RefreshWithoutUpgrade(context.Background(), state, f.client)
+	if diags.HasError() {
+		f.t.Fatalf("refreshing: %v", diags)
+	}
+	return newState
+}
+
+// checkState compares the top-level arguments of config with the attributes
+// in state. Scalars must be equal and lists, sets and maps must have the same
+// number of elements.
+func (f *fakeAPI) checkState(step string, tc lifecycleTestCase, state *terraform.InstanceState, config map[string]interface{}) {
+	f.t.Helper()
+	ignored := make(map[string]bool)
+	for _, key := range tc.ignore {
+		ignored[key] = true
+	}
+	keys := make([]string, 0, len(config))
+	for key := range config {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if ignored[key] {
+			continue
+		}
+		var attr, expected string
+		switch value := config[key].(type) {
+		case []interface{}:
+			attr, expected = key+".#", fmt.Sprint(len(value))
+		case []map[string]interface{}:
+			attr, expected = key+".#", fmt.Sprint(len(value))
+		case map[string]interface{}:
+			attr, expected = key+".%", fmt.Sprint(len(value))
+		default:
+			attr, expected = key, fmt.Sprint(value)
+		}
+		if actual := state.Attributes[attr]; actual != expected {
+			f.t.Errorf("%s: after %s expected %s = %q, got %q", tc.resource, step, attr, expected, actual)
+		}
+	}
+}
+
+// recordImportID returns the source_type:domain_id:record_id import ID of a
+// record.
+func recordImportID(s *terraform.InstanceState) string {
+	return strings.Join([]string{s.Attributes["source_type"], s.Attributes["domain_id"], s.ID}, ":")
+}
@@ -0,0 +1,147 @@
+package constellix
+
+import (
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Hostnames are case insensitive, and the API may answer with the fully
+// qualified form of a name sent relative to the domain of a record, so the
+// hostname arguments of records and pools treat these spellings as the same
+// hostname:
+//
+//   - values are sent in lower case, with their trailing dot, if any, kept as
+//     it decides whether the API appends the domain name;
+//   - plans ignore changes of case, also in sets, whose elements are hashed
+//     with their hostnames in lower case, but not of the trailing dot, which
+//     points the record elsewhere;
+//   - reads keep the spelling of the configuration when the API answers with
+//     the same hostname, or with the configured relative name qualified with
+//     the name of the domain, and store the answer in lower case otherwise,
+//     relative to the domain when nothing is configured, as on import.
+
+// canonicalHostname returns the form spellings of a hostname are compared in:
+// lower case and without surrounding spaces.
+func canonicalHostname(hostname string) string {
+	return strings.ToLower(strings.TrimSpace(hostname))
+}
+
+// sameHostname reports whether configured and answered spell the same
+// hostname. A configured name without trailing dot is relative, and also
+// the same as answered when answered is that name qualified with the domain
+// zone returns the name of. zone is only called for such answers, and may be
+// nil where names are not relative to a domain.
+func sameHostname(configured, answered string, zone func() string) bool {
+	configured, answered = canonicalHostname(configured), canonicalHostname(answered)
+	if configured == answered {
+		return true
+	}
+	if configured == "" || strings.HasSuffix(configured, ".") || !strings.HasPrefix(answered, configured+".") {
+		return false
+	}
+	return relativeHostname(answered, zone) == configured
+}
+
+// relativeHostname returns hostname relative to the domain zone returns the
+// name of, or "" when it is not qualified with it or zone is nil.
+func relativeHostname(hostname string, zone func() string) string {
+	if zone == nil || !strings.HasSuffix(hostname, ".") {
+		return ""
+	}
+	domain := strings.TrimSuffix(canonicalHostname(zone()), ".")
+	if domain == "" {
+		return ""
+	}
+	hostname = canonicalHostname(hostname)
+	if !strings.HasSuffix(hostname, "."+domain+".") {
+		return ""
+	}
+	return strings.TrimSuffix(hostname, "."+domain+".")
+}
+
+// normalizeHostname returns the spelling of a hostname argument sent to the
+// API.
+func normalizeHostname(value interface{}) interface{} {
+	return strings.ToLower(strings.TrimSpace(toStringValue(value)))
+}
+
+// suppressEquivalentHostname ignores changes between spellings of the same
+// hostname.
+func suppressEquivalentHostname(k, old, new string, d *schema.ResourceData) bool {
+	return canonicalHostname(old) == canonicalHostname(new)
+}
+
+// hostnameSchema returns a copy of attr which ignores changes between
+// spellings of the same hostname.
+func hostnameSchema(attr *schema.Schema) *schema.Schema {
+	s := *attr
+	s.DiffSuppressFunc = suppressEquivalentHostname
+	return &s
+}
+
+// hashWithHostnames returns the hash function of a set of elem whose fields
+// listed in hostnames hold hostnames. Elements are hashed like
+// schema.HashResource does, with their hostnames in canonical form, so the
+// same hostname spelled differently is the same element.
+func hashWithHostnames(elem *schema.Resource, hostnames ...string) schema.SchemaSetFunc {
+	hash := schema.HashResource(elem)
+	return func(v interface{}) int {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return hash(v)
+		}
+		canonical := make(map[string]interface{}, len(m))
+		for k, value := range m {
+			canonical[k] = value
+		}
+		for _, name := range hostnames {
+			if hostname, ok := canonical[name].(string); ok {
+				canonical[name] = canonicalHostname(hostname)
+			}
+		}
+		return hash(canonical)
+	}
+}
+
+// keepHostnameSpelling returns configured when it spells the same hostname
+// as answered, and answered in lower case otherwise, relative to the domain
+// when nothing is configured.
+func keepHostnameSpelling(configured, answered string, zone func() string) string {
+	if configured != "" && sameHostname(configured, answered, zone) {
+		return configured
+	}
+	if relative := relativeHostname(answered, zone); configured == "" && relative != "" {
+		return relative
+	}
+	return strings.ToLower(answered)
+}
+
+// keepHostnameSpellings rewrites the hostnames in the named field of the
+// answered elements of a set or list with the spelling the configured
+// elements use for them.
+func keepHostnameSpellings(answered []interface{}, configured interface{}, name string, zone func() string) {
+	var spellings []string
+	for _, elem := range configuredValues(configured) {
+		if inner, ok := elem.(map[string]interface{}); ok {
+			if hostname, ok := inner[name].(string); ok && hostname != "" {
+				spellings = append(spellings, hostname)
+			}
+		}
+	}
+	for _, elem := range answered {
+		inner := elem.(map[string]interface{})
+		hostname, ok := inner[name].(string)
+		if !ok {
+			continue
+		}
+		spelling := ""
+		for _, configured := range spellings {
+			if sameHostname(configured, hostname, zone) {
+				spelling = configured
+				break
+			}
+		}
+		inner[name] = keepHostnameSpelling(spelling, hostname, zone)
+	}
+}
@@ -0,0 +1,263 @@
+package constellix
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestHostnameEquivalence(t *testing.T) {
+	for _, spelling := range []string{"www.example.com.", "WWW.Example.COM.", " www.example.com. "} {
+		if canonical := canonicalHostname(spelling); canonical != "www.example.com." {
+			t.Errorf("expected %q to be www.example.com., got %q", spelling, canonical)
+		}
+		if !suppressEquivalentHostname("host", "www.example.com.", spelling, nil) {
+			t.Errorf("expected a change to %q to be suppressed", spelling)
+		}
+	}
+	if suppressEquivalentHostname("host", "www.example.com.", "www.example.net.", nil) {
+		t.Errorf("expected a change of host name not to be suppressed")
+	}
+	if suppressEquivalentHostname("host", "mail", "mail.", nil) {
+		t.Errorf("expected a change of the trailing dot not to be suppressed")
+	}
+	if v := normalizeHostname("WWW.Example.com."); v != "www.example.com." {
+		t.Errorf("expected the trailing dot to be sent, got %q", v)
+	}
+
+	zone := func() string { return "Example.com" }
+	for _, tc := range []struct {
+		configured, answered, read string
+	}{
+		{"WWW.Example.com.", "www.example.com.", "WWW.Example.com."},
+		{"WWW", "www.example.com.", "WWW"},
+		{"www.example.com", "Origin.Example.net.", "origin.example.net."},
+		{"www.example.com", "www.example.com.", "www.example.com."},
+		{"www", "www.example.net.", "www.example.net."},
+		{"www", "www.", "www."},
+		{"", "WWW.Example.com.", "www"},
+		{"", "www.example.net.", "www.example.net."},
+	} {
+		if v := keepHostnameSpelling(tc.configured, tc.answered, zone); v != tc.read {
+			t.Errorf("expected %q answered for %q to be read as %q, got %q", tc.answered, tc.configured, tc.read, v)
+		}
+	}
+	if v := keepHostnameSpelling("www", "www.example.com.", nil); v != "www.example.com." {
+		t.Errorf("expected a name outside a domain not to be qualified, got %q", v)
+	}
+	answered := []interface{}{
+		map[string]interface{}{"value": "mx1.example.com.", "level": 10},
+		map[string]interface{}{"value": "MX2.example.com", "level": 20},
+	}
+	roundRobin := mxRecord.roundRobin.schema()
+	keepHostnameSpellings(answered, schema.NewSet(roundRobin.Set, []interface{}{
+		map[string]interface{}{"value": "MX1", "level": "10", "disable_flag": false},
+	}), "value", zone)
+	if v := answered[0].(map[string]interface{})["value"]; v != "MX1" {
+		t.Errorf("expected the configured spelling of mx1, got %q", v)
+	}
+	if v := answered[1].(map[string]interface{})["value"]; v != "mx2.example.com" {
+		t.Errorf("expected mx2 in lower case, got %q", v)
+	}
+
+	hash := roundRobin.Set
+	a := map[string]interface{}{"value": "MX1.example.com", "level": "10", "disable_flag": false}
+	b := map[string]interface{}{"value": "mx1.example.com", "level": "10", "disable_flag": false}
+	if hash(a) != hash(b) {
+		t.Errorf("expected spellings of the same host name to hash alike")
+	}
+	b["value"] = "mx1.example.com."
+	if hash(a) == hash(b) {
+		t.Errorf("expected host names differing in the trailing dot to hash differently")
+	}
+	b["value"] = "mx1.example.com"
+	b["level"] = "20"
+	if hash(a) == hash(b) {
+		t.Errorf("expected elements with different levels to hash differently")
+	}
+}
+
+// TestHostnameSpellings checks that the hostname arguments of records and
+// pools are sent in lower case, and that neither an answer in another
+// spelling, qualified with the domain for records, nor an import shows as a
+// change.
+func TestHostnameSpellings(t *testing.T) {
+	f := newFakeAPI(t)
+	domainID := f.domain("example.com")
+	records := "v1/domains/" + domainID + "/records/"
+	record := func(name string, args map[string]interface{}) map[string]interface{} {
+		config := map[string]interface{}{"domain_id": domainID, "source_type": "domains", "name": name, "ttl": 300}
+		for key, value := range args {
+			config[key] = value
+		}
+		return config
+	}
+
+	for _, tc := range []struct {
+		resource, collection string
+		config               map[string]interface{}
+		// hostname is the configured spelling of a host name in config.
+		hostname string
+	}{
+		{"constellix_cname_record", records + "cname/", record("docs", map[string]interface{}{
+			"host": "Docs.Example.NET",
+		}), "Docs.Example.NET"},
+		{"constellix_cname_record", records + "cname/", record("backup", map[string]interface{}{
+			"host":          "primary.example.net.",
+			"record_option": "failover",
+			"record_failover_values": []interface{}{
+				map[string]interface{}{"value": "Backup.Example.net", "sort_order": "1", "disable_flag": false},
+			},
+		}), "Backup.Example.net"},
+		{"constellix_aname_record", records + "aname/", record("apex", map[string]interface{}{
+			"roundrobin": []interface{}{map[string]interface{}{"value": "LB.example.net", "disable_flag": false}},
+		}), "LB.example.net"},
+		{"constellix_mx_record", records + "mx/", record("mail", map[string]interface{}{
+			"roundrobin": []interface{}{map[string]interface{}{"value": "MX1.Example.com", "level": "10", "disable_flag": false}},
+		}), "MX1.Example.com"},
+		{"constellix_ns_record", records + "ns/", record("sub", map[string]interface{}{
+			"roundrobin": []interface{}{map[string]interface{}{"value": "NS1.example.net", "disable_flag": false}},
+		}), "NS1.example.net"},
+		{"constellix_ptr_record", records + "ptr/", record("1", map[string]interface{}{
+			"roundrobin": []interface{}{map[string]interface{}{"value": "Host1.Example.com", "disable_flag": false}},
+		}), "Host1.Example.com"},
+		{"constellix_srv_record", records + "srv/", record("_sip._tcp", map[string]interface{}{
+			"roundrobin": []interface{}{map[string]interface{}{"value": "SIP.example.com", "port": 5060, "priority": 10, "weight": 5, "disable_flag": false}},
+		}), "SIP.example.com"},
+		{"constellix_cname_record_pool", "v1/pools/CNAME/", map[string]interface{}{
+			"name":                   "origins",
+			"num_return":             1,
+			"min_available_failover": 1,
+			"values": []interface{}{
+				map[string]interface{}{"value": "Origin1.Example.net", "weight": 10, "policy": "followsonar"},
+			},
+		}, "Origin1.Example.net"},
+	} {
+		r := f.provider.ResourcesMap[tc.resource]
+		state := f.apply(r, nil, tc.config)
+		path := tc.collection + state.ID
+
+		sent := strings.ToLower(tc.hostname)
+		obj, _ := f.server.Object(path)
+		if !hasString(obj, sent) {
+			t.Errorf("%s: expected %q to be sent, got %v", tc.resource, sent, obj)
+		}
+
+		// The API answers records with the name qualified with their
+		// domain, and pools in another case.
+		answered := sent + ".example.com."
+		if !strings.HasSuffix(tc.resource, "_record") {
+			answered = strings.ToUpper(sent)
+		}
+		replaceString(obj, sent, answered)
+		if _, err := f.client.UpdatebyID(obj, path); err != nil {
+			t.Fatalf("%s: rewriting %s: %s", tc.resource, path, err)
+		}
+		state = f.refresh(r, state)
+		if !hasAttribute(state, tc.hostname) {
+			t.Errorf("%s: expected the configured %q to be kept, got %v", tc.resource, tc.hostname, state.Attributes)
+		}
+		f.checkNoChanges(r, state, tc.config)
+
+		importID := state.ID
+		if strings.HasSuffix(tc.resource, "_record") {
+			importID = recordImportID(state)
+		}
+		imported, err := r.Importer.StateContext(context.Background(), r.Data(&terraform.InstanceState{ID: importID}), f.client)
+		if err != nil || len(imported) != 1 {
+			t.Fatalf("%s: import of %s failed: %v", tc.resource, importID, err)
+		}
+		importedState := f.refresh(r, imported[0].State())
+		read := strings.ToLower(answered)
+		if strings.HasSuffix(tc.resource, "_record") {
+			read = sent
+		}
+		if !hasAttribute(importedState, read) {
+			t.Errorf("%s: expected %q to be imported, got %v", tc.resource, read, importedState.Attributes)
+		}
+		f.checkNoChanges(r, importedState, tc.config)
+	}
+}
+
+// TestHostnameDotChange checks that adding the trailing dot to a hostname,
+// which takes it out of the domain of the record, shows as a change.
+func TestHostnameDotChange(t *testing.T) {
+	f := newFakeAPI(t)
+	domainID := f.domain("example.com")
+	r := f.provider.ResourcesMap["constellix_cname_record"]
+	config := map[string]interface{}{"domain_id": domainID, "source_type": "domains", "name": "www", "ttl": 300, "host": "mail"}
+	state := f.apply(r, nil, config)
+
+	obj, _ := f.server.Object("v1/domains/" + domainID + "/records/cname/" + state.ID)
+	replaceString(obj, "mail", "mail.example.com.")
+	if _, err := f.client.UpdatebyID(obj, "v1/domains/"+domainID+"/records/cname/"+state.ID); err != nil {
+		t.Fatalf("rewriting the record: %s", err)
+	}
+	state = f.refresh(r, state)
+	f.checkNoChanges(r, state, config)
+
+	config["host"] = "mail."
+	diff, err := f.plan(r, state, config)
+	if err != nil {
+		t.Fatalf("planning: %s", err)
+	}
+	if diff == nil || diff.Attributes["host"] == nil {
+		t.Errorf("expected mail. to change the host, got %v", diff)
+	}
+}
+
+// hasString reports whether s is one of the strings in v, at any depth.
+func hasString(v interface{}, s string) bool {
+	switch v := v.(type) {
+	case string:
+		return v == s
+	case map[string]interface{}:
+		for _, elem := range v {
+			if hasString(elem, s) {
+				return true
+			}
+		}
+	case []interface{}:
+		for _, elem := range v {
+			if hasString(elem, s) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// replaceString replaces the strings old in the maps and lists of v, at any
+// depth, with new.
+func replaceString(v interface{}, old, new string) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, elem := range v {
+			if elem == old {
+				v[key] = new
+			} else {
+				replaceString(elem, old, new)
+			}
+		}
+	case []interface{}:
+		for i, elem := range v {
+			if elem == old {
+				v[i] = new
+			} else {
+				replaceString(elem, old, new)
+			}
+		}
+	}
+}
+
+func hasAttribute(state *terraform.InstanceState, value string) bool {
+	for _, attr := range state.Attributes {
+		if attr == value {
+			return true
+		}
+	}
+	return false
+}
@@ -4,13 +4,64 @@ import (
 	"github.com/Constellix/constellix-go-client/models"
 )
 
-// DomainAttributes extends the models.DomainAttributes.
+// DomainAttributes extends the models.DomainAttributes with the SOA of the
+// domain sent with integer timers.
 type DomainAttributes struct {
 	models.DomainAttributes
-	Disabled bool `json:"disabled"`
+	Soa      *SOAAttributes `json:"soa,omitempty"`
+	Disabled bool           `json:"disabled"`
+}
+
+// SOAAttributes contains the SOA record of a domain, without its serial,
+// which Constellix maintains.
+type SOAAttributes struct {
+	PrimaryNameServer string `json:"primaryNameserver,omitempty"`
+	Email             string `json:"email,omitempty"`
+	TTL               int    `json:"ttl,omitempty"`
+	Refresh           int    `json:"refresh,omitempty"`
+	Retry             int    `json:"retry,omitempty"`
+	Expire            int    `json:"expire,omitempty"`
+	NegCache          int    `json:"negCache,omitempty"`
 }
 
 // DomainAttributesV4 contains the domain attributes aligned with API v4.
 type DomainAttributesV4 struct {
 	Enabled bool `json:"enabled"`
 }
+
+// DomainDNSSECAttributesV4 enables or disables the DNSSEC of a domain with
+// API v4.
+type DomainDNSSECAttributesV4 struct {
+	DNSSEC bool `json:"dnssec"`
+}
+
+// ARecordPoolAttributes extends the models.ARecordPoolAttributes with the
+// disable flag of the pool sent as a boolean.
+type ARecordPoolAttributes struct {
+	models.ARecordPoolAttributes
+	DisableFlag bool `json:"disableFlag"`
+}
+
+// CnameRecordPoolAttributes extends the models.CnameRecordPoolAttributes with
+// the disable flag of the pool sent as a boolean.
+type CnameRecordPoolAttributes struct {
+	models.CnameRecordPoolAttributes
+	DisableFlag bool `json:"disableFlag"`
+}
+
+// SecondaryDomainAttributes contains the attributes of a secondary domain,
+// a zone transferred from masters outside Constellix.
+type SecondaryDomainAttributes struct {
+	Name        string                  `json:"name"`
+	Masters     []SecondaryDomainMaster `json:"masters"`
+	TSIGKeyID   *int                    `json:"tsigKeyId"`
+	AllowNotify []string                `json:"allowNotify"`
+	Note        string                  `json:"note"`
+	Disabled    bool                    `json:"disabled"`
+}
+
+// SecondaryDomainMaster is a server a secondary domain is transferred from.
+type SecondaryDomainMaster struct {
+	IP   string `json:"ip"`
+	Port int    `json:"port"`
+}
@@ -0,0 +1,62 @@
+package constellix
+
+import (
+	"log"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// domainMutexKV serializes record writes that target the same domain or
+// template. The API answers concurrent writes to one domain with 409 Conflict,
+// while writes to different domains can safely run in parallel.
+var domainMutexKV = newMutexKV()
+
+// mutexKV is a simple key/value store for arbitrary mutexes.
+type mutexKV struct {
+	lock  sync.Mutex
+	store map[string]*sync.Mutex
+}
+
+func newMutexKV() *mutexKV {
+	return &mutexKV{
+		store: make(map[string]*sync.Mutex),
+	}
+}
+
+// Lock locks the mutex for the given key. Caller is responsible for calling
+// Unlock for the same key.
+func (m *mutexKV) Lock(key string) {
+	log.Printf("[DEBUG] Locking %q", key)
+	m.get(key).Lock()
+	log.Printf("[DEBUG] Locked %q", key)
+}
+
+// Unlock unlocks the mutex for the given key. Caller must have called Lock for
+// the same key first.
+func (m *mutexKV) Unlock(key string) {
+	log.Printf("[DEBUG] Unlocking %q", key)
+	m.get(key).Unlock()
+	log.Printf("[DEBUG] Unlocked %q", key)
+}
+
+func (m *mutexKV) get(key string) *sync.Mutex {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	mutex, ok := m.store[key]
+	if !ok {
+		mutex = &sync.Mutex{}
+		m.store[key] = mutex
+	}
+	return mutex
+}
+
+// lockDomain takes the write lock of the domain (or template) a record
+// belongs to and returns the function that releases it.
+func lockDomain(d *schema.ResourceData) func() {
+	key := d.Get("source_type").(string) + "/" + d.Get("domain_id").(string)
+	domainMutexKV.Lock(key)
+	return func() {
+		domainMutexKV.Unlock(key)
+	}
+}
@@ -0,0 +1,69 @@
+package constellix
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func lockTestData(t *testing.T, sourceType, domainID string) *schema.ResourceData {
+	return schema.TestResourceDataRaw(t, map[string]*schema.Schema{
+		"source_type": {Type: schema.TypeString, Required: true},
+		"domain_id":   {Type: schema.TypeString, Required: true},
+	}, map[string]interface{}{"source_type": sourceType, "domain_id": domainID})
+}
+
+// TestLockDomainSerializesWrites checks that writes to the same domain run
+// one at a time.
+func TestLockDomainSerializesWrites(t *testing.T) {
+	var active, most int32
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		d := lockTestData(t, "domains", "lock-serial")
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			unlock := lockDomain(d)
+			defer unlock()
+			n := atomic.AddInt32(&active, 1)
+			for {
+				m := atomic.LoadInt32(&most)
+				if n <= m || atomic.CompareAndSwapInt32(&most, m, n) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			atomic.AddInt32(&active, -1)
+		}()
+	}
+	wg.Wait()
+	if most != 1 {
+		t.Errorf("expected writes to one domain to run one at a time, %d ran at once", most)
+	}
+}
+
+// TestLockDomainAllowsOtherDomains checks that a write to a domain does not
+// wait for a write to another domain, nor to a template of the same ID.
+func TestLockDomainAllowsOtherDomains(t *testing.T) {
+	unlock := lockDomain(lockTestData(t, "domains", "lock-held"))
+	defer unlock()
+
+	for _, other := range []*schema.ResourceData{
+		lockTestData(t, "domains", "lock-other"),
+		lockTestData(t, "templates", "lock-held"),
+	} {
+		locked := make(chan struct{})
+		go func(d *schema.ResourceData) {
+			lockDomain(d)()
+			close(locked)
+		}(other)
+		select {
+		case <-locked:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("expected %s/%s not to wait for the lock of domains/lock-held", other.Get("source_type"), other.Get("domain_id"))
+		}
+	}
+}
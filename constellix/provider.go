@@ -1,14 +1,18 @@
 package constellix
 
 import (
+	"context"
 	"fmt"
+	"net/url"
+	"time"
 
-	"github.com/Constellix/constellix-go-client/client"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
-	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/terraform-providers/terraform-provider-constellix/client"
 )
 
-func Provider() terraform.ResourceProvider {
+func Provider() *schema.Provider {
 	return &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"apikey": &schema.Schema{
@@ -36,10 +40,43 @@ func Provider() terraform.ResourceProvider {
 				Optional:    true,
 				Description: "Proxy server URL",
 			},
+
+			"api_endpoint": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Base URL of the Constellix DNS API",
+				DefaultFunc: schema.EnvDefaultFunc("CONSTELLIX_API_ENDPOINT", client.BaseURL),
+			},
+
+			"sonar_endpoint": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Base URL of the Constellix Sonar API",
+				DefaultFunc: schema.EnvDefaultFunc("CONSTELLIX_SONAR_ENDPOINT", client.SonarURL),
+			},
+
+			"max_retries": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      client.DefaultMaxRetries,
+				Description:  "Maximum number of retries of a rate limited, conflicting or temporarily failing API call",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+
+			"retry_max_wait": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      int(client.DefaultRetryMaxWait / time.Second),
+				Description:  "Maximum number of seconds to wait between two retries of an API call",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
 			"constellix_domain":                  resourceConstellixDomain(),
+			"constellix_domain_dnssec":           resourceConstellixDomainDNSSEC(),
+			"constellix_domain_snapshot":         resourceConstellixDomainSnapshot(),
+			"constellix_secondary_domain":        resourceConstellixSecondaryDomain(),
 			"constellix_a_record":                resourceConstellixARecord(),
 			"constellix_aaaa_record":             resourceConstellixAAAARecord(),
 			"constellix_aname_record":            resourceConstellixANAMERecord(),
@@ -56,6 +93,10 @@ func Provider() terraform.ResourceProvider {
 			"constellix_spf_record":              resourceConstellixSpf(),
 			"constellix_srv_record":              resourceConstellixSRVRecord(),
 			"constellix_txt_record":              resourceConstellixTxt(),
+			"constellix_tlsa_record":             resourceConstellixTLSA(),
+			"constellix_sshfp_record":            resourceConstellixSSHFP(),
+			"constellix_https_record":            resourceConstellixHTTPS(),
+			"constellix_svcb_record":             resourceConstellixSVCB(),
 			"constellix_template":                resourceConstellixTemplate(),
 			"constellix_a_record_pool":           resourceConstellixARecordPool(),
 			"constellix_aaaa_record_pool":        resourceConstellixAAAArecordPool(),
@@ -78,6 +119,8 @@ func Provider() terraform.ResourceProvider {
 			"constellix_a_record_pool":           datasourceConstellixARecordPool(),
 			"constellix_cert_record":             datasourceConstellixCert(),
 			"constellix_domain":                  datasourceConstellixDomain(),
+			"constellix_domains":                 datasourceConstellixDomains(),
+			"constellix_domain_history":          datasourceConstellixDomainHistory(),
 			"constellix_caa_record":              datasourceConstellixCaa(),
 			"constellix_contact_lists":           datasourceConstellixContactList(),
 			"constellix_geo_proximity":           datasourceConstellixGeoProximity(),
@@ -89,8 +132,13 @@ func Provider() terraform.ResourceProvider {
 			"constellix_naptr_record":            datasourceConstellixNAPTR(),
 			"constellix_ns_record":               datasourceConstellixNS(),
 			"constellix_txt_record":              datasourceConstellixTxt(),
+			"constellix_tlsa_record":             datasourceConstellixTLSA(),
+			"constellix_sshfp_record":            datasourceConstellixSSHFP(),
+			"constellix_https_record":            datasourceConstellixHTTPS(),
+			"constellix_svcb_record":             datasourceConstellixSVCB(),
 			"constellix_spf_record":              datasourceConstellixSPF(),
 			"constellix_tags":                    datasourceConstellixTags(),
+			"constellix_records":                 datasourceConstellixRecords(),
 			"constellix_vanity_nameserver":       datasourceConstellixVanityNameserver(),
 			"constellix_cname_record_pool":       datasourceConstellixCnamerecordPool(),
 			"constellix_template":                datasourceConstellixTemplate(),
@@ -102,22 +150,29 @@ func Provider() terraform.ResourceProvider {
 			"constellix_dns_check":               datasourceConstellixDNSCheck(),
 		},
 
-		ConfigureFunc: configureClient,
+		ConfigureContextFunc: configureClient,
 	}
 }
 
-func configureClient(d *schema.ResourceData) (interface{}, error) {
+func configureClient(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
 	config := config{
-		apikey:    d.Get("apikey").(string),
-		secretkey: d.Get("secretkey").(string),
-		insecure:  d.Get("insecure").(bool),
-		proxyurl:  d.Get("proxyurl").(string),
+		apikey:        d.Get("apikey").(string),
+		secretkey:     d.Get("secretkey").(string),
+		insecure:      d.Get("insecure").(bool),
+		proxyurl:      d.Get("proxyurl").(string),
+		apiEndpoint:   d.Get("api_endpoint").(string),
+		sonarEndpoint: d.Get("sonar_endpoint").(string),
+		maxRetries:    d.Get("max_retries").(int),
+		retryMaxWait:  time.Duration(d.Get("retry_max_wait").(int)) * time.Second,
 	}
 
 	if err := config.Valid(); err != nil {
-		return nil, err
+		return nil, diag.FromErr(err)
+	}
+	cli, err := config.getClient()
+	if err != nil {
+		return nil, diag.FromErr(err)
 	}
-	cli := config.getClient()
 	return cli, nil
 }
 
@@ -130,17 +185,53 @@ func (c config) Valid() error {
 	if c.secretkey == "" {
 		return fmt.Errorf("secret key is required")
 	}
+
+	if err := validateEndpoint("api_endpoint", c.apiEndpoint); err != nil {
+		return err
+	}
+
+	if err := validateEndpoint("sonar_endpoint", c.sonarEndpoint); err != nil {
+		return err
+	}
+	return nil
+}
+
+func validateEndpoint(key, endpoint string) error {
+	if endpoint == "" {
+		return nil
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return fmt.Errorf("%s is not a valid URL: %s", key, err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%s must be an absolute http or https URL, got %q", key, endpoint)
+	}
 	return nil
 }
 
-func (c config) getClient() interface{} {
+func (c config) getClient() (interface{}, error) {
+	options := []client.Option{
+		client.Insecure(c.insecure),
+		client.BaseUrl(c.apiEndpoint),
+		client.SonarUrl(c.sonarEndpoint),
+		client.MaxRetries(c.maxRetries),
+		client.RetryMaxWait(c.retryMaxWait),
+	}
+	if c.proxyurl != "" {
+		options = append(options, client.ProxyUrl(c.proxyurl))
+	}
 
-	return client.GetClient(c.apikey, c.secretkey)
+	return client.GetClient(c.apikey, c.secretkey, options...)
 }
 
 type config struct {
-	apikey    string
-	secretkey string
-	insecure  bool
-	proxyurl  string
+	apikey        string
+	secretkey     string
+	insecure      bool
+	proxyurl      string
+	apiEndpoint   string
+	sonarEndpoint string
+	maxRetries    int
+	retryMaxWait  time.Duration
 }
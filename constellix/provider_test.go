@@ -4,28 +4,29 @@ import (
 	"os"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
-	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
-var testAccProviders map[string]terraform.ResourceProvider
+var testAccProviderFactories map[string]func() (*schema.Provider, error)
 var testAccProvider *schema.Provider
 
 func init() {
-	testAccProvider = Provider().(*schema.Provider)
-	testAccProviders = map[string]terraform.ResourceProvider{
-		"constellix": testAccProvider,
+	testAccProvider = Provider()
+	testAccProviderFactories = map[string]func() (*schema.Provider, error){
+		"constellix": func() (*schema.Provider, error) {
+			return testAccProvider, nil
+		},
 	}
 }
 
 func TestProvider(t *testing.T) {
-	if err := Provider().(*schema.Provider).InternalValidate(); err != nil {
+	if err := Provider().InternalValidate(); err != nil {
 		t.Fatalf("err: %s", err)
 	}
 }
 
 func TestProvider_impl(t *testing.T) {
-	var _ terraform.ResourceProvider = Provider()
+	var _ *schema.Provider = Provider()
 }
 
 func testAccPreCheck(t *testing.T) {
@@ -39,3 +40,38 @@ func testAccPreCheck(t *testing.T) {
 		t.Fatal("SECRET KEY env variable must be set for acceptance tests")
 	}
 }
+
+func TestProviderConfigValid(t *testing.T) {
+	valid := config{
+		apikey:        "apikey",
+		secretkey:     "secretkey",
+		apiEndpoint:   "http://127.0.0.1:8080/",
+		sonarEndpoint: "https://sonar.example.com",
+	}
+	if err := valid.Valid(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	invalid := valid
+	invalid.apiEndpoint = "api.example.com"
+	if err := invalid.Valid(); err == nil {
+		t.Fatalf("expected an error for api_endpoint without scheme")
+	}
+
+	invalid = valid
+	invalid.sonarEndpoint = "ftp://sonar.example.com"
+	if err := invalid.Valid(); err == nil {
+		t.Fatalf("expected an error for sonar_endpoint with unsupported scheme")
+	}
+}
+
+func TestProviderConfigInvalidProxy(t *testing.T) {
+	c := config{
+		apikey:    "apikey",
+		secretkey: "secretkey",
+		proxyurl:  "proxy-without-scheme",
+	}
+	if _, err := c.getClient(); err == nil {
+		t.Fatalf("expected an error for an invalid proxyurl")
+	}
+}
@@ -0,0 +1,28 @@
+package constellix
+
+import (
+	"context"
+	"log"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/terraform-providers/terraform-provider-constellix/client"
+)
+
+// readOrMarkGone fetches endpoint for the read of d. When the API reports the
+// object as gone, the ID of d is cleared so Terraform plans to recreate it and
+// a nil response is returned together with a nil error; callers stop reading
+// once d.Id() is empty. Any other failure, including a network error without
+// a response, is returned unchanged.
+func readOrMarkGone(ctx context.Context, d *schema.ResourceData, constellixClient *client.Client, endpoint string) (*http.Response, error) {
+	resp, err := constellixClient.GetbyIdContext(ctx, endpoint)
+	if err != nil {
+		if isNotFound(err) {
+			log.Printf("[WARN] %s no longer exists, removing %s from state", endpoint, d.Id())
+			d.SetId("")
+			return nil, nil
+		}
+		return nil, err
+	}
+	return resp, nil
+}
@@ -0,0 +1,160 @@
+package constellix
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/terraform-providers/terraform-provider-constellix/client"
+)
+
+// newNotFoundServer returns a server that answers every request with 404.
+func newNotFoundServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"errors":["Record not found"]}`))
+	}))
+}
+
+// newUnreachableServerURL returns the URL of a server that is no longer
+// listening, so every request fails without a response.
+func newUnreachableServerURL() string {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+	return server.URL
+}
+
+func newTestClient(t *testing.T, baseURL string) *client.Client {
+	c, err := client.GetClient("apikey", "secretkey",
+		client.BaseUrl(baseURL), client.SonarUrl(baseURL), client.MaxRetries(0))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	return c
+}
+
+func TestReadOrMarkGone(t *testing.T) {
+	server := newNotFoundServer()
+	defer server.Close()
+
+	d := schema.TestResourceDataRaw(t, resourceConstellixARecord().Schema, map[string]interface{}{})
+	d.SetId("1234")
+
+	resp, err := readOrMarkGone(context.Background(), d, newTestClient(t, server.URL), "v1/domains/1/records/a/1234")
+	if err != nil {
+		t.Fatalf("expected no error for a 404, got %s", err)
+	}
+	if resp != nil {
+		t.Errorf("expected no response for a 404")
+	}
+	if d.Id() != "" {
+		t.Errorf("expected the ID to be cleared, got %q", d.Id())
+	}
+}
+
+func TestReadOrMarkGoneNetworkError(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceConstellixARecord().Schema, map[string]interface{}{})
+	d.SetId("1234")
+
+	_, err := readOrMarkGone(context.Background(), d, newTestClient(t, newUnreachableServerURL()), "v1/domains/1/records/a/1234")
+	if err == nil {
+		t.Fatalf("expected an error when the API cannot be reached")
+	}
+	if d.Id() != "1234" {
+		t.Errorf("expected the ID to be kept on a network error, got %q", d.Id())
+	}
+}
+
+func TestReadOrMarkGoneCancelled(t *testing.T) {
+	server := newNotFoundServer()
+	defer server.Close()
+
+	d := schema.TestResourceDataRaw(t, resourceConstellixARecord().Schema, map[string]interface{}{})
+	d.SetId("1234")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := readOrMarkGone(ctx, d, newTestClient(t, server.URL), "v1/domains/1/records/a/1234"); err == nil {
+		t.Fatalf("expected an error once the context is cancelled")
+	}
+	if d.Id() != "1234" {
+		t.Errorf("expected the ID to be kept when the read is aborted, got %q", d.Id())
+	}
+}
+
+func TestResourceReadMarksGoneOn404(t *testing.T) {
+	server := newNotFoundServer()
+	defer server.Close()
+	c := newTestClient(t, server.URL)
+
+	reads := map[string]*schema.Resource{
+		"constellix_a_record":      resourceConstellixARecord(),
+		"constellix_mx_record":     resourceConstellixMX(),
+		"constellix_domain":        resourceConstellixDomain(),
+		"constellix_geo_filter":    resourceConstellixIPFilter(),
+		"constellix_http_check":    resourceConstellixHTTPCheck(),
+		"constellix_dns_check":     resourceConstellixDNSCheck(),
+		"constellix_a_record_pool": resourceConstellixARecordPool(),
+	}
+	for name, r := range reads {
+		d := r.TestResourceData()
+		d.SetId("1234")
+		d.Set("domain_id", "1")
+		d.Set("source_type", "domains")
+		if diags := r.ReadContext(context.Background(), d, c); diags.HasError() {
+			t.Errorf("%s: expected no error for a 404, got %v", name, diags)
+		}
+		if d.Id() != "" {
+			t.Errorf("%s: expected the ID to be cleared, got %q", name, d.Id())
+		}
+	}
+}
+
+func TestResourceReadAndImportNetworkError(t *testing.T) {
+	c := newTestClient(t, newUnreachableServerURL())
+
+	resources := map[string]*schema.Resource{
+		"constellix_a_record":   resourceConstellixARecord(),
+		"constellix_geo_filter": resourceConstellixIPFilter(),
+		"constellix_dns_check":  resourceConstellixDNSCheck(),
+	}
+	for name, r := range resources {
+		d := r.TestResourceData()
+		d.SetId("domains:1:1234")
+		if _, err := r.Importer.StateContext(context.Background(), d, c); err == nil {
+			t.Errorf("%s: expected import to fail when the API cannot be reached", name)
+		}
+
+		d = r.TestResourceData()
+		d.SetId("1234")
+		d.Set("domain_id", "1")
+		d.Set("source_type", "domains")
+		if diags := r.ReadContext(context.Background(), d, c); !diags.HasError() {
+			t.Errorf("%s: expected read to fail when the API cannot be reached", name)
+		}
+		if d.Id() != "1234" {
+			t.Errorf("%s: expected the ID to be kept on a network error, got %q", name, d.Id())
+		}
+	}
+}
+
+func TestResourceImportNotFound(t *testing.T) {
+	server := newNotFoundServer()
+	defer server.Close()
+	c := newTestClient(t, server.URL)
+
+	resources := map[string]*schema.Resource{
+		"constellix_a_record":   resourceConstellixARecord(),
+		"constellix_geo_filter": resourceConstellixIPFilter(),
+	}
+	for name, r := range resources {
+		d := r.TestResourceData()
+		d.SetId("domains:1:1234")
+		_, err := r.Importer.StateContext(context.Background(), d, c)
+		if !client.IsNotFound(err) {
+			t.Errorf("%s: expected a not found error, got %v", name, err)
+		}
+	}
+}
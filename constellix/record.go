@@ -0,0 +1,767 @@
+package constellix
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/terraform-providers/terraform-provider-constellix/client"
+)
+
+// recordType describes one type of the records served under
+// v1/{domains,templates}/{id}/records/{path}. Every record resource is built
+// from one: the type declares its own arguments and how they map to the JSON
+// of the API, and the functions in this file do the rest.
+type recordType struct {
+	// path is the segment of the record type in the API's URLs.
+	path string
+	// fields are the top-level arguments of the type, beyond those every
+	// record has.
+	fields []recordField
+	// roundRobin describes the values of the record, if it has any.
+	roundRobin *recordValues
+	// traffic adds the geo location, record option, pools and record failover
+	// arguments of the record types Constellix can steer traffic with.
+	traffic bool
+	// roundRobinFailover adds the roundrobin_failover argument.
+	roundRobinFailover bool
+	// recordOptions maps the values of record_option the type accepts to the
+	// argument holding the values its records answer with under each.
+	recordOptions map[string]string
+
+	schemaVersion int
+}
+
+// recordField maps an argument to a field of the API's JSON.
+type recordField struct {
+	name   string
+	json   string
+	schema *schema.Schema
+	// toAPI converts the value of the argument to the value the API expects.
+	// The value is sent unchanged when it is nil.
+	toAPI func(interface{}) interface{}
+	// fromAPI converts the value the API answers with to the value of the
+	// argument. It defaults to a conversion to the type of the schema.
+	fromAPI func(interface{}) interface{}
+	// hostname marks a string argument holding a hostname, which is
+	// normalized as described in hostname.go.
+	hostname bool
+}
+
+// recordValues describes the roundrobin block of a record type.
+type recordValues struct {
+	fields []recordField
+	// list keeps the values in the order of the configuration instead of
+	// treating them as a set.
+	list bool
+	// optional allows records without values, such as those answered from
+	// pools or failover values.
+	optional bool
+	// validate checks a configured value beyond what the schemas of its
+	// fields can, such as a digest whose length depends on another field.
+	validate func(value map[string]interface{}) error
+}
+
+func (rt *recordType) resource() *schema.Resource {
+	r := &schema.Resource{
+		CreateContext: rt.create,
+		ReadContext:   rt.read,
+		UpdateContext: rt.update,
+		DeleteContext: rt.delete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: rt.importState,
+		},
+
+		SchemaVersion: rt.schemaVersion,
+
+		Schema: rt.schema(),
+	}
+	if rt.roundRobin != nil && rt.roundRobin.validate != nil || len(rt.recordOptions) > 0 {
+		r.CustomizeDiff = rt.customizeDiff
+	}
+	return r
+}
+
+// dataSource returns a data source that finds a record of the type by name.
+func (rt *recordType) dataSource() *schema.Resource {
+	s := rt.schema()
+	for name, attr := range s {
+		switch name {
+		case "domain_id", "source_type", "name":
+			s[name] = &schema.Schema{
+				Type:     attr.Type,
+				Required: true,
+			}
+		default:
+			s[name] = computedSchema(attr)
+		}
+	}
+	return &schema.Resource{
+		ReadContext: rt.readByName,
+
+		Schema: s,
+	}
+}
+
+// computedSchema returns a copy of attr, and of the attributes nested in it,
+// that is only read.
+func computedSchema(attr *schema.Schema) *schema.Schema {
+	computed := &schema.Schema{
+		Type:     attr.Type,
+		Computed: true,
+		Elem:     attr.Elem,
+	}
+	if elem, ok := attr.Elem.(*schema.Resource); ok {
+		nested := make(map[string]*schema.Schema, len(elem.Schema))
+		for name, nestedAttr := range elem.Schema {
+			nested[name] = computedSchema(nestedAttr)
+		}
+		computed.Elem = &schema.Resource{Schema: nested}
+	}
+	return computed
+}
+
+func (rt *recordType) schema() map[string]*schema.Schema {
+	s := map[string]*schema.Schema{
+		"domain_id": &schema.Schema{
+			Type:     schema.TypeString,
+			Required: true,
+		},
+
+		"source_type": &schema.Schema{
+			Type:     schema.TypeString,
+			Required: true,
+		},
+
+		"name": &schema.Schema{
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+
+		"ttl": &schema.Schema{
+			Type:     schema.TypeInt,
+			Required: true,
+		},
+
+		"noanswer": &schema.Schema{
+			Type:     schema.TypeBool,
+			Optional: true,
+			Computed: true,
+		},
+
+		"note": &schema.Schema{
+			Type:     schema.TypeString,
+			Optional: true,
+			Computed: true,
+		},
+
+		"gtd_region": &schema.Schema{
+			Type:     schema.TypeInt,
+			Optional: true,
+			Computed: true,
+		},
+
+		"type": &schema.Schema{
+			Type:     schema.TypeString,
+			Optional: true,
+			Computed: true,
+		},
+
+		"parentid": &schema.Schema{
+			Type:     schema.TypeInt,
+			Optional: true,
+			Computed: true,
+		},
+
+		"parent": &schema.Schema{
+			Type:     schema.TypeString,
+			Optional: true,
+			Computed: true,
+		},
+
+		"source": &schema.Schema{
+			Type:     schema.TypeString,
+			Optional: true,
+			Computed: true,
+		},
+	}
+
+	for _, f := range rt.fields {
+		s[f.name] = f.attrSchema()
+	}
+	if rt.roundRobin != nil {
+		s["roundrobin"] = rt.roundRobin.schema()
+	}
+	if rt.traffic {
+		for name, attr := range trafficSchema() {
+			s[name] = attr
+		}
+		if len(rt.recordOptions) > 0 {
+			s["record_option"].ValidateFunc = validation.StringInSlice(rt.recordOptionNames(), false)
+		}
+	}
+	if rt.roundRobinFailover {
+		s["roundrobin_failover"] = roundRobinFailoverSchema()
+	}
+	return s
+}
+
+func (v *recordValues) schema() *schema.Schema {
+	elem := &schema.Resource{Schema: make(map[string]*schema.Schema, len(v.fields))}
+	for _, f := range v.fields {
+		elem.Schema[f.name] = f.attrSchema()
+	}
+	s := &schema.Schema{
+		Type:     schema.TypeSet,
+		Elem:     elem,
+		Required: !v.optional,
+		Optional: v.optional,
+		Computed: v.optional,
+	}
+	if v.list {
+		s.Type = schema.TypeList
+	} else if hostnames := v.hostnames(); len(hostnames) > 0 {
+		s.Set = hashWithHostnames(elem, hostnames...)
+	}
+	return s
+}
+
+// hostnames returns the names of the fields of the values holding hostnames.
+func (v *recordValues) hostnames() []string {
+	var names []string
+	for _, f := range v.fields {
+		if f.hostname {
+			names = append(names, f.name)
+		}
+	}
+	return names
+}
+
+// endpoint returns the URL of the records of the type in the domain or
+// template of d.
+func (rt *recordType) endpoint(d *schema.ResourceData) string {
+	return "v1/" + d.Get("source_type").(string) + "/" + d.Get("domain_id").(string) + "/records/" + rt.path
+}
+
+func (rt *recordType) create(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	constellixClient := m.(*client.Client)
+	if err := rt.checkValues(d); err != nil {
+		return diag.FromErr(err)
+	}
+
+	unlock := lockDomain(d)
+	resp, err := constellixClient.SaveContext(ctx, rt.expand(d), rt.endpoint(d))
+	unlock()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer resp.Body.Close()
+
+	id, err := createdRecordID(resp.Body)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(id)
+	return rt.read(ctx, d, m)
+}
+
+func (rt *recordType) read(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	constellixClient := m.(*client.Client)
+
+	resp, err := readOrMarkGone(ctx, d, constellixClient, rt.endpoint(d)+"/"+d.Id())
+	if err != nil || d.Id() == "" {
+		return diag.FromErr(err)
+	}
+	return diag.FromErr(rt.flattenResponse(d, resp, domainName(ctx, constellixClient, d)))
+}
+
+func (rt *recordType) readByName(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	constellixClient := m.(*client.Client)
+	name := d.Get("name").(string)
+
+	it := constellixClient.Iterate(ctx, rt.endpoint(d))
+	for it.Next() {
+		if record := it.Object(); record["name"] == name {
+			d.SetId(toStringValue(record["id"]))
+			return diag.FromErr(rt.flatten(d, record, nil))
+		}
+	}
+	if err := it.Err(); err != nil {
+		return diag.FromErr(err)
+	}
+	return diag.Errorf("%s record with name %s is not present", strings.ToUpper(rt.path), name)
+}
+
+func (rt *recordType) update(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	constellixClient := m.(*client.Client)
+	if err := rt.checkValues(d); err != nil {
+		return diag.FromErr(err)
+	}
+
+	unlock := lockDomain(d)
+	_, err := constellixClient.UpdatebyIDContext(ctx, rt.expand(d), rt.endpoint(d)+"/"+d.Id())
+	unlock()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return rt.read(ctx, d, m)
+}
+
+func (rt *recordType) delete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	constellixClient := m.(*client.Client)
+
+	unlock := lockDomain(d)
+	err := constellixClient.DeletebyIdContext(ctx, rt.endpoint(d)+"/"+d.Id())
+	unlock()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId("")
+	return nil
+}
+
+func (rt *recordType) importState(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	log.Printf("[DEBUG] %s: Beginning Import", d.Id())
+	constellixClient := m.(*client.Client)
+	sourceType, domainID, recordID, err := parseRecordImportID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+	d.SetId(recordID)
+	d.Set("source_type", sourceType)
+	d.Set("domain_id", domainID)
+
+	resp, err := constellixClient.GetbyIdContext(ctx, rt.endpoint(d)+"/"+recordID)
+	if err != nil {
+		return nil, err
+	}
+	if err := rt.flattenResponse(d, resp, domainName(ctx, constellixClient, d)); err != nil {
+		return nil, err
+	}
+	log.Printf("[DEBUG] %s finished import", d.Id())
+	return []*schema.ResourceData{d}, nil
+}
+
+// domainName returns a function looking up the name of the domain or
+// template of d on its first call. It returns "" when the lookup fails, as
+// no answer is then known to be qualified with it.
+func domainName(ctx context.Context, constellixClient *client.Client, d *schema.ResourceData) func() string {
+	var name *string
+	return func() string {
+		if name != nil {
+			return *name
+		}
+		name = new(string)
+		resp, err := constellixClient.GetbyIdContext(ctx, "v1/"+d.Get("source_type").(string)+"/"+d.Get("domain_id").(string))
+		if err != nil {
+			log.Printf("[WARN] looking up the name of domain %s: %s", d.Get("domain_id").(string), err)
+			return ""
+		}
+		defer resp.Body.Close()
+		var data map[string]interface{}
+		if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+			log.Printf("[WARN] looking up the name of domain %s: %s", d.Get("domain_id").(string), err)
+			return ""
+		}
+		*name = toStringValue(data["name"])
+		return *name
+	}
+}
+
+// flattenResponse sets the arguments of d from a response holding a record.
+// zone returns the name of the domain of the record.
+func (rt *recordType) flattenResponse(d *schema.ResourceData, resp *http.Response, zone func() string) error {
+	defer resp.Body.Close()
+	bodyBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	var data map[string]interface{}
+	if err := json.Unmarshal(bodyBytes, &data); err != nil {
+		return err
+	}
+	return rt.flatten(d, data, zone)
+}
+
+// customizeDiff checks the configuration of a record while planning.
+func (rt *recordType) customizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if err := rt.validateValues(ctx, d, m); err != nil {
+		return err
+	}
+	return rt.validateRecordOption(ctx, d, m)
+}
+
+// validateValues checks the configured values of a record while planning.
+func (rt *recordType) validateValues(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("roundrobin") {
+		return nil
+	}
+	return rt.checkValues(d)
+}
+
+// checkValues validates the configured values of a record. Blocks nested in the values of a set
+// are not always known while planning, so values are checked again before
+// they are sent to the API.
+func (rt *recordType) checkValues(d interface{ Get(string) interface{} }) error {
+	if rt.roundRobin == nil || rt.roundRobin.validate == nil {
+		return nil
+	}
+	for _, elem := range configuredValues(d.Get("roundrobin")) {
+		if err := rt.roundRobin.validate(elem.(map[string]interface{})); err != nil {
+			return fmt.Errorf("roundrobin: %s", err)
+		}
+	}
+	return nil
+}
+
+// noAnswerUpgrader upgrades the state of record types that declared noanswer
+// as a string in their first schema version.
+func noAnswerUpgrader(r *schema.Resource) schema.StateUpgrader {
+	v0 := make(map[string]*schema.Schema, len(r.Schema))
+	for name, attr := range r.Schema {
+		v0[name] = attr
+	}
+	v0["noanswer"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		Computed: true,
+	}
+	return schema.StateUpgrader{
+		Version: 0,
+		Type:    (&schema.Resource{Schema: v0}).CoreConfigSchema().ImpliedType(),
+		Upgrade: upgradeNoAnswer,
+	}
+}
+
+func upgradeNoAnswer(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	if rawState == nil {
+		return rawState, nil
+	}
+	rawState["noanswer"] = toBool(rawState["noanswer"])
+	return rawState, nil
+}
+
+// disableFlags are the names of the disable flags of records and pools,
+// which were "true" or "false" strings before they were booleans.
+var disableFlags = map[string]bool{
+	"disable_flag":                 true,
+	"record_failover_disable_flag": true,
+}
+
+// disableFlagUpgrader upgrades the state of resources that declared their
+// disable flags as strings before version+1 of their schema.
+func disableFlagUpgrader(r *schema.Resource, version int) schema.StateUpgrader {
+	return schema.StateUpgrader{
+		Version: version,
+		Type:    (&schema.Resource{Schema: stringDisableFlags(r.Schema)}).CoreConfigSchema().ImpliedType(),
+		Upgrade: upgradeDisableFlags,
+	}
+}
+
+// stringDisableFlags returns a copy of s, and of the blocks nested in it, with
+// the disable flags declared as strings.
+func stringDisableFlags(s map[string]*schema.Schema) map[string]*schema.Schema {
+	previous := make(map[string]*schema.Schema, len(s))
+	for name, attr := range s {
+		copied := *attr
+		if elem, ok := attr.Elem.(*schema.Resource); ok {
+			copied.Elem = &schema.Resource{Schema: stringDisableFlags(elem.Schema)}
+		} else if disableFlags[name] {
+			copied.Type = schema.TypeString
+			copied.Default = nil
+		}
+		previous[name] = &copied
+	}
+	return previous
+}
+
+func upgradeDisableFlags(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	if rawState == nil {
+		return rawState, nil
+	}
+	upgradeDisableFlagsIn(rawState)
+	return rawState, nil
+}
+
+// upgradeDisableFlagsIn converts the disable flags of state, and of the
+// blocks nested in it, to booleans.
+func upgradeDisableFlagsIn(state map[string]interface{}) {
+	for name, value := range state {
+		if elems, ok := value.([]interface{}); ok {
+			for _, elem := range elems {
+				if inner, ok := elem.(map[string]interface{}); ok {
+					upgradeDisableFlagsIn(inner)
+				}
+			}
+		} else if disableFlags[name] {
+			state[name] = toBool(value)
+		}
+	}
+}
+
+// parseRecordImportID splits the source_type:domain_id:record_id import ID of
+// a record.
+func parseRecordImportID(id string) (sourceType, domainID, recordID string, err error) {
+	parts := strings.Split(id, ":")
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return "", "", "", fmt.Errorf("unexpected format of ID (%s), expected source_type:domain_id:record_id", id)
+	}
+	if parts[0] != "domains" && parts[0] != "templates" {
+		return "", "", "", fmt.Errorf("unexpected source type %q in ID (%s), expected domains or templates", parts[0], id)
+	}
+	return parts[0], parts[1], parts[2], nil
+}
+
+// createdRecordID returns the ID of the record the API answered a create
+// with. The API answers with a list holding the created record.
+func createdRecordID(body io.Reader) (string, error) {
+	bodyBytes, err := ioutil.ReadAll(body)
+	if err != nil {
+		return "", err
+	}
+	var created []map[string]interface{}
+	if err := json.Unmarshal(bodyBytes, &created); err != nil || len(created) == 0 {
+		return "", fmt.Errorf("unexpected response to record creation: %s", bodyBytes)
+	}
+	return fmt.Sprintf("%.0f", created[0]["id"]), nil
+}
+
+// expand returns the JSON the API expects for the record in d.
+func (rt *recordType) expand(d *schema.ResourceData) map[string]interface{} {
+	body := map[string]interface{}{
+		"ttl":      d.Get("ttl").(int),
+		"noAnswer": d.Get("noanswer").(bool),
+		"note":     d.Get("note").(string),
+	}
+	if name, ok := d.GetOk("name"); ok {
+		body["name"] = name
+	}
+	if gtdRegion, ok := d.GetOk("gtd_region"); ok {
+		body["gtdRegion"] = gtdRegion
+	}
+	if recordType, ok := d.GetOk("type"); ok {
+		body["type"] = recordType
+	}
+
+	for _, f := range rt.fields {
+		if value, ok := d.GetOk(f.name); ok || f.schema.Required {
+			body[f.json] = f.expand(value)
+		}
+	}
+	if rt.roundRobin != nil {
+		body["roundRobin"] = rt.roundRobin.expand(d.Get("roundrobin"))
+	}
+	if rt.traffic {
+		expandTraffic(d, body)
+	}
+	if rt.roundRobinFailover {
+		body["roundRobinFailover"] = expandFailoverValues(d.Get("roundrobin_failover").(*schema.Set).List())
+	}
+	return body
+}
+
+func (v *recordValues) expand(configured interface{}) []interface{} {
+	elems := configuredValues(configured)
+	values := make([]interface{}, 0, len(elems))
+	for _, elem := range elems {
+		inner := elem.(map[string]interface{})
+		value := make(map[string]interface{}, len(v.fields))
+		for _, f := range v.fields {
+			if attr, ok := inner[f.name]; ok && (f.schema.Required || !isZero(attr)) {
+				value[f.json] = f.expand(attr)
+			}
+		}
+		values = append(values, value)
+	}
+	return values
+}
+
+// configuredValues returns the elements of a roundrobin, which is a set or,
+// for record types that keep the order of their values, a list.
+func configuredValues(configured interface{}) []interface{} {
+	switch configured := configured.(type) {
+	case *schema.Set:
+		return configured.List()
+	case []interface{}:
+		return configured
+	}
+	return nil
+}
+
+// flatten sets the arguments of d from the JSON of a record. zone returns the
+// name of the domain of the record, which hostnames may be qualified with.
+func (rt *recordType) flatten(d *schema.ResourceData, data map[string]interface{}, zone func() string) error {
+	values := map[string]interface{}{
+		"name":       toStringValue(data["name"]),
+		"ttl":        toIntValue(data["ttl"]),
+		"noanswer":   toBool(data["noAnswer"]),
+		"note":       toStringValue(data["note"]),
+		"gtd_region": toIntValue(data["gtdRegion"]),
+		"type":       toStringValue(data["type"]),
+		"parentid":   toIntValue(data["parentId"]),
+		"parent":     toStringValue(data["parent"]),
+		"source":     toStringValue(data["source"]),
+	}
+	for _, f := range rt.fields {
+		values[f.name] = f.flatten(data[f.json])
+		if f.hostname {
+			values[f.name] = keepHostnameSpelling(d.Get(f.name).(string), values[f.name].(string), zone)
+		}
+	}
+	if rt.roundRobin != nil {
+		roundRobin := rt.roundRobin.flatten(data["roundRobin"])
+		for _, name := range rt.roundRobin.hostnames() {
+			keepHostnameSpellings(roundRobin, d.Get("roundrobin"), name, zone)
+		}
+		values["roundrobin"] = roundRobin
+	}
+	if rt.traffic {
+		flattenTraffic(data, values)
+		keepGeoFilter(values, d.Get("geo_location"))
+		keepHostnameSpellings(values["record_failover_values"].([]interface{}), d.Get("record_failover_values"), "value", zone)
+	}
+	if rt.roundRobinFailover {
+		values["roundrobin_failover"] = flattenFailoverValues(data["roundRobinFailover"])
+		keepHostnameSpellings(values["roundrobin_failover"].([]interface{}), d.Get("roundrobin_failover"), "value", zone)
+	}
+
+	for name, value := range values {
+		if err := d.Set(name, value); err != nil {
+			return fmt.Errorf("setting %s: %s", name, err)
+		}
+	}
+	return nil
+}
+
+func (v *recordValues) flatten(answered interface{}) []interface{} {
+	elems, _ := answered.([]interface{})
+	values := make([]interface{}, 0, len(elems))
+	for _, elem := range elems {
+		inner, _ := elem.(map[string]interface{})
+		value := make(map[string]interface{}, len(v.fields))
+		for _, f := range v.fields {
+			value[f.name] = f.flatten(inner[f.json])
+		}
+		values = append(values, value)
+	}
+	return values
+}
+
+// attrSchema returns the schema of the argument.
+func (f *recordField) attrSchema() *schema.Schema {
+	if f.hostname {
+		return hostnameSchema(f.schema)
+	}
+	return f.schema
+}
+
+func (f *recordField) expand(value interface{}) interface{} {
+	if f.toAPI != nil {
+		return f.toAPI(value)
+	}
+	if f.hostname {
+		return normalizeHostname(value)
+	}
+	return value
+}
+
+func (f *recordField) flatten(value interface{}) interface{} {
+	if f.fromAPI != nil {
+		return f.fromAPI(value)
+	}
+	switch f.schema.Type {
+	case schema.TypeInt:
+		return toIntValue(value)
+	case schema.TypeBool:
+		return toBool(value)
+	case schema.TypeString:
+		return toStringValue(value)
+	}
+	return value
+}
+
+// disableFlagField maps the disable_flag of the values of a record type.
+func disableFlagField(attr *schema.Schema) recordField {
+	return recordField{name: "disable_flag", json: "disableFlag", schema: attr}
+}
+
+// apiInt sends a numeric string argument as the number the API expects.
+func apiInt(value interface{}) interface{} {
+	return toIntValue(value)
+}
+
+// toStringValue formats a value of the API's JSON as a string, writing
+// numbers without exponents or trailing zeros.
+func toStringValue(value interface{}) string {
+	switch value := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// toIntValue converts a number, or a string holding one, to an int.
+func toIntValue(value interface{}) int {
+	switch value := value.(type) {
+	case int:
+		return value
+	case float64:
+		return int(value)
+	case string:
+		i, _ := strconv.Atoi(value)
+		return i
+	}
+	return 0
+}
+
+// toIntValues converts a list of numbers of the API's JSON to ints.
+func toIntValues(value interface{}) []int {
+	elems, _ := value.([]interface{})
+	ints := make([]int, 0, len(elems))
+	for _, elem := range elems {
+		ints = append(ints, toIntValue(elem))
+	}
+	return ints
+}
+
+// toBool converts a boolean, or a string holding one, to a bool.
+func toBool(value interface{}) bool {
+	switch value := value.(type) {
+	case bool:
+		return value
+	case string:
+		b, _ := strconv.ParseBool(value)
+		return b
+	}
+	return false
+}
+
+// isZero reports whether an optional value of a roundrobin element is unset.
+// Booleans are always sent; empty lists and blocks are not.
+func isZero(value interface{}) bool {
+	switch value := value.(type) {
+	case nil:
+		return true
+	case string:
+		return value == ""
+	case int:
+		return value == 0
+	case []interface{}:
+		return len(value) == 0
+	}
+	return false
+}
@@ -0,0 +1,600 @@
+package constellix
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// recordTypes are the record types of the provider, keyed by resource name.
+var recordTypes = map[string]*recordType{
+	"constellix_a_record":                aRecord,
+	"constellix_aaaa_record":             aaaaRecord,
+	"constellix_aname_record":            anameRecord,
+	"constellix_cname_record":            cnameRecord,
+	"constellix_caa_record":              caaRecord,
+	"constellix_cert_record":             certRecord,
+	"constellix_hinfo_record":            hinfoRecord,
+	"constellix_http_redirection_record": httpRedirectionRecord,
+	"constellix_mx_record":               mxRecord,
+	"constellix_naptr_record":            naptrRecord,
+	"constellix_ns_record":               nsRecord,
+	"constellix_ptr_record":              ptrRecord,
+	"constellix_rp_record":               rpRecord,
+	"constellix_spf_record":              spfRecord,
+	"constellix_srv_record":              srvRecord,
+	"constellix_txt_record":              txtRecord,
+	"constellix_tlsa_record":             tlsaRecord,
+	"constellix_sshfp_record":            sshfpRecord,
+	"constellix_https_record":            httpsRecord,
+	"constellix_svcb_record":             svcbRecord,
+}
+
+func TestParseRecordImportID(t *testing.T) {
+	cases := []struct {
+		id                             string
+		sourceType, domainID, recordID string
+		ok                             bool
+	}{
+		{"domains:1001:2002", "domains", "1001", "2002", true},
+		{"templates:7:8", "templates", "7", "8", true},
+		{"2002", "", "", "", false},
+		{"domains:1001", "", "", "", false},
+		{"domains:1001:2002:3", "", "", "", false},
+		{"domains::2002", "", "", "", false},
+		{"zones:1001:2002", "", "", "", false},
+	}
+	for _, tc := range cases {
+		sourceType, domainID, recordID, err := parseRecordImportID(tc.id)
+		if (err == nil) != tc.ok {
+			t.Errorf("%s: unexpected error %v", tc.id, err)
+			continue
+		}
+		if sourceType != tc.sourceType || domainID != tc.domainID || recordID != tc.recordID {
+			t.Errorf("%s: got %q, %q, %q", tc.id, sourceType, domainID, recordID)
+		}
+	}
+}
+
+func TestRecordValueConversions(t *testing.T) {
+	if s := toStringValue(float64(10000000)); s != "10000000" {
+		t.Errorf("expected numbers without exponent, got %q", s)
+	}
+	if s := toStringValue(1.5); s != "1.5" {
+		t.Errorf("expected 1.5, got %q", s)
+	}
+	if s := toStringValue(nil); s != "" {
+		t.Errorf("expected nil to be empty, got %q", s)
+	}
+	if i := toIntValue("42"); i != 42 {
+		t.Errorf("expected 42, got %d", i)
+	}
+	if i := toIntValue(float64(7)); i != 7 {
+		t.Errorf("expected 7, got %d", i)
+	}
+	if !toBool("true") || toBool("false") || toBool("") || !toBool(true) {
+		t.Errorf("unexpected conversion of booleans")
+	}
+	if v := fromTXT(`"v=spf1 " "-all"`); v != "v=spf1 -all" {
+		t.Errorf("expected the quotes of a TXT value to be stripped, got %q", v)
+	}
+	if v := fromBase64(toBase64("certificate")); v != "certificate" {
+		t.Errorf("expected the certificate to survive encoding, got %q", v)
+	}
+}
+
+func TestRecordExpandAndFlatten(t *testing.T) {
+	r := aRecord.resource()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"domain_id":   "1001",
+		"source_type": "domains",
+		"name":        "www",
+		"ttl":         300,
+		"roundrobin": []interface{}{
+			map[string]interface{}{"value": "192.0.2.1", "disable_flag": false},
+		},
+		"geo_location": []interface{}{
+			map[string]interface{}{"geo_ip_user_region": []interface{}{3}, "drop": true},
+		},
+		"record_failover_values": []interface{}{
+			map[string]interface{}{"value": "192.0.2.3", "sort_order": "2", "disable_flag": false, "check_id": 12},
+			map[string]interface{}{"value": "192.0.2.2", "sort_order": "1", "disable_flag": true},
+		},
+		"record_failover_failover_type": "1",
+		"roundrobin_failover": []interface{}{
+			map[string]interface{}{"value": "192.0.2.5", "sort_order": "10"},
+			map[string]interface{}{"value": "192.0.2.4", "sort_order": "9"},
+		},
+	})
+
+	body := aRecord.expand(d)
+	if body["name"] != "www" || body["ttl"] != 300 {
+		t.Errorf("unexpected common fields %v", body)
+	}
+	roundRobin := []interface{}{map[string]interface{}{"value": "192.0.2.1", "disableFlag": false}}
+	if !reflect.DeepEqual(body["roundRobin"], roundRobin) {
+		t.Errorf("expected roundRobin %v, got %v", roundRobin, body["roundRobin"])
+	}
+	geo := map[string]interface{}{"geoipUserRegion": []int{3}, "drop": true, "geoipFailover": false}
+	if !reflect.DeepEqual(body["geolocation"], geo) {
+		t.Errorf("expected geolocation %v, got %v", geo, body["geolocation"])
+	}
+	failover := map[string]interface{}{
+		"values": []interface{}{
+			map[string]interface{}{"value": "192.0.2.2", "sortOrder": 1, "disableFlag": true},
+			map[string]interface{}{"value": "192.0.2.3", "sortOrder": 2, "disableFlag": false, "checkId": 12},
+		},
+		"failoverType": 1,
+		"disabled":     false,
+	}
+	if !reflect.DeepEqual(body["recordFailover"], failover) {
+		t.Errorf("expected recordFailover %v, got %v", failover, body["recordFailover"])
+	}
+	rrFailover := body["roundRobinFailover"].([]interface{})
+	if len(rrFailover) != 2 || rrFailover[0].(map[string]interface{})["value"] != "192.0.2.4" {
+		t.Errorf("expected roundRobinFailover sorted by sort order, got %v", rrFailover)
+	}
+
+	answer := map[string]interface{}{
+		"id":         float64(2002),
+		"name":       "www",
+		"ttl":        float64(300),
+		"type":       "A",
+		"roundRobin": []interface{}{map[string]interface{}{"value": "192.0.2.1", "disableFlag": false}},
+		"geolocation": map[string]interface{}{
+			"geoipUserRegion": []interface{}{float64(3)},
+			"drop":            true,
+		},
+		"recordFailover": map[string]interface{}{
+			"values": []interface{}{
+				map[string]interface{}{"value": "192.0.2.2", "sortOrder": float64(1), "disableFlag": true},
+			},
+			"failoverType": float64(1),
+			"disabled":     false,
+		},
+		"pools": []interface{}{float64(5)},
+	}
+	d.SetId("2002")
+	if err := aRecord.flatten(d, answer, nil); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expected := map[string]string{
+		"roundrobin.#":                        "1",
+		"geo_location.0.geo_ip_user_region.0": "3",
+		"geo_location.0.drop":                 "true",
+		"geo_location.0.geo_ip_filter":        "0",
+		"record_failover_values.#":            "1",
+		"record_failover_failover_type":       "1",
+		"record_failover_disable_flag":        "false",
+		"roundrobin_failover.#":               "0",
+		"pools.0":                             "5",
+		"type":                                "A",
+		"ttl":                                 "300",
+	}
+	state := d.State()
+	for key, value := range expected {
+		if state.Attributes[key] != value {
+			t.Errorf("expected %s = %q, got %q", key, value, state.Attributes[key])
+		}
+	}
+}
+
+func TestRecordValuesExpandSkipsUnsetOptionals(t *testing.T) {
+	values := srvRecord.roundRobin.expand([]interface{}{
+		map[string]interface{}{"value": "sip.example.com.", "port": 5060, "priority": 0, "weight": 0, "disable_flag": false},
+	})
+	expected := []interface{}{map[string]interface{}{"value": "sip.example.com.", "port": 5060, "disableFlag": false}}
+	if !reflect.DeepEqual(values, expected) {
+		t.Errorf("expected %v, got %v", expected, values)
+	}
+
+	values = mxRecord.roundRobin.expand([]interface{}{
+		map[string]interface{}{"value": "mx.example.com.", "level": "10", "disable_flag": true},
+	})
+	expected = []interface{}{map[string]interface{}{"value": "mx.example.com.", "level": 10, "disableFlag": true}}
+	if !reflect.DeepEqual(values, expected) {
+		t.Errorf("expected %v, got %v", expected, values)
+	}
+}
+
+func TestUpgradeNoAnswer(t *testing.T) {
+	for _, r := range []*schema.Resource{resourceConstellixPtr(), resourceConstellixTxt()} {
+		if len(r.StateUpgraders) == 0 || r.StateUpgraders[0].Version != 0 {
+			t.Fatalf("expected an upgrader from version 0")
+		}
+		for raw, expected := range map[string]bool{"true": true, "false": false, "": false} {
+			state, err := r.StateUpgraders[0].Upgrade(context.Background(), map[string]interface{}{"noanswer": raw}, nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if state["noanswer"] != expected {
+				t.Errorf("expected %q to be upgraded to %t, got %v", raw, expected, state["noanswer"])
+			}
+		}
+	}
+}
+
+func TestUpgradeGeoLocation(t *testing.T) {
+	for _, r := range []*schema.Resource{resourceConstellixARecord(), resourceConstellixAAAARecord(), resourceConstellixANAMERecord(), resourceConstellixCNameRecord()} {
+		// The geo_location upgrader is followed by the disable_flag one.
+		upgrader := r.StateUpgraders[0]
+		if upgrader.Version != r.SchemaVersion-2 {
+			t.Fatalf("expected an upgrader from version %d, got %d", r.SchemaVersion-2, upgrader.Version)
+		}
+		state, err := upgrader.Upgrade(context.Background(), map[string]interface{}{
+			"geo_location": map[string]interface{}{"geo_ip_user_region": "3", "drop": "true", "geo_ip_failover": "false", "geo_ip_proximity": "7"},
+		}, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		expected := []interface{}{map[string]interface{}{
+			"geo_ip_user_region": []interface{}{3},
+			"geo_ip_filter":      0,
+			"geo_ip_proximity":   7,
+			"drop":               true,
+			"geo_ip_failover":    false,
+		}}
+		if !reflect.DeepEqual(state["geo_location"], expected) {
+			t.Errorf("expected geo_location %v, got %v", expected, state["geo_location"])
+		}
+		if _, err := schema.JSONMapToStateValue(state, r.CoreConfigSchema()); err != nil {
+			t.Errorf("expected the upgraded state to fit the schema: %s", err)
+		}
+
+		state, err = upgrader.Upgrade(context.Background(), map[string]interface{}{"geo_location": map[string]interface{}{}}, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if geo := state["geo_location"].([]interface{}); len(geo) != 0 {
+			t.Errorf("expected no geo_location block, got %v", geo)
+		}
+	}
+}
+
+// PTR values used to be numbers; a state holding one still reads as the
+// string the value now is.
+func TestPtrNumericValueState(t *testing.T) {
+	r := resourceConstellixPtr()
+	state := map[string]interface{}{
+		"roundrobin": []interface{}{map[string]interface{}{"value": 13, "disable_flag": false}},
+	}
+	value, err := schema.JSONMapToStateValue(state, r.CoreConfigSchema())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	for it := value.GetAttr("roundrobin").ElementIterator(); it.Next(); {
+		_, elem := it.Element()
+		if v := elem.GetAttr("value").AsString(); v != "13" {
+			t.Errorf("expected value 13 to read as a string, got %q", v)
+		}
+	}
+}
+
+func TestRecordDeleteEndpoints(t *testing.T) {
+	f := newFakeAPI(t)
+	domainID := f.domain("example.com")
+
+	for name, rt := range recordTypes {
+		endpoint := "v1/domains/" + domainID + "/records/" + rt.path
+		id := f.create(endpoint, map[string]interface{}{"name": "test", "ttl": 300})
+
+		r := f.provider.ResourcesMap[name]
+		d := r.TestResourceData()
+		d.SetId(id)
+		d.Set("domain_id", domainID)
+		d.Set("source_type", "domains")
+		if diags := r.DeleteContext(context.Background(), d, f.client); diags.HasError() {
+			t.Errorf("%s: delete failed: %v", name, diags)
+			continue
+		}
+
+		requests := f.server.Requests()
+		if last := requests[len(requests)-1]; last != "DELETE "+endpoint+"/"+id {
+			t.Errorf("%s: expected delete of %s/%s, got %s", name, endpoint, id, last)
+		}
+		if _, ok := f.server.Object(endpoint + "/" + id); ok {
+			t.Errorf("%s: expected %s/%s to be deleted", name, endpoint, id)
+		}
+	}
+}
+
+func TestUpgradeDisableFlags(t *testing.T) {
+	for name, r := range map[string]*schema.Resource{
+		"a":          resourceConstellixARecord(),
+		"aaaa":       resourceConstellixAAAARecord(),
+		"aname":      resourceConstellixANAMERecord(),
+		"cname":      resourceConstellixCNameRecord(),
+		"caa":        resourceConstellixCaa(),
+		"cert":       resourceConstellixCert(),
+		"hinfo":      resourceConstellixHinfo(),
+		"mx":         resourceConstellixMX(),
+		"naptr":      resourceConstellixNAPTR(),
+		"ns":         resourceConstellixNS(),
+		"ptr":        resourceConstellixPtr(),
+		"rp":         resourceConstellixRP(),
+		"spf":        resourceConstellixSpf(),
+		"a pool":     resourceConstellixARecordPool(),
+		"aaaa pool":  resourceConstellixAAAArecordPool(),
+		"cname pool": resourceConstellixCnameRecordPool(),
+	} {
+		upgrader := r.StateUpgraders[len(r.StateUpgraders)-1]
+		if upgrader.Version != r.SchemaVersion-1 {
+			t.Fatalf("%s: expected an upgrader from version %d, got %d", name, r.SchemaVersion-1, upgrader.Version)
+		}
+
+		// Every block holding a disable flag gets one in the old state.
+		state := make(map[string]interface{})
+		for key, attr := range r.Schema {
+			if elem, ok := attr.Elem.(*schema.Resource); ok {
+				if _, ok := elem.Schema["disable_flag"]; ok {
+					state[key] = []interface{}{map[string]interface{}{"disable_flag": "true"}}
+				}
+			} else if disableFlags[key] {
+				state[key] = "false"
+			}
+		}
+		if len(state) == 0 {
+			t.Fatalf("%s: expected disable flags in the schema", name)
+		}
+		state, err := upgrader.Upgrade(context.Background(), state, nil)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", name, err)
+		}
+		for key, value := range state {
+			if elems, ok := value.([]interface{}); ok {
+				value = elems[0].(map[string]interface{})["disable_flag"]
+				if value != true {
+					t.Errorf("%s: expected %s.0.disable_flag to be upgraded to true, got %#v", name, key, value)
+				}
+			} else if value != false {
+				t.Errorf("%s: expected %s to be upgraded to false, got %#v", name, key, value)
+			}
+		}
+		if _, err := schema.JSONMapToStateValue(state, r.CoreConfigSchema()); err != nil {
+			t.Errorf("%s: expected the upgraded state to fit the schema: %s", name, err)
+		}
+
+		config := map[string]interface{}{"disable_flag": "maybe"}
+		if _, ok := r.Schema["roundrobin"]; ok {
+			config = map[string]interface{}{
+				"roundrobin": []interface{}{map[string]interface{}{"disable_flag": "maybe"}},
+			}
+		} else if _, ok := r.Schema["disable_flag"]; !ok {
+			config = map[string]interface{}{"record_failover_disable_flag": "maybe"}
+		}
+		invalid := false
+		for _, diag := range r.Validate(terraform.NewResourceConfigRaw(config)) {
+			invalid = invalid || strings.Contains(diag.Summary, `"maybe"`)
+		}
+		if !invalid {
+			t.Errorf("%s: expected disable_flag \"maybe\" to be invalid", name)
+		}
+	}
+}
+
+func TestRecordOptionChecks(t *testing.T) {
+	f := newFakeAPI(t)
+	domainID := f.domain("example.com")
+	record := func(args map[string]interface{}) map[string]interface{} {
+		config := map[string]interface{}{"domain_id": domainID, "source_type": "domains", "name": "www", "ttl": 300}
+		for key, value := range args {
+			config[key] = value
+		}
+		return config
+	}
+	roundRobin := []interface{}{map[string]interface{}{"value": "192.0.2.1", "disable_flag": false}}
+	failover := func(sortOrders ...string) []interface{} {
+		values := make([]interface{}, 0, len(sortOrders))
+		for i, sortOrder := range sortOrders {
+			values = append(values, map[string]interface{}{"value": fmt.Sprintf("192.0.2.%d", i+10), "sort_order": sortOrder, "disable_flag": false})
+		}
+		return values
+	}
+
+	for _, tc := range []struct {
+		resource string
+		config   map[string]interface{}
+		// expected is part of the error planning config fails with, if any.
+		expected string
+	}{
+		{"constellix_a_record", record(map[string]interface{}{
+			"roundrobin": roundRobin,
+		}), ""},
+		{"constellix_a_record", record(map[string]interface{}{
+			"roundrobin":             roundRobin,
+			"record_option":          "failover",
+			"record_failover_values": failover("1", "2"),
+		}), ""},
+		{"constellix_a_record", record(map[string]interface{}{
+			"record_option": "failover",
+			"roundrobin":    roundRobin,
+		}), `record_option "failover" requires record_failover_values`},
+		{"constellix_a_record", record(map[string]interface{}{
+			"record_option": "roundRobinFailover",
+			"roundrobin":    roundRobin,
+		}), `record_option "roundRobinFailover" requires roundrobin_failover`},
+		{"constellix_a_record", record(map[string]interface{}{
+			"record_option": "roundRobin",
+		}), `record_option "roundRobin" requires roundrobin`},
+		{"constellix_a_record", record(map[string]interface{}{
+			"roundrobin": roundRobin,
+			"pools":      []interface{}{123},
+		}), ""},
+		{"constellix_a_record", record(map[string]interface{}{
+			"record_option":          "pools",
+			"pools":                  []interface{}{123},
+			"record_failover_values": failover("1"),
+		}), ""},
+		{"constellix_a_record", record(map[string]interface{}{
+			"record_option": "pools",
+			"pools":         []interface{}{123},
+			"roundrobin":    roundRobin,
+		}), `roundrobin conflicts with record_option = "pools"`},
+		// Values of the other record options may be kept along.
+		{"constellix_a_record", record(map[string]interface{}{
+			"record_option":          "roundRobinFailover",
+			"pools":                  []interface{}{123},
+			"roundrobin":             roundRobin,
+			"roundrobin_failover":    failover("1", "2"),
+			"record_failover_values": failover("1", "2"),
+		}), ""},
+		{"constellix_a_record", record(map[string]interface{}{
+			"roundrobin":             roundRobin,
+			"record_option":          "failover",
+			"record_failover_values": failover("1", "2", "1"),
+		}), "record_failover_values: sort_order 1 is used by more than one value"},
+		{"constellix_a_record", record(map[string]interface{}{
+			"roundrobin":             roundRobin,
+			"record_option":          "failover",
+			"record_failover_values": failover("first"),
+		}), `record_failover_values: sort_order must be a positive integer, got "first"`},
+		{"constellix_aaaa_record", record(map[string]interface{}{
+			"record_option": "roundRobinFailover",
+			"roundrobin_failover": []interface{}{
+				map[string]interface{}{"value": "2001:db8::1", "sort_order": "2", "disable_flag": false},
+				map[string]interface{}{"value": "2001:db8::2", "sort_order": "2", "disable_flag": false},
+			},
+		}), "roundrobin_failover: sort_order 2 is used by more than one value"},
+		{"constellix_aname_record", record(map[string]interface{}{
+			"roundrobin": []interface{}{map[string]interface{}{"value": "lb.example.net.", "disable_flag": false}},
+			"pools":      []interface{}{123},
+		}), ""},
+		{"constellix_cname_record", record(map[string]interface{}{
+			"record_option": "pools",
+			"pools":         []interface{}{123},
+		}), ""},
+		{"constellix_cname_record", record(map[string]interface{}{
+			"record_option": "pools",
+			"pools":         []interface{}{123},
+			"host":          "origin.example.net.",
+		}), `host conflicts with record_option = "pools"`},
+	} {
+		r := f.provider.ResourcesMap[tc.resource]
+		_, err := f.plan(r, nil, tc.config)
+		if tc.expected == "" && err != nil {
+			t.Errorf("%s: unexpected error: %s", tc.resource, err)
+		}
+		if tc.expected != "" && (err == nil || !strings.Contains(err.Error(), tc.expected)) {
+			t.Errorf("%s: expected an error with %q, got %v", tc.resource, tc.expected, err)
+		}
+	}
+
+	// Arguments the record types do not accept are refused while validating.
+	for resource, config := range map[string]map[string]interface{}{
+		"constellix_a_record":     record(map[string]interface{}{"record_option": "standard"}),
+		"constellix_aname_record": record(map[string]interface{}{"record_option": "pools"}),
+		"constellix_cname_record": record(map[string]interface{}{"record_failover_failover_type": "4"}),
+	} {
+		r := f.provider.ResourcesMap[resource]
+		if diags := r.Validate(terraform.NewResourceConfigRaw(config)); !diags.HasError() {
+			t.Errorf("%s: expected %v to be invalid", resource, config)
+		}
+	}
+
+	// Moving a record from pools to failover drops its pools, which the
+	// state still holds while planning.
+	r := f.provider.ResourcesMap["constellix_cname_record"]
+	state := f.apply(r, nil, record(map[string]interface{}{
+		"record_option": "pools",
+		"pools":         []interface{}{123},
+	}))
+	config := record(map[string]interface{}{
+		"host":                   "primary.example.net.",
+		"record_option":          "failover",
+		"record_failover_values": []interface{}{map[string]interface{}{"value": "backup.example.net.", "sort_order": "1", "disable_flag": false}},
+	})
+	state = f.apply(r, state, config)
+	if state.Attributes["record_option"] != "failover" {
+		t.Errorf("expected the record to fail over, got %v", state.Attributes)
+	}
+}
+
+func TestRecordDataSources(t *testing.T) {
+	f := newFakeAPI(t)
+	domainID := f.domain("example.com")
+	args := map[string]interface{}{"domain_id": domainID, "source_type": "domains", "name": "test"}
+
+	// Records created elsewhere may lack values, check IDs and the like.
+	for name, rt := range recordTypes {
+		endpoint := "v1/domains/" + domainID + "/records/" + rt.path
+		id := f.create(endpoint, map[string]interface{}{"name": "test", "ttl": 300})
+
+		ds, ok := f.provider.DataSourcesMap[name]
+		if !ok {
+			continue
+		}
+		d := schema.TestResourceDataRaw(t, ds.Schema, args)
+		if diags := ds.ReadContext(context.Background(), d, f.client); diags.HasError() {
+			t.Errorf("%s: data source read failed: %v", name, diags)
+			continue
+		}
+		if d.Id() != id {
+			t.Errorf("%s: expected data source to find %s, got %q", name, id, d.Id())
+		}
+	}
+
+	// The data source reads a record as the resource does.
+	r := f.provider.ResourcesMap["constellix_a_record"]
+	state := f.apply(r, nil, map[string]interface{}{
+		"domain_id":   domainID,
+		"source_type": "domains",
+		"name":        "www",
+		"ttl":         300,
+		"roundrobin": []interface{}{
+			map[string]interface{}{"value": "192.0.2.1", "disable_flag": false},
+			map[string]interface{}{"value": "192.0.2.2", "disable_flag": true},
+		},
+	})
+	ds := f.provider.DataSourcesMap["constellix_a_record"]
+	d := schema.TestResourceDataRaw(t, ds.Schema, map[string]interface{}{"domain_id": domainID, "source_type": "domains", "name": "www"})
+	if diags := ds.ReadContext(context.Background(), d, f.client); diags.HasError() {
+		t.Fatalf("data source read failed: %v", diags)
+	}
+	read := d.State()
+	for key, value := range state.Attributes {
+		if actual, ok := read.Attributes[key]; !ok || actual != value {
+			t.Errorf("expected data source attribute %s = %q, got %q", key, value, actual)
+		}
+	}
+}
+
+func TestRecordDataSourcesReadLaterPages(t *testing.T) {
+	f := newFakeAPI(t)
+	domainID := f.domain("example.com")
+
+	for name, rt := range recordTypes {
+		ds, ok := f.provider.DataSourcesMap[name]
+		if !ok {
+			continue
+		}
+		endpoint := "v1/domains/" + domainID + "/records/" + rt.path
+		var id string
+		for _, record := range []string{"first", "second", "third"} {
+			id = f.create(endpoint, map[string]interface{}{"name": record, "ttl": 300})
+		}
+
+		before := len(f.server.Requests())
+		d := schema.TestResourceDataRaw(t, ds.Schema, map[string]interface{}{"domain_id": domainID, "source_type": "domains", "name": "third"})
+		if diags := ds.ReadContext(context.Background(), d, f.client); diags.HasError() {
+			t.Errorf("%s: data source read failed: %v", name, diags)
+			continue
+		}
+		if d.Id() != id {
+			t.Errorf("%s: expected data source to find %s on the second page, got %q", name, id, d.Id())
+		}
+		pages := 0
+		for _, request := range f.server.Requests()[before:] {
+			if request == "GET "+endpoint {
+				pages++
+			}
+		}
+		if pages != 2 {
+			t.Errorf("%s: expected 2 pages to be listed, got %d", name, pages)
+		}
+	}
+}
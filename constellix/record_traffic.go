@@ -0,0 +1,415 @@
+package constellix
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// trafficSchema returns the arguments of the record types whose answers can
+// be steered by geo location, pools and failover.
+func trafficSchema() map[string]*schema.Schema {
+	failoverValue := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"value": hostnameSchema(&schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			}),
+			"check_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+			"sort_order": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"disable_flag": &schema.Schema{
+				Type:     schema.TypeBool,
+				Required: true,
+			},
+		},
+	}
+
+	return map[string]*schema.Schema{
+		"geo_location": geoLocationSchema(),
+
+		"record_option": &schema.Schema{
+			Type:     schema.TypeString,
+			Optional: true,
+			Computed: true,
+		},
+
+		"pools": &schema.Schema{
+			Type:     schema.TypeList,
+			Optional: true,
+			Computed: true,
+			Elem:     &schema.Schema{Type: schema.TypeInt},
+		},
+
+		"contact_ids": &schema.Schema{
+			Type:     schema.TypeList,
+			Optional: true,
+			Computed: true,
+			Elem:     &schema.Schema{Type: schema.TypeInt},
+		},
+
+		"record_failover_values": &schema.Schema{
+			Type:     schema.TypeSet,
+			Elem:     failoverValue,
+			Set:      hashWithHostnames(failoverValue, "value"),
+			Optional: true,
+		},
+
+		"record_failover_failover_type": &schema.Schema{
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validation.StringInSlice([]string{"1", "2", "3"}, false),
+		},
+
+		"record_failover_disable_flag": &schema.Schema{
+			Type:     schema.TypeBool,
+			Optional: true,
+			Computed: true,
+		},
+	}
+}
+
+// geoLocationSchema returns the geo_location block, which selects the
+// clients a record answers by IP filter or geo proximity.
+func geoLocationSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		MaxItems: 1,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"geo_ip_user_region": &schema.Schema{
+					Type:          schema.TypeList,
+					Optional:      true,
+					Elem:          &schema.Schema{Type: schema.TypeInt},
+					ConflictsWith: []string{"geo_location.0.geo_ip_filter"},
+				},
+				"geo_ip_filter": &schema.Schema{
+					Type:          schema.TypeInt,
+					Optional:      true,
+					ValidateFunc:  validation.IntAtLeast(1),
+					ConflictsWith: []string{"geo_location.0.geo_ip_user_region"},
+				},
+				"geo_ip_proximity": &schema.Schema{
+					Type:         schema.TypeInt,
+					Optional:     true,
+					ValidateFunc: validation.IntAtLeast(1),
+				},
+				"drop": &schema.Schema{
+					Type:     schema.TypeBool,
+					Optional: true,
+					Default:  false,
+				},
+				"geo_ip_failover": &schema.Schema{
+					Type:     schema.TypeBool,
+					Optional: true,
+					Default:  false,
+				},
+			},
+		},
+	}
+}
+
+func roundRobinFailoverSchema() *schema.Schema {
+	value := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"value": hostnameSchema(&schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			}),
+			"disable_flag": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
+			"sort_order": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"check_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+		},
+	}
+	return &schema.Schema{
+		Type:     schema.TypeSet,
+		Elem:     value,
+		Set:      hashWithHostnames(value, "value"),
+		Optional: true,
+		Computed: true,
+	}
+}
+
+// recordOptionNames returns the values of record_option the type accepts.
+func (rt *recordType) recordOptionNames() []string {
+	names := make([]string, 0, len(rt.recordOptions))
+	for name := range rt.recordOptions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// validateRecordOption checks while planning that a record configures the
+// values it answers with under its record_option, and no values of its own
+// along with pools. The sort orders of failover values must also be unique,
+// as they decide which value answers first.
+func (rt *recordType) validateRecordOption(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	config := d.GetRawConfig()
+	if len(rt.recordOptions) == 0 || config.IsNull() || !config.IsKnown() {
+		return nil
+	}
+
+	for _, name := range []string{"record_failover_values", "roundrobin_failover"} {
+		if err := checkSortOrders(d, name); err != nil {
+			return err
+		}
+	}
+
+	recordOption := config.GetAttr("record_option")
+	if !recordOption.IsKnown() {
+		return nil
+	}
+	option := ""
+	if !recordOption.IsNull() {
+		option = recordOption.AsString()
+	}
+	if name, ok := rt.recordOptions[option]; ok && !configured(d, name) {
+		return fmt.Errorf("record_option %q requires %s", option, name)
+	}
+	if name := rt.recordOptions["roundRobin"]; option == "pools" && configured(d, name) {
+		return fmt.Errorf("%s conflicts with record_option = \"pools\", which answers with the values of the pools", name)
+	}
+	return nil
+}
+
+// configured reports whether the argument name is set in the configuration
+// of d. Values not yet known count as set.
+func configured(d *schema.ResourceDiff, name string) bool {
+	value := d.GetRawConfig().GetAttr(name)
+	if value.IsNull() {
+		return false
+	}
+	if !value.IsKnown() || !value.Type().IsCollectionType() {
+		return true
+	}
+	return value.LengthInt() > 0
+}
+
+// checkSortOrders checks that the failover values configured in the
+// argument name of d each have a sort_order of their own.
+func checkSortOrders(d *schema.ResourceDiff, name string) error {
+	config := d.GetRawConfig()
+	if !config.Type().HasAttribute(name) {
+		return nil
+	}
+	values := config.GetAttr(name)
+	if values.IsNull() || !values.IsKnown() {
+		return nil
+	}
+	seen := make(map[int]bool)
+	for it := values.ElementIterator(); it.Next(); {
+		_, value := it.Element()
+		sortOrder := value.GetAttr("sort_order")
+		if sortOrder.IsNull() || !sortOrder.IsKnown() {
+			continue
+		}
+		order, err := strconv.Atoi(sortOrder.AsString())
+		if err != nil || order < 1 {
+			return fmt.Errorf("%s: sort_order must be a positive integer, got %q", name, sortOrder.AsString())
+		}
+		if seen[order] {
+			return fmt.Errorf("%s: sort_order %d is used by more than one value", name, order)
+		}
+		seen[order] = true
+	}
+	return nil
+}
+
+// expandTraffic adds the traffic arguments of d to the JSON of a record.
+func expandTraffic(d *schema.ResourceData, body map[string]interface{}) {
+	body["geolocation"] = expandGeoLocation(d.Get("geo_location").([]interface{}))
+	if recordOption, ok := d.GetOk("record_option"); ok {
+		body["recordOption"] = recordOption
+	}
+	if pools, ok := d.GetOk("pools"); ok {
+		body["pools"] = toListOfInt(pools)
+	}
+	if contactIDs, ok := d.GetOk("contact_ids"); ok {
+		body["contactIds"] = toListOfInt(contactIDs)
+	}
+	if values, ok := d.GetOk("record_failover_values"); ok {
+		body["recordFailover"] = map[string]interface{}{
+			"values":       expandFailoverValues(values.(*schema.Set).List()),
+			"failoverType": toIntValue(d.Get("record_failover_failover_type")),
+			"disabled":     toBool(d.Get("record_failover_disable_flag")),
+		}
+	}
+}
+
+// flattenTraffic sets the traffic arguments in values from the JSON of a
+// record.
+func flattenTraffic(data, values map[string]interface{}) {
+	geoLocation, _ := data["geolocation"].(map[string]interface{})
+	values["geo_location"] = flattenGeoLocation(geoLocation)
+	values["record_option"] = toStringValue(data["recordOption"])
+	values["pools"] = toIntValues(data["pools"])
+	values["contact_ids"] = toIntValues(data["contactIds"])
+
+	failover, _ := data["recordFailover"].(map[string]interface{})
+	values["record_failover_values"] = flattenFailoverValues(failover["values"])
+	values["record_failover_failover_type"] = ""
+	values["record_failover_disable_flag"] = toBool(failover["disabled"])
+	if failover != nil {
+		values["record_failover_failover_type"] = toStringValue(failover["failoverType"])
+	}
+}
+
+// keepGeoFilter drops the geo filter answered for a geo_location block held
+// without one, so that a filter the API fills in for the regions of a record
+// does not show as a change. The answer is kept when no block is held, as on
+// import.
+func keepGeoFilter(values map[string]interface{}, held interface{}) {
+	current, _ := held.([]interface{})
+	answered, _ := values["geo_location"].([]interface{})
+	if len(current) == 0 || current[0] == nil || len(answered) == 0 {
+		return
+	}
+	if toIntValue(current[0].(map[string]interface{})["geo_ip_filter"]) == 0 {
+		answered[0].(map[string]interface{})["geo_ip_filter"] = 0
+	}
+}
+
+// expandGeoLocation returns the geolocation of a record. A record without a
+// geo_location block has an empty one.
+func expandGeoLocation(configured []interface{}) map[string]interface{} {
+	geo := make(map[string]interface{})
+	if len(configured) == 0 || configured[0] == nil {
+		return geo
+	}
+	geoLocation := configured[0].(map[string]interface{})
+	if regions := toListOfInt(geoLocation["geo_ip_user_region"]); len(regions) > 0 {
+		geo["geoipUserRegion"] = regions
+	}
+	if filter := toIntValue(geoLocation["geo_ip_filter"]); filter != 0 {
+		geo["geoipFilter"] = filter
+	}
+	if proximity := toIntValue(geoLocation["geo_ip_proximity"]); proximity != 0 {
+		geo["geoipProximity"] = proximity
+	}
+	geo["drop"] = toBool(geoLocation["drop"])
+	geo["geoipFailover"] = toBool(geoLocation["geo_ip_failover"])
+	return geo
+}
+
+// flattenGeoLocation returns the geo_location block of the geolocation of a
+// record, or none when the geolocation is empty.
+func flattenGeoLocation(geo map[string]interface{}) []interface{} {
+	empty := true
+	for _, value := range geo {
+		empty = empty && value == nil
+	}
+	if empty {
+		return []interface{}{}
+	}
+	return []interface{}{map[string]interface{}{
+		"geo_ip_user_region": toIntValues(geo["geoipUserRegion"]),
+		"geo_ip_filter":      toIntValue(geo["geoipFilter"]),
+		"geo_ip_proximity":   toIntValue(geo["geoipProximity"]),
+		"drop":               toBool(geo["drop"]),
+		"geo_ip_failover":    toBool(geo["geoipFailover"]),
+	}}
+}
+
+// geoLocationUpgrader upgrades the state of record types that declared
+// geo_location as a map of strings before version+1 of their schema.
+func geoLocationUpgrader(r *schema.Resource, version int) schema.StateUpgrader {
+	previous := make(map[string]*schema.Schema, len(r.Schema))
+	for name, attr := range r.Schema {
+		previous[name] = attr
+	}
+	previous["geo_location"] = &schema.Schema{
+		Type:     schema.TypeMap,
+		Optional: true,
+		Computed: true,
+		Elem:     &schema.Schema{Type: schema.TypeString},
+	}
+	return schema.StateUpgrader{
+		Version: version,
+		Type:    (&schema.Resource{Schema: previous}).CoreConfigSchema().ImpliedType(),
+		Upgrade: upgradeGeoLocation,
+	}
+}
+
+func upgradeGeoLocation(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	if rawState == nil {
+		return rawState, nil
+	}
+	geoLocation, _ := rawState["geo_location"].(map[string]interface{})
+	if len(geoLocation) == 0 {
+		rawState["geo_location"] = []interface{}{}
+		return rawState, nil
+	}
+	var regions []interface{}
+	if region := toIntValue(geoLocation["geo_ip_user_region"]); region != 0 {
+		regions = []interface{}{region}
+	}
+	rawState["geo_location"] = []interface{}{map[string]interface{}{
+		"geo_ip_user_region": regions,
+		"geo_ip_filter":      0,
+		"geo_ip_proximity":   toIntValue(geoLocation["geo_ip_proximity"]),
+		"drop":               toBool(geoLocation["drop"]),
+		"geo_ip_failover":    toBool(geoLocation["geo_ip_failover"]),
+	}}
+	return rawState, nil
+}
+
+// expandFailoverValues returns the values of a record failover or a round
+// robin failover in the order the API tries them.
+func expandFailoverValues(configured []interface{}) []interface{} {
+	values := make([]interface{}, 0, len(configured))
+	for _, elem := range configured {
+		inner := elem.(map[string]interface{})
+		value := map[string]interface{}{
+			"value":       normalizeHostname(inner["value"]),
+			"sortOrder":   toIntValue(inner["sort_order"]),
+			"disableFlag": toBool(inner["disable_flag"]),
+		}
+		if checkID := toIntValue(inner["check_id"]); checkID != 0 {
+			value["checkId"] = checkID
+		}
+		values = append(values, value)
+	}
+	sort.SliceStable(values, func(i, j int) bool {
+		return values[i].(map[string]interface{})["sortOrder"].(int) < values[j].(map[string]interface{})["sortOrder"].(int)
+	})
+	return values
+}
+
+func flattenFailoverValues(answered interface{}) []interface{} {
+	elems, _ := answered.([]interface{})
+	values := make([]interface{}, 0, len(elems))
+	for _, elem := range elems {
+		inner, _ := elem.(map[string]interface{})
+		value := map[string]interface{}{
+			"value":        toStringValue(inner["value"]),
+			"sort_order":   toStringValue(inner["sortOrder"]),
+			"disable_flag": toBool(inner["disableFlag"]),
+			"check_id":     toIntValue(inner["checkId"]),
+		}
+		values = append(values, value)
+	}
+	return values
+}
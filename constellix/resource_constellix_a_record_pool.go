@@ -1,27 +1,30 @@
 package constellix
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"log"
 	"strconv"
 
-	"github.com/Constellix/constellix-go-client/client"
-	"github.com/Constellix/constellix-go-client/models"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/terraform-providers/terraform-provider-constellix/client"
 )
 
 func resourceConstellixARecordPool() *schema.Resource {
-	return &schema.Resource{
-		Create: resourceConstellixARecordPoolCreate,
-		Update: resourceConstellixARecordPoolUpdate,
-		Read:   resourceConstellixARecordPoolRead,
-		Delete: resourceConstellixARecordPoolDelete,
+	r := &schema.Resource{
+		CreateContext: resourceConstellixARecordPoolCreate,
+		UpdateContext: resourceConstellixARecordPoolUpdate,
+		ReadContext:   resourceConstellixARecordPoolRead,
+		DeleteContext: resourceConstellixARecordPoolDelete,
 		Importer: &schema.ResourceImporter{
-			State: resourceConstellixARecordPoolImport,
+			StateContext: resourceConstellixARecordPoolImport,
 		},
 
+		SchemaVersion: 1,
+
 		Schema: map[string]*schema.Schema{
 
 			"name": &schema.Schema{
@@ -58,7 +61,7 @@ func resourceConstellixARecordPool() *schema.Resource {
 			},
 
 			"disable_flag": &schema.Schema{
-				Type:     schema.TypeString,
+				Type:     schema.TypeBool,
 				Optional: true,
 				Computed: true,
 			},
@@ -79,7 +82,7 @@ func resourceConstellixARecordPool() *schema.Resource {
 						},
 
 						"disable_flag": &schema.Schema{
-							Type:     schema.TypeString,
+							Type:     schema.TypeBool,
 							Optional: true,
 							Computed: true,
 						},
@@ -97,17 +100,15 @@ func resourceConstellixARecordPool() *schema.Resource {
 			},
 		},
 	}
+	r.StateUpgraders = []schema.StateUpgrader{disableFlagUpgrader(r, 0)}
+	return r
 }
 
-func resourceConstellixARecordPoolImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+func resourceConstellixARecordPoolImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
 	log.Printf("[DEBUG] %s: Beginning Import", d.Id())
 	constellixClient := m.(*client.Client)
-	resp, err := constellixClient.GetbyId("v1/pools/A/" + d.Id())
+	resp, err := constellixClient.GetbyIdContext(ctx, "v1/pools/A/"+d.Id())
 	if err != nil {
-		if resp.StatusCode == 404 {
-			d.SetId("")
-			return nil, err
-		}
 		return nil, err
 	}
 	bodybytes, err := ioutil.ReadAll(resp.Body)
@@ -124,7 +125,7 @@ func resourceConstellixARecordPoolImport(d *schema.ResourceData, m interface{})
 	d.Set("note", data["note"])
 	d.Set("version", data["version"])
 	d.Set("failed_flag", data["failedFlag"])
-	d.Set("disable_flag", data["disableFlag"])
+	d.Set("disable_flag", toBool(data["disableFlag"]))
 	resrr := (data["values"]).([]interface{})
 	mapListRR := make([]interface{}, 0, 1)
 	for _, val := range resrr {
@@ -132,8 +133,8 @@ func resourceConstellixARecordPoolImport(d *schema.ResourceData, m interface{})
 		inner := val.(map[string]interface{})
 		tpMap["value"] = fmt.Sprintf("%v", inner["value"])
 		tpMap["weight"], _ = strconv.Atoi(fmt.Sprintf("%v", inner["weight"]))
-		tpMap["disable_flag"] = fmt.Sprintf("%v", inner["disableFlag"])
-		tpMap["policy"] = fmt.Sprintf("%v", inner["policy"])
+		tpMap["disable_flag"] = toBool(inner["disableFlag"])
+		tpMap["policy"] = toStringValue(inner["policy"])
 		tpMap["check_id"], _ = strconv.Atoi(fmt.Sprintf("%v", inner["checkId"]))
 
 		mapListRR = append(mapListRR, tpMap)
@@ -145,10 +146,10 @@ func resourceConstellixARecordPoolImport(d *schema.ResourceData, m interface{})
 
 }
 
-func resourceConstellixARecordPoolCreate(d *schema.ResourceData, m interface{}) error {
+func resourceConstellixARecordPoolCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*client.Client)
 
-	arecordpoolAttr := models.ARecordPoolAttributes{}
+	arecordpoolAttr := ARecordPoolAttributes{}
 
 	if name, ok := d.GetOk("name"); ok {
 		arecordpoolAttr.Name = name.(string)
@@ -174,9 +175,7 @@ func resourceConstellixARecordPoolCreate(d *schema.ResourceData, m interface{})
 		arecordpoolAttr.FailedFlag = ff.(string)
 	}
 
-	if df1, ok := d.GetOk("disable_flag"); ok {
-		arecordpoolAttr.DisableFlag1 = df1.(string)
-	}
+	arecordpoolAttr.DisableFlag = d.Get("disable_flag").(bool)
 
 	if rr, ok := d.GetOk("values"); ok {
 		mapListRR := make([]interface{}, 0, 1)
@@ -186,23 +185,23 @@ func resourceConstellixARecordPoolCreate(d *schema.ResourceData, m interface{})
 			inner := val.(map[string]interface{})
 			tpMap["value"] = fmt.Sprintf("%v", inner["value"])
 			tpMap["weight"], _ = strconv.Atoi(fmt.Sprintf("%v", inner["weight"]))
-			tpMap["disableFlag"] = fmt.Sprintf("%v", inner["disable_flag"])
+			tpMap["disableFlag"] = toBool(inner["disable_flag"])
 			tpMap["checkId"], _ = strconv.Atoi(fmt.Sprintf("%v", inner["check_id"]))
-			tpMap["policy"] = fmt.Sprintf("%v", inner["policy"])
+			tpMap["policy"] = toStringValue(inner["policy"])
 
 			mapListRR = append(mapListRR, tpMap)
 		}
 		arecordpoolAttr.Values = mapListRR
 	}
 
-	resp, err := client.Save(arecordpoolAttr, "v1/pools/A")
+	resp, err := client.SaveContext(ctx, arecordpoolAttr, "v1/pools/A")
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	bodybtes, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	bodystring := string(bodybtes)
 	log.Println("Body String of ARecordPool Respince :", bodystring)
@@ -210,12 +209,12 @@ func resourceConstellixARecordPoolCreate(d *schema.ResourceData, m interface{})
 	json.Unmarshal([]byte(bodystring[1:len(bodystring)-1]), &data)
 
 	d.SetId(fmt.Sprintf("%.0f", data["id"]))
-	return resourceConstellixARecordPoolRead(d, m)
+	return resourceConstellixARecordPoolRead(ctx, d, m)
 }
 
-func resourceConstellixARecordPoolUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceConstellixARecordPoolUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*client.Client)
-	arecordpoolAttr := models.ARecordPoolAttributes{}
+	arecordpoolAttr := ARecordPoolAttributes{}
 
 	arecordpoolAttr.Name = d.Get("name").(string)
 
@@ -227,9 +226,7 @@ func resourceConstellixARecordPoolUpdate(d *schema.ResourceData, m interface{})
 		arecordpoolAttr.FailedFlag = d.Get("failed_flag").(string)
 	}
 
-	if _, ok := d.GetOk("disable_flag"); ok {
-		arecordpoolAttr.DisableFlag1 = d.Get("disable_flag").(string)
-	}
+	arecordpoolAttr.DisableFlag = d.Get("disable_flag").(bool)
 
 	if _, ok := d.GetOk("note"); ok {
 		arecordpoolAttr.Note = d.Get("note").(string)
@@ -247,9 +244,9 @@ func resourceConstellixARecordPoolUpdate(d *schema.ResourceData, m interface{})
 			inner := val.(map[string]interface{})
 			tpMap["value"] = fmt.Sprintf("%v", inner["value"])
 			tpMap["weight"], _ = strconv.Atoi(fmt.Sprintf("%v", inner["weight"]))
-			tpMap["disableFlag"] = fmt.Sprintf("%v", inner["disable_flag"])
+			tpMap["disableFlag"] = toBool(inner["disable_flag"])
 			tpMap["checkId"], _ = strconv.Atoi(fmt.Sprintf("%v", inner["check_id"]))
-			tpMap["policy"] = fmt.Sprintf("%v", inner["policy"])
+			tpMap["policy"] = toStringValue(inner["policy"])
 
 			mapListRR = append(mapListRR, tpMap)
 		}
@@ -257,28 +254,24 @@ func resourceConstellixARecordPoolUpdate(d *schema.ResourceData, m interface{})
 	}
 
 	arecordpoolid := d.Id()
-	_, err := client.UpdatebyID(arecordpoolAttr, "v1/pools/A/"+arecordpoolid)
+	_, err := client.UpdatebyIDContext(ctx, arecordpoolAttr, "v1/pools/A/"+arecordpoolid)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	return resourceConstellixARecordPoolRead(d, m)
+	return resourceConstellixARecordPoolRead(ctx, d, m)
 }
 
-func resourceConstellixARecordPoolRead(d *schema.ResourceData, m interface{}) error {
+func resourceConstellixARecordPoolRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*client.Client)
 	arecordpoolid := d.Id()
 
-	resp, err := client.GetbyId("v1/pools/A/" + arecordpoolid)
-	if err != nil {
-		if resp.StatusCode == 404 {
-			d.SetId("")
-			return nil
-		}
-		return err
+	resp, err := readOrMarkGone(ctx, d, client, "v1/pools/A/"+arecordpoolid)
+	if err != nil || d.Id() == "" {
+		return diag.FromErr(err)
 	}
 	bodybytes, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	bodystring := string(bodybytes)
 	var data map[string]interface{}
@@ -290,7 +283,7 @@ func resourceConstellixARecordPoolRead(d *schema.ResourceData, m interface{}) er
 	d.Set("note", data["note"])
 	d.Set("version", data["version"])
 	d.Set("failed_flag", data["failedFlag"])
-	d.Set("disable_flag", data["disableFlag"])
+	d.Set("disable_flag", toBool(data["disableFlag"]))
 	resrr := (data["values"]).([]interface{})
 	mapListRR := make([]interface{}, 0, 1)
 	for _, val := range resrr {
@@ -298,8 +291,8 @@ func resourceConstellixARecordPoolRead(d *schema.ResourceData, m interface{}) er
 		inner := val.(map[string]interface{})
 		tpMap["value"] = fmt.Sprintf("%v", inner["value"])
 		tpMap["weight"], _ = strconv.Atoi(fmt.Sprintf("%v", inner["weight"]))
-		tpMap["disable_flag"] = fmt.Sprintf("%v", inner["disableFlag"])
-		tpMap["policy"] = fmt.Sprintf("%v", inner["policy"])
+		tpMap["disable_flag"] = toBool(inner["disableFlag"])
+		tpMap["policy"] = toStringValue(inner["policy"])
 		tpMap["check_id"], _ = strconv.Atoi(fmt.Sprintf("%v", inner["checkId"]))
 
 		mapListRR = append(mapListRR, tpMap)
@@ -309,13 +302,13 @@ func resourceConstellixARecordPoolRead(d *schema.ResourceData, m interface{}) er
 	return nil
 }
 
-func resourceConstellixARecordPoolDelete(d *schema.ResourceData, m interface{}) error {
+func resourceConstellixARecordPoolDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*client.Client)
 	dn := d.Id()
 
-	err := client.DeletebyId("v1/pools/A/" + dn)
+	err := client.DeletebyIdContext(ctx, "v1/pools/A/"+dn)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	d.SetId("")
 	return nil
@@ -1,6 +1,7 @@
 package constellix
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -8,18 +9,19 @@ import (
 	"strconv"
 	"testing"
 
-	"github.com/Constellix/constellix-go-client/client"
 	"github.com/Constellix/constellix-go-client/models"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
-	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/terraform-providers/terraform-provider-constellix/client"
 )
 
 func TestAccARecordPool_Basic(t *testing.T) {
 	var arp models.ARecordPoolAttributes
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckConstellixARecordPoolDestroy,
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckConstellixARecordPoolDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCheckConstellixARecordPoolConfig_basic(1),
@@ -36,9 +38,9 @@ func TestAccConstellixARecordPool_Update(t *testing.T) {
 	var arp models.ARecordPoolAttributes
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckConstellixARecordPoolDestroy,
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckConstellixARecordPoolDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCheckConstellixARecordPoolConfig_basic(1),
@@ -151,3 +153,68 @@ func testAccCheckConstellixARecordPoolAttributes(numreturn interface{}, arp *mod
 		return nil
 	}
 }
+
+func TestConstellixARecordPoolLifecycle(t *testing.T) {
+	f := newFakeAPI(t)
+
+	pool := func(weight int) map[string]interface{} {
+		return map[string]interface{}{
+			"name":                   "web",
+			"num_return":             1,
+			"min_available_failover": 1,
+			"values": []interface{}{
+				map[string]interface{}{"value": "192.0.2.1", "weight": weight, "policy": "followsonar"},
+				map[string]interface{}{"value": "192.0.2.2", "weight": 20, "policy": "followsonar"},
+			},
+		}
+	}
+	f.lifecycle(lifecycleTestCase{
+		resource:   "constellix_a_record_pool",
+		create:     pool(10),
+		update:     pool(30),
+		dataSource: map[string]interface{}{"name": "web"},
+	})
+}
+
+func TestConstellixARecordPoolDisableFlags(t *testing.T) {
+	f := newFakeAPI(t)
+	r := f.provider.ResourcesMap["constellix_a_record_pool"]
+
+	config := map[string]interface{}{
+		"name":                   "web",
+		"num_return":             1,
+		"min_available_failover": 1,
+		"disable_flag":           true,
+		"values": []interface{}{
+			map[string]interface{}{"value": "192.0.2.1", "weight": 10, "policy": "followsonar", "disable_flag": true},
+		},
+	}
+	state := f.apply(r, nil, config)
+	obj, _ := f.server.Object("v1/pools/A/" + state.ID)
+	values, _ := obj["values"].([]interface{})
+	if obj["disableFlag"] != true || len(values) != 1 || values[0].(map[string]interface{})["disableFlag"] != true {
+		t.Errorf("expected the disable flags to be sent as booleans, got %v", obj)
+	}
+	f.checkNoChanges(r, f.refresh(r, state), config)
+
+	// State saved when the disable flags were strings upgrades without
+	// changes.
+	upgraded, err := r.StateUpgraders[0].Upgrade(context.Background(), map[string]interface{}{
+		"id":                     state.ID,
+		"name":                   "web",
+		"num_return":             1,
+		"min_available_failover": 1,
+		"disable_flag":           "true",
+		"values": []interface{}{
+			map[string]interface{}{"value": "192.0.2.1", "weight": 10, "policy": "followsonar", "disable_flag": "true"},
+		},
+	}, f.client)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	value, err := schema.JSONMapToStateValue(upgraded, r.CoreConfigSchema())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	f.checkNoChanges(r, f.refresh(r, terraform.NewInstanceStateShimmedFromValue(value, r.SchemaVersion)), config)
+}
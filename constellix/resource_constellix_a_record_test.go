@@ -1,6 +1,7 @@
 package constellix
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -8,18 +9,19 @@ import (
 	"strconv"
 	"testing"
 
-	"github.com/Constellix/constellix-go-client/client"
 	"github.com/Constellix/constellix-go-client/models"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
-	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/terraform-providers/terraform-provider-constellix/client"
 )
 
 func TestAccA_Basic(t *testing.T) {
 	var a models.ARecordAttributes
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckConstellixARecordDestroy,
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckConstellixARecordDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCheckConstellixARecordConfig_basic(1800),
@@ -36,9 +38,9 @@ func TestAccConstellixARecord_Update(t *testing.T) {
 	var a models.ARecordAttributes
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckConstellixARecordDestroy,
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckConstellixARecordDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCheckConstellixARecordConfig_basic(1800),
@@ -62,13 +64,13 @@ func testAccCheckConstellixARecordConfig_basic(ttl int) string {
 	return fmt.Sprintf(`
 	resource "constellix_domain" "domain1" {
 		name = "checkarecord.com"
-		soa = {
+		soa {
 			email = "com.com."
 			primary_nameserver = "ns41.constellix.com."
 			ttl = 1900
-			refresh = 48100
+			refresh = 43200
 			retry = 7200
-			expire = 1209
+			expire = 1209600
 			negcache = 8000
 		}
 	}
@@ -81,11 +83,11 @@ func testAccCheckConstellixARecordConfig_basic(ttl int) string {
 		note = "Practice record"
 		roundrobin  {
 			     value       = "16.45.25.35"
-			     disable_flag = "false"
+			     disable_flag = false
 		}
 		roundrobin {
 			       value = "15.45.25.30"
-			       disable_flag = "true"
+			       disable_flag = true
 		}			   
 		record_failover_failover_type = 2
 			  
@@ -179,3 +181,119 @@ func arecordfromcontainer(resp *http.Response) (*models.ARecordAttributes, error
 	return &model, nil
 
 }
+
+func TestConstellixARecordLifecycle(t *testing.T) {
+	f := newFakeAPI(t)
+	domainID := f.domain("example.com")
+
+	a := func(ttl int, values ...string) map[string]interface{} {
+		roundrobin := make([]interface{}, 0, len(values))
+		for _, value := range values {
+			roundrobin = append(roundrobin, map[string]interface{}{"value": value, "disable_flag": false})
+		}
+		return map[string]interface{}{
+			"domain_id":   domainID,
+			"source_type": "domains",
+			"name":        "www",
+			"ttl":         ttl,
+			"note":        "managed by terraform",
+			"roundrobin":  roundrobin,
+		}
+	}
+	f.lifecycle(lifecycleTestCase{
+		resource:   "constellix_a_record",
+		create:     a(300, "192.0.2.1"),
+		update:     a(600, "192.0.2.1", "192.0.2.2"),
+		importID:   recordImportID,
+		dataSource: map[string]interface{}{"domain_id": domainID, "source_type": "domains", "name": "www"},
+	})
+}
+
+func TestConstellixARecordGeoLocation(t *testing.T) {
+	f := newFakeAPI(t)
+	domainID := f.domain("example.com")
+	filterID, _ := strconv.Atoi(f.create("v1/geoFilters", map[string]interface{}{"name": "europe", "geoipContinents": []string{"EU"}}))
+	r := f.provider.ResourcesMap["constellix_a_record"]
+
+	a := func(geoLocation map[string]interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"domain_id":    domainID,
+			"source_type":  "domains",
+			"name":         "www",
+			"ttl":          300,
+			"roundrobin":   []interface{}{map[string]interface{}{"value": "192.0.2.1", "disable_flag": false}},
+			"geo_location": []interface{}{geoLocation},
+		}
+	}
+	geolocation := func(id string) map[string]interface{} {
+		obj, _ := f.server.Object("v1/domains/" + domainID + "/records/a/" + id)
+		geo, _ := obj["geolocation"].(map[string]interface{})
+		return geo
+	}
+
+	config := a(map[string]interface{}{"geo_ip_user_region": []interface{}{1, 2}, "drop": true})
+	state := f.apply(r, nil, config)
+	geo := geolocation(state.ID)
+	if fmt.Sprint(geo["geoipUserRegion"]) != "[1 2]" || geo["drop"] != true || geo["geoipFilter"] != nil {
+		t.Errorf("expected the regions 1 and 2 to be sent, got %v", geo)
+	}
+	if state.Attributes["geo_location.0.geo_ip_user_region.#"] != "2" || state.Attributes["geo_location.0.drop"] != "true" {
+		t.Errorf("unexpected geo_location %v", state.Attributes)
+	}
+	f.checkNoChanges(r, f.refresh(r, state), config)
+
+	// A geo filter the API answers for the regions is not configured, so it
+	// does not show as a change.
+	path := "v1/domains/" + domainID + "/records/a/" + state.ID
+	obj, _ := f.server.Object(path)
+	obj["geolocation"].(map[string]interface{})["geoipFilter"] = filterID
+	if _, err := f.client.UpdatebyID(obj, path); err != nil {
+		t.Fatalf("rewriting %s: %s", path, err)
+	}
+	state = f.refresh(r, state)
+	if v := state.Attributes["geo_location.0.geo_ip_filter"]; v != "0" {
+		t.Errorf("expected the answered geo filter not to be read, got %q", v)
+	}
+	f.checkNoChanges(r, state, config)
+
+	// A geo filter takes the place of the regions without replacing the
+	// record.
+	config = a(map[string]interface{}{"geo_ip_filter": filterID, "geo_ip_failover": true})
+	id := state.ID
+	state = f.apply(r, state, config)
+	if state.ID != id {
+		t.Errorf("expected update in place, ID changed from %s to %s", id, state.ID)
+	}
+	geo = geolocation(state.ID)
+	if geo["geoipFilter"] != float64(filterID) || geo["geoipUserRegion"] != nil || geo["geoipFailover"] != true {
+		t.Errorf("expected geo filter %d to be sent, got %v", filterID, geo)
+	}
+	f.checkNoChanges(r, f.refresh(r, state), config)
+
+	both := a(map[string]interface{}{"geo_ip_filter": filterID, "geo_ip_user_region": []interface{}{1}})
+	if diags := r.Validate(terraform.NewResourceConfigRaw(both)); !diags.HasError() {
+		t.Errorf("expected regions and a geo filter to conflict")
+	}
+
+	// State saved when geo_location was a map of strings upgrades to the
+	// block without changes.
+	config = a(map[string]interface{}{"geo_ip_user_region": []interface{}{1}})
+	state = f.apply(r, state, config)
+	upgraded, err := r.StateUpgraders[0].Upgrade(context.Background(), map[string]interface{}{
+		"id":           state.ID,
+		"domain_id":    domainID,
+		"source_type":  "domains",
+		"name":         "www",
+		"ttl":          300,
+		"roundrobin":   []interface{}{map[string]interface{}{"value": "192.0.2.1", "disable_flag": false}},
+		"geo_location": map[string]interface{}{"geo_ip_user_region": "1", "drop": "false"},
+	}, f.client)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	value, err := schema.JSONMapToStateValue(upgraded, r.CoreConfigSchema())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	f.checkNoChanges(r, f.refresh(r, terraform.NewInstanceStateShimmedFromValue(value, r.SchemaVersion)), config)
+}
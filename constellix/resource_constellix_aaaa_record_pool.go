@@ -1,28 +1,32 @@
 package constellix
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"log"
 	"strconv"
 
-	"github.com/Constellix/constellix-go-client/client"
 	"github.com/Constellix/constellix-go-client/models"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/terraform-providers/terraform-provider-constellix/client"
 )
 
 func resourceConstellixAAAArecordPool() *schema.Resource {
-	return &schema.Resource{
-		Create: resourceConstellixAAAAPoolCreate,
-		Update: resourceConstellixAAAAPoolUpdate,
-		Read:   resourceConstellixAAAAPoolRead,
-		Delete: resourceConstellixAAAAPoolDelete,
+	r := &schema.Resource{
+		CreateContext: resourceConstellixAAAAPoolCreate,
+		UpdateContext: resourceConstellixAAAAPoolUpdate,
+		ReadContext:   resourceConstellixAAAAPoolRead,
+		DeleteContext: resourceConstellixAAAAPoolDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: resourceConstellixAAAAPoolImport,
+			StateContext: resourceConstellixAAAAPoolImport,
 		},
 
+		SchemaVersion: 1,
+
 		Schema: map[string]*schema.Schema{
 			"name": &schema.Schema{
 				Type:     schema.TypeString,
@@ -78,7 +82,7 @@ func resourceConstellixAAAArecordPool() *schema.Resource {
 						},
 
 						"disable_flag": &schema.Schema{
-							Type:     schema.TypeString,
+							Type:     schema.TypeBool,
 							Optional: true,
 							Computed: true,
 						},
@@ -93,19 +97,17 @@ func resourceConstellixAAAArecordPool() *schema.Resource {
 			},
 		},
 	}
+	r.StateUpgraders = []schema.StateUpgrader{disableFlagUpgrader(r, 0)}
+	return r
 }
 
-func resourceConstellixAAAAPoolImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+func resourceConstellixAAAAPoolImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
 	log.Printf("[DEBUG] %s: Beginning Import", d.Id())
 	constellixClient := m.(*client.Client)
 	dn := d.Id()
 
-	resp, err := constellixClient.GetbyId("v1/pools/AAAA/" + dn)
+	resp, err := constellixClient.GetbyIdContext(ctx, "v1/pools/AAAA/"+dn)
 	if err != nil {
-		if resp.StatusCode == 404 {
-			d.SetId("")
-			return nil, err
-		}
 		return nil, err
 	}
 	bodybytes, err := ioutil.ReadAll(resp.Body)
@@ -121,7 +123,7 @@ func resourceConstellixAAAAPoolImport(d *schema.ResourceData, m interface{}) ([]
 	d.Set("num_return", data["numReturn"])
 	d.Set("min_available_failover", data["minAvailableFailover"])
 	d.Set("failed_flag", data["failedFlag"])
-	d.Set("disable_flag", data["disableFlag"])
+	d.Set("disable_flag", toBool(data["disableFlag"]))
 	d.Set("note", data["note"])
 
 	resrr := (data["values"]).([]interface{})
@@ -130,9 +132,9 @@ func resourceConstellixAAAAPoolImport(d *schema.ResourceData, m interface{}) ([]
 		tpMap := make(map[string]interface{})
 		inner := val.(map[string]interface{})
 		tpMap["value"] = fmt.Sprintf("%v", inner["value"])
-		tpMap["weight"], _ = strconv.Atoi(fmt.Sprintf("%v", inner["weight"]))
-		tpMap["disable_flag"] = fmt.Sprintf("%v", inner["disableFlag"])
-		tpMap["policy"] = fmt.Sprintf("%v", inner["policy"])
+		tpMap["weight"] = fmt.Sprintf("%v", inner["weight"])
+		tpMap["disable_flag"] = toBool(inner["disableFlag"])
+		tpMap["policy"] = toStringValue(inner["policy"])
 		tpMap["check_id"], _ = strconv.Atoi(fmt.Sprintf("%v", inner["checkId"]))
 
 		mapListRR = append(mapListRR, tpMap)
@@ -143,7 +145,7 @@ func resourceConstellixAAAAPoolImport(d *schema.ResourceData, m interface{}) ([]
 	return []*schema.ResourceData{d}, nil
 }
 
-func resourceConstellixAAAAPoolCreate(d *schema.ResourceData, m interface{}) error {
+func resourceConstellixAAAAPoolCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*client.Client)
 
 	aaaapoolAttr := models.AAAArecordPoolAttributes{}
@@ -179,35 +181,35 @@ func resourceConstellixAAAAPoolCreate(d *schema.ResourceData, m interface{}) err
 			tpMap := make(map[string]interface{})
 			inner := val.(map[string]interface{})
 			tpMap["value"] = fmt.Sprintf("%v", inner["value"])
-			tpMap["weight"], _ = strconv.Atoi(fmt.Sprintf("%v", inner["weight"]))
-			tpMap["disable_flag"] = fmt.Sprintf("%v", inner["disable_flag"])
-			tpMap["check_id"], _ = strconv.Atoi(fmt.Sprintf("%v", inner["check_id"]))
-			tpMap["policy"] = fmt.Sprintf("%v", inner["policy"])
+			tpMap["weight"] = fmt.Sprintf("%v", inner["weight"])
+			tpMap["disableFlag"] = toBool(inner["disable_flag"])
+			tpMap["checkId"], _ = strconv.Atoi(fmt.Sprintf("%v", inner["check_id"]))
+			tpMap["policy"] = toStringValue(inner["policy"])
 
 			mapListRR = append(mapListRR, tpMap)
 		}
 		aaaapoolAttr.Values = mapListRR
 	}
 
-	resp, err := client.Save(aaaapoolAttr, "v1/pools/AAAA")
+	resp, err := client.SaveContext(ctx, aaaapoolAttr, "v1/pools/AAAA")
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	//Managing response and extracting id of resource
 	bodybtes, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	bodystring := string(bodybtes)
 	var data map[string]interface{}
 	json.Unmarshal([]byte(bodystring[1:len(bodystring)-1]), &data)
 
 	d.SetId(fmt.Sprintf("%.0f", data["id"]))
-	return resourceConstellixAAAAPoolRead(d, m)
+	return resourceConstellixAAAAPoolRead(ctx, d, m)
 }
 
-func resourceConstellixAAAAPoolUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceConstellixAAAAPoolUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*client.Client)
 
 	aaaapoolAttr := models.AAAArecordPoolAttributes{}
@@ -243,10 +245,10 @@ func resourceConstellixAAAAPoolUpdate(d *schema.ResourceData, m interface{}) err
 			tpMap := make(map[string]interface{})
 			inner := val.(map[string]interface{})
 			tpMap["value"] = fmt.Sprintf("%v", inner["value"])
-			tpMap["weight"], _ = strconv.Atoi(fmt.Sprintf("%v", inner["weight"]))
-			tpMap["disableFlag"] = fmt.Sprintf("%v", inner["disable_flag"])
+			tpMap["weight"] = fmt.Sprintf("%v", inner["weight"])
+			tpMap["disableFlag"] = toBool(inner["disable_flag"])
 			tpMap["checkId"], _ = strconv.Atoi(fmt.Sprintf("%v", inner["check_id"]))
-			tpMap["policy"] = fmt.Sprintf("%v", inner["policy"])
+			tpMap["policy"] = toStringValue(inner["policy"])
 
 			mapListRR = append(mapListRR, tpMap)
 		}
@@ -255,30 +257,26 @@ func resourceConstellixAAAAPoolUpdate(d *schema.ResourceData, m interface{}) err
 
 	dn := d.Id()
 
-	_, err := client.UpdatebyID(aaaapoolAttr, "v1/pools/AAAA/"+dn)
+	_, err := client.UpdatebyIDContext(ctx, aaaapoolAttr, "v1/pools/AAAA/"+dn)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	//Managing response and extracting id of resource
-	return resourceConstellixAAAAPoolRead(d, m)
+	return resourceConstellixAAAAPoolRead(ctx, d, m)
 }
 
-func resourceConstellixAAAAPoolRead(d *schema.ResourceData, m interface{}) error {
+func resourceConstellixAAAAPoolRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*client.Client)
 	dn := d.Id()
 
-	resp, err := client.GetbyId("v1/pools/AAAA/" + dn)
-	if err != nil {
-		if resp.StatusCode == 404 {
-			d.SetId("")
-			return nil
-		}
-		return err
+	resp, err := readOrMarkGone(ctx, d, client, "v1/pools/AAAA/"+dn)
+	if err != nil || d.Id() == "" {
+		return diag.FromErr(err)
 	}
 	bodybytes, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	bodystring := string(bodybytes)
 
@@ -289,7 +287,7 @@ func resourceConstellixAAAAPoolRead(d *schema.ResourceData, m interface{}) error
 	d.Set("num_return", data["numReturn"])
 	d.Set("min_available_failover", data["minAvailableFailover"])
 	d.Set("failed_flag", data["failedFlag"])
-	d.Set("disable_flag", data["disableFlag"])
+	d.Set("disable_flag", toBool(data["disableFlag"]))
 	d.Set("note", data["note"])
 
 	resrr := (data["values"]).([]interface{})
@@ -298,9 +296,9 @@ func resourceConstellixAAAAPoolRead(d *schema.ResourceData, m interface{}) error
 		tpMap := make(map[string]interface{})
 		inner := val.(map[string]interface{})
 		tpMap["value"] = fmt.Sprintf("%v", inner["value"])
-		tpMap["weight"], _ = strconv.Atoi(fmt.Sprintf("%v", inner["weight"]))
-		tpMap["disable_flag"] = fmt.Sprintf("%v", inner["disableFlag"])
-		tpMap["policy"] = fmt.Sprintf("%v", inner["policy"])
+		tpMap["weight"] = fmt.Sprintf("%v", inner["weight"])
+		tpMap["disable_flag"] = toBool(inner["disableFlag"])
+		tpMap["policy"] = toStringValue(inner["policy"])
 		tpMap["check_id"], _ = strconv.Atoi(fmt.Sprintf("%v", inner["checkId"]))
 
 		mapListRR = append(mapListRR, tpMap)
@@ -310,13 +308,13 @@ func resourceConstellixAAAAPoolRead(d *schema.ResourceData, m interface{}) error
 	return nil
 }
 
-func resourceConstellixAAAAPoolDelete(d *schema.ResourceData, m interface{}) error {
+func resourceConstellixAAAAPoolDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*client.Client)
 	dn := d.Id()
 
-	err := client.DeletebyId("v1/pools/AAAA/" + dn)
+	err := client.DeletebyIdContext(ctx, "v1/pools/AAAA/"+dn)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	d.SetId("")
 	return nil
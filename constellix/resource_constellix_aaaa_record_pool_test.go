@@ -8,18 +8,18 @@ import (
 	"strconv"
 	"testing"
 
-	"github.com/Constellix/constellix-go-client/client"
 	"github.com/Constellix/constellix-go-client/models"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
-	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/terraform-providers/terraform-provider-constellix/client"
 )
 
 func TestAccAAAARecordPool_Basic(t *testing.T) {
 	var aaaarp models.AAAArecordPoolAttributes
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckConstellixAAAARecordPoolDestroy,
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckConstellixAAAARecordPoolDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCheckConstellixAAAARecordPoolConfig_basic(20),
@@ -36,9 +36,9 @@ func TestAccConstellixAAAARecordPool_Update(t *testing.T) {
 	var aaaarp models.AAAArecordPoolAttributes
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckConstellixAAAARecordPoolDestroy,
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckConstellixAAAARecordPoolDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCheckConstellixAAAARecordPoolConfig_basic(20),
@@ -152,3 +152,25 @@ func testAccCheckConstellixAAAARecordPoolAttributes(numreturn interface{}, arp *
 		return nil
 	}
 }
+
+func TestConstellixAAAARecordPoolLifecycle(t *testing.T) {
+	f := newFakeAPI(t)
+
+	pool := func(weight int) map[string]interface{} {
+		return map[string]interface{}{
+			"name":                   "web",
+			"num_return":             1,
+			"min_available_failover": 1,
+			"values": []interface{}{
+				map[string]interface{}{"value": "2001:db8::1", "weight": weight, "policy": "followsonar"},
+				map[string]interface{}{"value": "2001:db8::2", "weight": 20, "policy": "followsonar"},
+			},
+		}
+	}
+	f.lifecycle(lifecycleTestCase{
+		resource:   "constellix_aaaa_record_pool",
+		create:     pool(10),
+		update:     pool(30),
+		dataSource: map[string]interface{}{"name": "web"},
+	})
+}
@@ -8,18 +8,18 @@ import (
 	"strconv"
 	"testing"
 
-	"github.com/Constellix/constellix-go-client/client"
 	"github.com/Constellix/constellix-go-client/models"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
-	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/terraform-providers/terraform-provider-constellix/client"
 )
 
 func TestAccAaaa_Basic(t *testing.T) {
 	var aaaa models.AAAARecordAttributes
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckConstellixAaaaDestroy,
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckConstellixAaaaDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCheckConstellixAaaaConfig_basic(1800),
@@ -36,9 +36,9 @@ func TestAccConstellixAaaa_Update(t *testing.T) {
 	var model models.AAAARecordAttributes
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckConstellixAaaaDestroy,
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckConstellixAaaaDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCheckConstellixAaaaConfig_basic(1800),
@@ -62,13 +62,13 @@ func testAccCheckConstellixAaaaConfig_basic(ttl int) string {
 	return fmt.Sprintf(`
 	resource "constellix_domain" "domain1" {
 		name = "checkaaaa.com"
-		soa = {
+		soa {
 			email = "com.com."
 			primary_nameserver = "ns41.constellix.com."
 			ttl = 1900
-			refresh = 48100
+			refresh = 43200
 			retry = 7200
-			expire = 1209
+			expire = 1209600
 			negcache = 8000
 		}
 	}
@@ -81,11 +81,11 @@ func testAccCheckConstellixAaaaConfig_basic(ttl int) string {
 		
 		roundrobin{
 			    value       = "5:0:0:0:0:0:0:6"
-			    disable_flag = "false"
+			    disable_flag = false
 				}
 		roundrobin{
 				value = "6:0:0:0:0:0:0:8"
-				disable_flag = "true"
+				disable_flag = true
 				}
 	}
 	`, ttl)
@@ -177,3 +177,29 @@ func aaaarecordfromcontainer(resp *http.Response) (*models.AAAARecordAttributes,
 	return &model, nil
 
 }
+
+func TestConstellixAAAARecordLifecycle(t *testing.T) {
+	f := newFakeAPI(t)
+	domainID := f.domain("example.com")
+
+	aaaa := func(ttl int, values ...string) map[string]interface{} {
+		roundrobin := make([]interface{}, 0, len(values))
+		for _, value := range values {
+			roundrobin = append(roundrobin, map[string]interface{}{"value": value, "disable_flag": false})
+		}
+		return map[string]interface{}{
+			"domain_id":   domainID,
+			"source_type": "domains",
+			"name":        "www",
+			"ttl":         ttl,
+			"roundrobin":  roundrobin,
+		}
+	}
+	f.lifecycle(lifecycleTestCase{
+		resource:   "constellix_aaaa_record",
+		create:     aaaa(300, "2001:db8::1"),
+		update:     aaaa(600, "2001:db8::1", "2001:db8::2"),
+		importID:   recordImportID,
+		dataSource: map[string]interface{}{"domain_id": domainID, "source_type": "domains", "name": "www"},
+	})
+}
@@ -8,18 +8,18 @@ import (
 	"strconv"
 	"testing"
 
-	"github.com/Constellix/constellix-go-client/client"
 	"github.com/Constellix/constellix-go-client/models"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
-	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/terraform-providers/terraform-provider-constellix/client"
 )
 
 func TestAccAname_Basic(t *testing.T) {
 	var aname models.AnameAttributes
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckConstellixAnameDestroy,
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckConstellixAnameDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCheckConstellixAnameConfig_basic(1800),
@@ -35,9 +35,9 @@ func TestAccAname_Basic(t *testing.T) {
 func TestAccConstellixAname_Update(t *testing.T) {
 	var model models.AnameAttributes
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckConstellixAnameDestroy,
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckConstellixAnameDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCheckConstellixAnameConfig_basic(1800),
@@ -62,13 +62,13 @@ func testAccCheckConstellixAnameConfig_basic(ttl int) string {
 	return fmt.Sprintf(`
 	resource "constellix_domain" "domain1" {
 		name = "domaintestaname.com"
-		soa = {
+		soa {
 			email = "com.com."
 			primary_nameserver = "ns41.constellix.com."
 			ttl = 1900
-			refresh = 48100
+			refresh = 43200
 			retry = 7200
-			expire = 1209
+			expire = 1209600
 			negcache = 8000
 		}
 	}
@@ -174,3 +174,29 @@ func anamefromcontainer(resp *http.Response) (*models.AnameAttributes, error) {
 	model.Note = fmt.Sprintf("%v", data["note"])
 	return &model, nil
 }
+
+func TestConstellixANAMERecordLifecycle(t *testing.T) {
+	f := newFakeAPI(t)
+	domainID := f.domain("example.com")
+
+	aname := func(ttl int, values ...string) map[string]interface{} {
+		roundrobin := make([]interface{}, 0, len(values))
+		for _, value := range values {
+			roundrobin = append(roundrobin, map[string]interface{}{"value": value, "disable_flag": false})
+		}
+		return map[string]interface{}{
+			"domain_id":   domainID,
+			"source_type": "domains",
+			"name":        "apex",
+			"ttl":         ttl,
+			"roundrobin":  roundrobin,
+		}
+	}
+	f.lifecycle(lifecycleTestCase{
+		resource:   "constellix_aname_record",
+		create:     aname(300, "lb.example.net."),
+		update:     aname(600, "lb.example.net.", "lb.example.org."),
+		importID:   recordImportID,
+		dataSource: map[string]interface{}{"domain_id": domainID, "source_type": "domains", "name": "apex"},
+	})
+}
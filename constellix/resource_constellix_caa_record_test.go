@@ -8,18 +8,18 @@ import (
 	"strconv"
 	"testing"
 
-	"github.com/Constellix/constellix-go-client/client"
 	"github.com/Constellix/constellix-go-client/models"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
-	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/terraform-providers/terraform-provider-constellix/client"
 )
 
 func TestAccCaa_Basic(t *testing.T) {
 	var caa models.CaaAttributes
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckConstellixCaaRecordDestroy,
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckConstellixCaaRecordDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCheckConstellixCaaRecordConfig_basic(1800),
@@ -36,9 +36,9 @@ func TestAccConstellixCaaRecord_Update(t *testing.T) {
 	var caa models.CaaAttributes
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckConstellixCaaRecordDestroy,
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckConstellixCaaRecordDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCheckConstellixCaaRecordConfig_basic(1800),
@@ -62,13 +62,13 @@ func testAccCheckConstellixCaaRecordConfig_basic(ttl int) string {
 	return fmt.Sprintf(`
 	resource "constellix_domain" "domain1" {
 		name = "checkcaarecord.com"
-		soa = {
+		soa {
 			email = "com.com."
 			primary_nameserver = "ns41.constellix.com."
 			ttl = 1900
-			refresh = 48100
+			refresh = 43200
 			retry = 7200
-			expire = 1209
+			expire = 1209600
 			negcache = 8000
 		}
 	}
@@ -83,14 +83,14 @@ func testAccCheckConstellixCaaRecordConfig_basic(ttl int) string {
 			tag = "issue"
 			data = "como.com"
 			flag = "0"
-			disable_flag = "false"
+			disable_flag = false
 		}
 		roundrobin{
 			caa_provider_id = 4
 			tag = "issue"
 			data = "como01.com"
 			flag = "1"
-			disable_flag = "true"
+			disable_flag = true
 		}
 	}
 	`, ttl)
@@ -183,3 +183,27 @@ func caarecordfromcontainer(resp *http.Response) (*models.CaaAttributes, error)
 	return &caa, nil
 
 }
+
+func TestConstellixCaaRecordLifecycle(t *testing.T) {
+	f := newFakeAPI(t)
+	domainID := f.domain("example.com")
+
+	caa := func(ttl int, data string) map[string]interface{} {
+		return map[string]interface{}{
+			"domain_id":   domainID,
+			"source_type": "domains",
+			"name":        "",
+			"ttl":         ttl,
+			"roundrobin": []interface{}{
+				map[string]interface{}{"caa_provider_id": 3, "tag": "issue", "data": data, "flag": "0", "disable_flag": false},
+			},
+		}
+	}
+	f.lifecycle(lifecycleTestCase{
+		resource:   "constellix_caa_record",
+		create:     caa(300, "letsencrypt.org"),
+		update:     caa(600, "pki.goog"),
+		importID:   recordImportID,
+		dataSource: map[string]interface{}{"domain_id": domainID, "source_type": "domains", "name": ""},
+	})
+}
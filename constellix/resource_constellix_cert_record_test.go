@@ -8,18 +8,18 @@ import (
 	"strconv"
 	"testing"
 
-	"github.com/Constellix/constellix-go-client/client"
 	"github.com/Constellix/constellix-go-client/models"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
-	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/terraform-providers/terraform-provider-constellix/client"
 )
 
 func TestAccCert_Basic(t *testing.T) {
 	var ct models.CertAttributes
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckConstellixCertDestroy,
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckConstellixCertDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCheckConstellixCertConfig_basic(1800),
@@ -36,9 +36,9 @@ func TestAccConstellixCert_Update(t *testing.T) {
 	var ct models.CertAttributes
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckConstellixCertDestroy,
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckConstellixCertDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCheckConstellixCertConfig_basic(1800),
@@ -62,13 +62,13 @@ func testAccCheckConstellixCertConfig_basic(ttl int) string {
 	return fmt.Sprintf(`
 	resource "constellix_domain" "domain1" {
 		name = "checkcert.com"
-		soa = {
+		soa {
 			email = "com.com."
 			primary_nameserver = "ns41.constellix.com."
 			ttl = 1900
-			refresh = 48100
+			refresh = 43200
 			retry = 7200
-			expire = 1209
+			expire = 1209600
 			negcache = 8000
 		}
 	}
@@ -82,7 +82,7 @@ func testAccCheckConstellixCertConfig_basic(ttl int) string {
 			certificate_type = 20
 			key_tag = 30
 			algorithm = 100
-			disable_flag = "true"
+			disable_flag = true
 			certificate = "certificate1"
 		}
 		roundrobin {
@@ -90,7 +90,7 @@ func testAccCheckConstellixCertConfig_basic(ttl int) string {
 			key_tag = 62
 			certificate = "certificate1"
 			algorithm = 45
-			disable_flag = "false"
+			disable_flag = false
 		}
 	}
 	`, ttl)
@@ -193,3 +193,27 @@ func testAccCheckConstellixCertAttributes(ttl interface{}, ct *models.CertAttrib
 		return nil
 	}
 }
+
+func TestConstellixCertRecordLifecycle(t *testing.T) {
+	f := newFakeAPI(t)
+	domainID := f.domain("example.com")
+
+	cert := func(ttl, keyTag int) map[string]interface{} {
+		return map[string]interface{}{
+			"domain_id":   domainID,
+			"source_type": "domains",
+			"name":        "cert",
+			"ttl":         ttl,
+			"roundrobin": []interface{}{
+				map[string]interface{}{"certificate_type": 1, "key_tag": keyTag, "algorithm": 8, "certificate": "MIIBIjANBgkq", "disable_flag": false},
+			},
+		}
+	}
+	f.lifecycle(lifecycleTestCase{
+		resource:   "constellix_cert_record",
+		create:     cert(300, 30),
+		update:     cert(600, 62),
+		importID:   recordImportID,
+		dataSource: map[string]interface{}{"domain_id": domainID, "source_type": "domains", "name": "cert"},
+	})
+}
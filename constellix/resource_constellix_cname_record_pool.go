@@ -1,28 +1,60 @@
 package constellix
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"log"
 	"strconv"
 
-	"github.com/Constellix/constellix-go-client/client"
-	"github.com/Constellix/constellix-go-client/models"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/terraform-providers/terraform-provider-constellix/client"
 )
 
 func resourceConstellixCnameRecordPool() *schema.Resource {
-	return &schema.Resource{
-		Create: resourceConstellixCnameRecordPoolCreate,
-		Update: resourceConstellixCnameRecordPoolUpdate,
-		Read:   resourceConstellixCnameRecordPoolRead,
-		Delete: resourceConstellixCnameRecordPoolDelete,
+	value := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"value": hostnameSchema(&schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			}),
+
+			"weight": &schema.Schema{
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+
+			"disable_flag": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
+			"check_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+			"policy": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+		},
+	}
+
+	r := &schema.Resource{
+		CreateContext: resourceConstellixCnameRecordPoolCreate,
+		UpdateContext: resourceConstellixCnameRecordPoolUpdate,
+		ReadContext:   resourceConstellixCnameRecordPoolRead,
+		DeleteContext: resourceConstellixCnameRecordPoolDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: resourceConstellixCnameRecordPoolImport,
+			StateContext: resourceConstellixCnameRecordPoolImport,
 		},
 
+		SchemaVersion: 1,
+
 		Schema: map[string]*schema.Schema{
 			"name": &schema.Schema{
 				Type:     schema.TypeString,
@@ -58,7 +90,7 @@ func resourceConstellixCnameRecordPool() *schema.Resource {
 			},
 
 			"disable_flag": &schema.Schema{
-				Type:     schema.TypeString,
+				Type:     schema.TypeBool,
 				Optional: true,
 				Computed: true,
 			},
@@ -66,50 +98,22 @@ func resourceConstellixCnameRecordPool() *schema.Resource {
 			"values": &schema.Schema{
 				Type:     schema.TypeSet,
 				Required: true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"value": &schema.Schema{
-							Type:     schema.TypeString,
-							Required: true,
-						},
-
-						"weight": &schema.Schema{
-							Type:     schema.TypeInt,
-							Required: true,
-						},
-
-						"disable_flag": &schema.Schema{
-							Type:     schema.TypeString,
-							Optional: true,
-							Computed: true,
-						},
-						"check_id": &schema.Schema{
-							Type:     schema.TypeInt,
-							Optional: true,
-							Computed: true,
-						},
-						"policy": &schema.Schema{
-							Type:     schema.TypeString,
-							Required: true,
-						},
-					},
-				},
+				Elem:     value,
+				Set:      hashWithHostnames(value, "value"),
 			},
 		},
 	}
+	r.StateUpgraders = []schema.StateUpgrader{disableFlagUpgrader(r, 0)}
+	return r
 }
 
-func resourceConstellixCnameRecordPoolImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+func resourceConstellixCnameRecordPoolImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
 	log.Printf("[DEBUG] %s: Beginning Import", d.Id())
 	constellixClient := m.(*client.Client)
 	cnamerecordpoolid := d.Id()
 
-	resp, err := constellixClient.GetbyId("v1/pools/CNAME/" + cnamerecordpoolid)
+	resp, err := constellixClient.GetbyIdContext(ctx, "v1/pools/CNAME/"+cnamerecordpoolid)
 	if err != nil {
-		if resp.StatusCode == 404 {
-			d.SetId("")
-			return nil, err
-		}
 		return nil, err
 	}
 	bodybytes, err := ioutil.ReadAll(resp.Body)
@@ -127,7 +131,7 @@ func resourceConstellixCnameRecordPoolImport(d *schema.ResourceData, m interface
 	d.Set("note", data["note"])
 	d.Set("version", data["version"])
 	d.Set("failed_flag", data["failedFlag"])
-	d.Set("disable_flag", data["disableFlag"])
+	d.Set("disable_flag", toBool(data["disableFlag"]))
 	resrr := (data["values"]).([]interface{})
 	mapListRR := make([]interface{}, 0, 1)
 	for _, val := range resrr {
@@ -136,21 +140,22 @@ func resourceConstellixCnameRecordPoolImport(d *schema.ResourceData, m interface
 		inner := val.(map[string]interface{})
 		tpMap["value"] = fmt.Sprintf("%v", inner["value"])
 		tpMap["weight"], _ = strconv.Atoi(fmt.Sprintf("%v", inner["weight"]))
-		tpMap["disable_flag"] = fmt.Sprintf("%v", inner["disableFlag"])
-		tpMap["policy"] = fmt.Sprintf("%v", inner["policy"])
+		tpMap["disable_flag"] = toBool(inner["disableFlag"])
+		tpMap["policy"] = toStringValue(inner["policy"])
 		tpMap["check_id"], _ = strconv.Atoi(fmt.Sprintf("%v", inner["checkId"]))
 
 		mapListRR = append(mapListRR, tpMap)
 	}
+	keepHostnameSpellings(mapListRR, d.Get("values"), "value", nil)
 	d.Set("values", mapListRR)
 	log.Printf("[DEBUG] %s finished import", d.Id())
 	return []*schema.ResourceData{d}, nil
 }
 
-func resourceConstellixCnameRecordPoolCreate(d *schema.ResourceData, m interface{}) error {
+func resourceConstellixCnameRecordPoolCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*client.Client)
 
-	cnamerecordpoolAttr := models.CnameRecordPoolAttributes{}
+	cnamerecordpoolAttr := CnameRecordPoolAttributes{}
 
 	if name, ok := d.GetOk("name"); ok {
 		cnamerecordpoolAttr.Name = name.(string)
@@ -176,47 +181,45 @@ func resourceConstellixCnameRecordPoolCreate(d *schema.ResourceData, m interface
 		cnamerecordpoolAttr.FailedFlag = ff.(string)
 	}
 
-	if df1, ok := d.GetOk("disableflag1"); ok {
-		cnamerecordpoolAttr.DisableFlag1 = df1.(string)
-	}
+	cnamerecordpoolAttr.DisableFlag = d.Get("disable_flag").(bool)
 	if rr, ok := d.GetOk("values"); ok {
 		mapListRR := make([]interface{}, 0, 1)
 		tp := rr.(*schema.Set).List()
 		for _, val := range tp {
 			tpMap := make(map[string]interface{})
 			inner := val.(map[string]interface{})
-			tpMap["value"] = fmt.Sprintf("%v", inner["value"])
+			tpMap["value"] = normalizeHostname(inner["value"])
 			tpMap["weight"], _ = strconv.Atoi(fmt.Sprintf("%v", inner["weight"]))
-			tpMap["disableFlag"] = fmt.Sprintf("%v", inner["disable_flag"])
+			tpMap["disableFlag"] = toBool(inner["disable_flag"])
 			tpMap["checkId"], _ = strconv.Atoi(fmt.Sprintf("%v", inner["check_id"]))
-			tpMap["policy"] = fmt.Sprintf("%v", inner["policy"])
+			tpMap["policy"] = toStringValue(inner["policy"])
 
 			mapListRR = append(mapListRR, tpMap)
 		}
 		cnamerecordpoolAttr.ValuesCname = mapListRR
 	}
 
-	resp, err := client.Save(cnamerecordpoolAttr, "v1/pools/CNAME")
+	resp, err := client.SaveContext(ctx, cnamerecordpoolAttr, "v1/pools/CNAME")
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	//Managing response and extracting id of resource
 	bodybtes, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	bodystring := string(bodybtes)
 	var data map[string]interface{}
 	json.Unmarshal([]byte(bodystring[1:len(bodystring)-1]), &data)
 
 	d.SetId(fmt.Sprintf("%.0f", data["id"]))
-	return resourceConstellixCnameRecordPoolRead(d, m)
+	return resourceConstellixCnameRecordPoolRead(ctx, d, m)
 }
 
-func resourceConstellixCnameRecordPoolUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceConstellixCnameRecordPoolUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*client.Client)
-	cnamerecordpoolAttr := models.CnameRecordPoolAttributes{}
+	cnamerecordpoolAttr := CnameRecordPoolAttributes{}
 
 	cnamerecordpoolAttr.Name = d.Get("name").(string)
 
@@ -228,9 +231,7 @@ func resourceConstellixCnameRecordPoolUpdate(d *schema.ResourceData, m interface
 		cnamerecordpoolAttr.FailedFlag = d.Get("failed_flag").(string)
 	}
 
-	if _, ok := d.GetOk("disableflag1"); ok {
-		cnamerecordpoolAttr.DisableFlag1 = d.Get("disableflag1").(string)
-	}
+	cnamerecordpoolAttr.DisableFlag = d.Get("disable_flag").(bool)
 
 	if _, ok := d.GetOk("note"); ok {
 		cnamerecordpoolAttr.Note = d.Get("note").(string)
@@ -245,11 +246,11 @@ func resourceConstellixCnameRecordPoolUpdate(d *schema.ResourceData, m interface
 		for _, val := range tp {
 			tpMap := make(map[string]interface{})
 			inner := val.(map[string]interface{})
-			tpMap["value"] = fmt.Sprintf("%v", inner["value"])
+			tpMap["value"] = normalizeHostname(inner["value"])
 			tpMap["weight"], _ = strconv.Atoi(fmt.Sprintf("%v", inner["weight"]))
-			tpMap["disableFlag"] = fmt.Sprintf("%v", inner["disable_flag"])
+			tpMap["disableFlag"] = toBool(inner["disable_flag"])
 			tpMap["checkId"], _ = strconv.Atoi(fmt.Sprintf("%v", inner["check_id"]))
-			tpMap["policy"] = fmt.Sprintf("%v", inner["policy"])
+			tpMap["policy"] = toStringValue(inner["policy"])
 
 			mapListRR = append(mapListRR, tpMap)
 		}
@@ -257,28 +258,24 @@ func resourceConstellixCnameRecordPoolUpdate(d *schema.ResourceData, m interface
 	}
 
 	cnamerecordpoolid := d.Id()
-	_, err := client.UpdatebyID(cnamerecordpoolAttr, "v1/pools/CNAME/"+cnamerecordpoolid)
+	_, err := client.UpdatebyIDContext(ctx, cnamerecordpoolAttr, "v1/pools/CNAME/"+cnamerecordpoolid)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	return resourceConstellixCnameRecordPoolRead(d, m)
+	return resourceConstellixCnameRecordPoolRead(ctx, d, m)
 }
 
-func resourceConstellixCnameRecordPoolRead(d *schema.ResourceData, m interface{}) error {
+func resourceConstellixCnameRecordPoolRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*client.Client)
 	cnamerecordpoolid := d.Id()
 
-	resp, err := client.GetbyId("v1/pools/CNAME/" + cnamerecordpoolid)
-	if err != nil {
-		if resp.StatusCode == 404 {
-			d.SetId("")
-			return nil
-		}
-		return err
+	resp, err := readOrMarkGone(ctx, d, client, "v1/pools/CNAME/"+cnamerecordpoolid)
+	if err != nil || d.Id() == "" {
+		return diag.FromErr(err)
 	}
 	bodybytes, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	bodystring := string(bodybytes)
 
@@ -291,7 +288,7 @@ func resourceConstellixCnameRecordPoolRead(d *schema.ResourceData, m interface{}
 	d.Set("note", data["note"])
 	d.Set("version", data["version"])
 	d.Set("failed_flag", data["failedFlag"])
-	d.Set("disable_flag", data["disableFlag"])
+	d.Set("disable_flag", toBool(data["disableFlag"]))
 	resrr := (data["values"]).([]interface{})
 	mapListRR := make([]interface{}, 0, 1)
 	for _, val := range resrr {
@@ -300,24 +297,25 @@ func resourceConstellixCnameRecordPoolRead(d *schema.ResourceData, m interface{}
 		inner := val.(map[string]interface{})
 		tpMap["value"] = fmt.Sprintf("%v", inner["value"])
 		tpMap["weight"], _ = strconv.Atoi(fmt.Sprintf("%v", inner["weight"]))
-		tpMap["disable_flag"] = fmt.Sprintf("%v", inner["disableFlag"])
-		tpMap["policy"] = fmt.Sprintf("%v", inner["policy"])
+		tpMap["disable_flag"] = toBool(inner["disableFlag"])
+		tpMap["policy"] = toStringValue(inner["policy"])
 		tpMap["check_id"], _ = strconv.Atoi(fmt.Sprintf("%v", inner["checkId"]))
 
 		mapListRR = append(mapListRR, tpMap)
 	}
+	keepHostnameSpellings(mapListRR, d.Get("values"), "value", nil)
 
 	d.Set("values", mapListRR)
 	return nil
 }
 
-func resourceConstellixCnameRecordPoolDelete(d *schema.ResourceData, m interface{}) error {
+func resourceConstellixCnameRecordPoolDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*client.Client)
 	dn := d.Id()
 
-	err := client.DeletebyId("v1/pools/CNAME/" + dn)
+	err := client.DeletebyIdContext(ctx, "v1/pools/CNAME/"+dn)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	d.SetId("")
 	return nil
@@ -8,18 +8,18 @@ import (
 	"strconv"
 	"testing"
 
-	"github.com/Constellix/constellix-go-client/client"
 	"github.com/Constellix/constellix-go-client/models"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
-	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/terraform-providers/terraform-provider-constellix/client"
 )
 
 func TestAccCNameRecordPool_Basic(t *testing.T) {
 	var crp models.CnameRecordPoolAttributes
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckConstellixCNameRecordPoolDestroy,
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckConstellixCNameRecordPoolDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCheckConstellixCNameRecordPoolConfig_basic(1),
@@ -36,9 +36,9 @@ func TestAccConstellixCNameRecordPool_Update(t *testing.T) {
 	var crp models.CnameRecordPoolAttributes
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckConstellixCNameRecordPoolDestroy,
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckConstellixCNameRecordPoolDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCheckConstellixCNameRecordPoolConfig_basic(1),
@@ -152,3 +152,25 @@ func testAccCheckConstellixCNameRecordPoolAttributes(numreturn interface{}, crp
 		return nil
 	}
 }
+
+func TestConstellixCnameRecordPoolLifecycle(t *testing.T) {
+	f := newFakeAPI(t)
+
+	pool := func(weight int) map[string]interface{} {
+		return map[string]interface{}{
+			"name":                   "origins",
+			"num_return":             1,
+			"min_available_failover": 1,
+			"values": []interface{}{
+				map[string]interface{}{"value": "origin1.example.net.", "weight": weight, "policy": "followsonar"},
+				map[string]interface{}{"value": "origin2.example.net.", "weight": 20, "policy": "followsonar"},
+			},
+		}
+	}
+	f.lifecycle(lifecycleTestCase{
+		resource:   "constellix_cname_record_pool",
+		create:     pool(10),
+		update:     pool(30),
+		dataSource: map[string]interface{}{"name": "origins"},
+	})
+}
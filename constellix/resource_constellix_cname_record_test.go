@@ -8,18 +8,18 @@ import (
 	"strconv"
 	"testing"
 
-	"github.com/Constellix/constellix-go-client/client"
 	"github.com/Constellix/constellix-go-client/models"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
-	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/terraform-providers/terraform-provider-constellix/client"
 )
 
 func TestAccCName_Basic(t *testing.T) {
 	var cname models.CRecordAttributes
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckConstellixCNameDestroy,
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckConstellixCNameDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCheckConstellixCNameConfig_basic(1800),
@@ -36,9 +36,9 @@ func TestAccConstellixCName_Update(t *testing.T) {
 	var cname models.CRecordAttributes
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckConstellixCNameDestroy,
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckConstellixCNameDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCheckConstellixCNameConfig_basic(1800),
@@ -62,13 +62,13 @@ func testAccCheckConstellixCNameConfig_basic(ttl int) string {
 	return fmt.Sprintf(`
 	resource "constellix_domain" "domain1" {
 		name = "checkcname.com"
-		soa = {
+		soa {
 			email = "com.com."
 			primary_nameserver = "ns41.constellix.com."
 			ttl = 1900
-			refresh = 48100
+			refresh = 43200
 			retry = 7200
-			expire = 1209
+			expire = 1209600
 			negcache = 8000
 		}
 	}
@@ -83,15 +83,15 @@ func testAccCheckConstellixCNameConfig_basic(ttl int) string {
 	    record_failover_values  {
 			     value = "a."
 			     sort_order = 2
-			     disable_flag = "false"
+			     disable_flag = false
 			   }
 			   record_failover_values  {
 				value = "c."
 				sort_order = 3
-				disable_flag = "false"
+				disable_flag = false
 			  }
 			   record_failover_failover_type = 1
-			   record_failover_disable_flag = "false"
+			   record_failover_disable_flag = false
 			 
 			
 	}
@@ -185,3 +185,25 @@ func cnamefromcontainer(resp *http.Response) (*models.CRecordAttributes, error)
 	return &model, nil
 
 }
+
+func TestConstellixCNameRecordLifecycle(t *testing.T) {
+	f := newFakeAPI(t)
+	domainID := f.domain("example.com")
+
+	cname := func(ttl int, host string) map[string]interface{} {
+		return map[string]interface{}{
+			"domain_id":   domainID,
+			"source_type": "domains",
+			"name":        "docs",
+			"ttl":         ttl,
+			"host":        host,
+		}
+	}
+	f.lifecycle(lifecycleTestCase{
+		resource:   "constellix_cname_record",
+		create:     cname(300, "docs.example.net."),
+		update:     cname(600, "docs.example.org."),
+		importID:   recordImportID,
+		dataSource: map[string]interface{}{"domain_id": domainID, "source_type": "domains", "name": "docs"},
+	})
+}
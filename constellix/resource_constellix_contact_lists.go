@@ -1,25 +1,27 @@
 package constellix
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"log"
 
-	"github.com/Constellix/constellix-go-client/client"
 	"github.com/Constellix/constellix-go-client/models"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/terraform-providers/terraform-provider-constellix/client"
 )
 
 func resourceConstellixContactList() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceConstellixContactListCreate,
-		Read:   resourceConstellixContactListRead,
-		Update: resourceConstellixcontactListUpdate,
-		Delete: resourceConstellixContactListDelete,
+		CreateContext: resourceConstellixContactListCreate,
+		ReadContext:   resourceConstellixContactListRead,
+		UpdateContext: resourceConstellixcontactListUpdate,
+		DeleteContext: resourceConstellixContactListDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: resourceConstellixContactListImport,
+			StateContext: resourceConstellixContactListImport,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -37,16 +39,12 @@ func resourceConstellixContactList() *schema.Resource {
 	}
 }
 
-func resourceConstellixContactListImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+func resourceConstellixContactListImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
 	log.Printf("[DEBUG] %s: Beginning Import", d.Id())
 	constellixClient := m.(*client.Client)
 	cid := d.Id()
-	resp, err := constellixClient.GetbyId("v2/contactLists/" + cid)
+	resp, err := constellixClient.GetbyIdContext(ctx, "v2/contactLists/"+cid)
 	if err != nil {
-		if resp.StatusCode == 404 {
-			d.SetId("")
-			return nil, err
-		}
 		return nil, err
 	}
 	bodybytes, err := ioutil.ReadAll(resp.Body)
@@ -64,7 +62,7 @@ func resourceConstellixContactListImport(d *schema.ResourceData, m interface{})
 	return []*schema.ResourceData{d}, nil
 }
 
-func resourceConstellixContactListCreate(d *schema.ResourceData, m interface{}) error {
+func resourceConstellixContactListCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*client.Client)
 	contactlistAttr := models.ContactListAttributes{}
 	if name, ok := d.GetOk("name"); ok {
@@ -80,14 +78,14 @@ func resourceConstellixContactListCreate(d *schema.ResourceData, m interface{})
 		contactlistAttr.EmailAddresses = emailList
 	}
 
-	resp, err := client.Save(contactlistAttr, "v2/contactLists")
+	resp, err := client.SaveContext(ctx, contactlistAttr, "v2/contactLists")
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	bodybytes, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	bodystring := string(bodybytes)
@@ -98,10 +96,10 @@ func resourceConstellixContactListCreate(d *schema.ResourceData, m interface{})
 	var idStruct = idstruct.(map[string]interface{})
 
 	d.SetId(fmt.Sprintf("%.0f", idStruct["id"]))
-	return resourceConstellixContactListRead(d, m)
+	return resourceConstellixContactListRead(ctx, d, m)
 }
 
-func resourceConstellixcontactListUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceConstellixcontactListUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*client.Client)
 	contactlistAttr := models.ContactListAttributes{}
 
@@ -117,28 +115,24 @@ func resourceConstellixcontactListUpdate(d *schema.ResourceData, m interface{})
 	}
 
 	cid := d.Id()
-	_, err := client.UpdatebyID(contactlistAttr, "v2/contactLists/"+cid)
+	_, err := client.UpdatebyIDContext(ctx, contactlistAttr, "v2/contactLists/"+cid)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	return resourceConstellixContactListRead(d, m)
+	return resourceConstellixContactListRead(ctx, d, m)
 }
 
-func resourceConstellixContactListRead(d *schema.ResourceData, m interface{}) error {
+func resourceConstellixContactListRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*client.Client)
 	cid := d.Id()
-	resp, err := client.GetbyId("v2/contactLists/" + cid)
-	if err != nil {
-		if resp.StatusCode == 404 {
-			d.SetId("")
-			return nil
-		}
-		return err
+	resp, err := readOrMarkGone(ctx, d, client, "v2/contactLists/"+cid)
+	if err != nil || d.Id() == "" {
+		return diag.FromErr(err)
 	}
 	bodybytes, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	bodystring := string(bodybytes)
 	var data map[string]interface{}
@@ -150,13 +144,13 @@ func resourceConstellixContactListRead(d *schema.ResourceData, m interface{}) er
 	return nil
 }
 
-func resourceConstellixContactListDelete(d *schema.ResourceData, m interface{}) error {
+func resourceConstellixContactListDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*client.Client)
 	cid := d.Id()
 
-	err := client.DeletebyId("v2/contactLists/" + cid)
+	err := client.DeletebyIdContext(ctx, "v2/contactLists/"+cid)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	d.SetId("")
 
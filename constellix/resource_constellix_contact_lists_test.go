@@ -7,18 +7,18 @@ import (
 	"net/http"
 	"testing"
 
-	"github.com/Constellix/constellix-go-client/client"
 	"github.com/Constellix/constellix-go-client/models"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
-	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/terraform-providers/terraform-provider-constellix/client"
 )
 
 func TestAccContactList_Basic(t *testing.T) {
 	var ctl models.ContactListAttributes
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckConstellixContactListDestroy,
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckConstellixContactListDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCheckConstellixContactListConfig_basic("abc@yahoo.com"),
@@ -35,9 +35,9 @@ func TestAccConstellixContactList_Update(t *testing.T) {
 	var ctl models.ContactListAttributes
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckConstellixContactListDestroy,
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckConstellixContactListDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCheckConstellixContactListConfig_basic("abc@yahoo.com"),
@@ -140,3 +140,20 @@ func contactlistfromcontainer(resp *http.Response) (*models.ContactListAttribute
 	return &ctl, nil
 
 }
+
+func TestConstellixContactListsLifecycle(t *testing.T) {
+	f := newFakeAPI(t)
+
+	contacts := func(emails ...interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"name":            "oncall",
+			"email_addresses": emails,
+		}
+	}
+	f.lifecycle(lifecycleTestCase{
+		resource:   "constellix_contact_lists",
+		create:     contacts("ops@example.com"),
+		update:     contacts("ops@example.com", "dev@example.com"),
+		dataSource: map[string]interface{}{"name": "oncall"},
+	})
+}
@@ -1,27 +1,29 @@
 package constellix
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"log"
 	"strings"
 
-	"github.com/Constellix/constellix-go-client/client"
 	"github.com/Constellix/constellix-go-client/models"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/terraform-providers/terraform-provider-constellix/client"
 )
 
 func resourceConstellixDNSCheck() *schema.Resource {
 	return &schema.Resource{
-		Create:        resourceConstellixDNSCheckCreate,
-		Read:          resourceConstellixDNSCheckRead,
-		Update:        resourceConstellixDNSCheckUpdate,
-		Delete:        resourceConstellixDNSCheckDelete,
+		CreateContext: resourceConstellixDNSCheckCreate,
+		ReadContext:   resourceConstellixDNSCheckRead,
+		UpdateContext: resourceConstellixDNSCheckUpdate,
+		DeleteContext: resourceConstellixDNSCheckDelete,
 		SchemaVersion: 1,
 		Importer: &schema.ResourceImporter{
-			State: resourceConstellixDNSCheckImport,
+			StateContext: resourceConstellixDNSCheckImport,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -107,16 +109,15 @@ func resourceConstellixDNSCheck() *schema.Resource {
 	}
 }
 
-func resourceConstellixDNSCheckImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+func resourceConstellixDNSCheckImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
 	log.Printf("[DEBUG] %s: Beginning Import", d.Id())
 	constellixClient := m.(*client.Client)
 	dnsid := d.Id()
-	resp, err := constellixClient.GetbyId("https://api.sonar.constellix.com/rest/api/dns/" + dnsid)
-	defer resp.Body.Close()
-
+	resp, err := constellixClient.GetbyIdContext(ctx, constellixClient.SonarEndpoint("rest/api/dns/")+dnsid)
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Body.Close()
 	bodybytes, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
 		return nil, err
@@ -141,7 +142,7 @@ func resourceConstellixDNSCheckImport(d *schema.ResourceData, m interface{}) ([]
 	return []*schema.ResourceData{d}, nil
 }
 
-func resourceConstellixDNSCheckCreate(d *schema.ResourceData, m interface{}) error {
+func resourceConstellixDNSCheckCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 
 	constellixConnect := m.(*client.Client)
 
@@ -187,10 +188,10 @@ func resourceConstellixDNSCheckCreate(d *schema.ResourceData, m interface{}) err
 		dnsAttr.ExpectedResponse = expected_response.(string)
 	}
 
-	resp, err := constellixConnect.Save(dnsAttr, "https://api.sonar.constellix.com/rest/api/dns")
+	resp, err := constellixConnect.SaveContext(ctx, dnsAttr, constellixConnect.SonarEndpoint("rest/api/dns"))
 	defer resp.Body.Close()
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	var location string
@@ -202,27 +203,26 @@ func resourceConstellixDNSCheckCreate(d *schema.ResourceData, m interface{}) err
 		}
 	}
 	if flag == false {
-		return fmt.Errorf("response contains empty location value")
+		return diag.Errorf("response contains empty location value")
 	}
 
 	locArr := strings.Split(location, "/")
 	d.SetId(locArr[len(locArr)-1])
 
-	return resourceConstellixDNSCheckRead(d, m)
+	return resourceConstellixDNSCheckRead(ctx, d, m)
 }
 
-func resourceConstellixDNSCheckRead(d *schema.ResourceData, m interface{}) error {
+func resourceConstellixDNSCheckRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	constellixClient := m.(*client.Client)
 	dnsid := d.Id()
-	resp, err := constellixClient.GetbyId("https://api.sonar.constellix.com/rest/api/dns/" + dnsid)
-	defer resp.Body.Close()
-
-	if err != nil {
-		return err
+	resp, err := readOrMarkGone(ctx, d, constellixClient, constellixClient.SonarEndpoint("rest/api/dns/")+dnsid)
+	if err != nil || d.Id() == "" {
+		return diag.FromErr(err)
 	}
+	defer resp.Body.Close()
 	bodybytes, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	bodystring := string(bodybytes)
 
@@ -243,7 +243,7 @@ func resourceConstellixDNSCheckRead(d *schema.ResourceData, m interface{}) error
 	return nil
 }
 
-func resourceConstellixDNSCheckUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceConstellixDNSCheckUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*client.Client)
 
 	dnsAttr := models.DNSAttributes{}
@@ -281,21 +281,21 @@ func resourceConstellixDNSCheckUpdate(d *schema.ResourceData, m interface{}) err
 	}
 
 	dn := d.Id()
-	resp, err := client.UpdatebyID(dnsAttr, "https://api.sonar.constellix.com/rest/api/dns/"+dn)
+	resp, err := client.UpdatebyIDContext(ctx, dnsAttr, client.SonarEndpoint("rest/api/dns/")+dn)
 	defer resp.Body.Close()
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	return resourceConstellixDNSCheckRead(d, m)
+	return resourceConstellixDNSCheckRead(ctx, d, m)
 }
 
-func resourceConstellixDNSCheckDelete(d *schema.ResourceData, m interface{}) error {
+func resourceConstellixDNSCheckDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	constellixConnect := m.(*client.Client)
 	dnsid := d.Id()
 
-	err := constellixConnect.DeletebyId("https://api.sonar.constellix.com/rest/api/dns/" + dnsid)
+	err := constellixConnect.DeletebyIdContext(ctx, constellixConnect.SonarEndpoint("rest/api/dns/")+dnsid)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	d.SetId("")
 	return nil
@@ -0,0 +1,23 @@
+package constellix
+
+import "testing"
+
+func TestConstellixDNSCheckLifecycle(t *testing.T) {
+	f := newFakeAPI(t)
+
+	check := func(expected string) map[string]interface{} {
+		return map[string]interface{}{
+			"name":              "apex",
+			"fqdn":              "example.com",
+			"resolver":          "192.0.2.53",
+			"check_sites":       []interface{}{1, 2},
+			"expected_response": expected,
+		}
+	}
+	f.lifecycle(lifecycleTestCase{
+		resource:   "constellix_dns_check",
+		create:     check("192.0.2.1"),
+		update:     check("192.0.2.2"),
+		dataSource: map[string]interface{}{"name": "apex"},
+	})
+}
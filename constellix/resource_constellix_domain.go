@@ -1,6 +1,7 @@
 package constellix
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -9,24 +10,26 @@ import (
 	"strconv"
 	"strings"
 
-	"github.com/Constellix/constellix-go-client/client"
-	"github.com/Constellix/constellix-go-client/models"
 	"github.com/Jeffail/gabs"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/terraform-providers/terraform-provider-constellix/client"
 )
 
 func resourceConstellixDomain() *schema.Resource {
-	return &schema.Resource{
-		Create: resourceConstellixDNSCreate,
-		Update: resourceConstellixDNSUpdate,
-		Read:   resourceConstellixDNSRead,
-		Delete: resourceConstellixDNSDelete,
+	r := &schema.Resource{
+		CreateContext: resourceConstellixDNSCreate,
+		UpdateContext: resourceConstellixDNSUpdate,
+		ReadContext:   resourceConstellixDNSRead,
+		DeleteContext: resourceConstellixDNSDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: resourceConstellixDNSImport,
+			StateContext: resourceConstellixDNSImport,
 		},
 
-		SchemaVersion: 1,
+		CustomizeDiff: validateSOA,
+
+		SchemaVersion: 2,
 
 		Schema: map[string]*schema.Schema{
 			"name": &schema.Schema{
@@ -83,74 +86,19 @@ func resourceConstellixDomain() *schema.Resource {
 				Elem:     &schema.Schema{Type: schema.TypeString},
 			},
 
-			"soa": &schema.Schema{
-				Type:     schema.TypeMap,
-				Optional: true,
-				Computed: true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"primary_nameserver": &schema.Schema{
-							Type:     schema.TypeString,
-							Optional: true,
-							Computed: true,
-						},
-
-						"email": &schema.Schema{
-							Type:     schema.TypeString,
-							Required: true,
-						},
-
-						"ttl": &schema.Schema{
-							Type:     schema.TypeString,
-							Optional: true,
-							Computed: true,
-						},
-
-						"refresh": &schema.Schema{
-							Type:     schema.TypeString,
-							Optional: true,
-							Computed: true,
-						},
-
-						"serial": &schema.Schema{
-							Type:     schema.TypeString,
-							Optional: true,
-							Computed: true,
-						},
-
-						"retry": &schema.Schema{
-							Type:     schema.TypeString,
-							Optional: true,
-							Computed: true,
-						},
-
-						"expire": &schema.Schema{
-							Type:     schema.TypeString,
-							Optional: true,
-							Computed: true,
-						},
-
-						"negcache": &schema.Schema{
-							Type:     schema.TypeString,
-							Optional: true,
-							Computed: true,
-						},
-					},
-				},
-			},
+			"soa": soaSchema(),
 		},
 	}
+	// Version 0 states declared soa as a map of strings as well.
+	r.StateUpgraders = []schema.StateUpgrader{soaUpgrader(r, 0), soaUpgrader(r, 1)}
+	return r
 }
 
-func resourceConstellixDNSImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+func resourceConstellixDNSImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
 	log.Printf("[DEBUG] %s: Beginning Import", d.Id())
 	constellixClient := m.(*client.Client)
-	resp, err := constellixClient.GetbyId("v1/domains/" + d.Id())
+	resp, err := constellixClient.GetbyIdContext(ctx, "v1/domains/"+d.Id())
 	if err != nil {
-		if resp != nil && resp.StatusCode == 404 {
-			d.SetId("")
-			return nil, err
-		}
 		return nil, err
 	}
 	bodyBytes, err := ioutil.ReadAll(resp.Body)
@@ -163,20 +111,9 @@ func resourceConstellixDNSImport(d *schema.ResourceData, m interface{}) ([]*sche
 		return nil, err
 	}
 
-	soaset := make(map[string]interface{})
-	if obj.Exists("soa") {
-		soaset["ttl"] = stripQuotes(obj.S("soa", "ttl").String())
-		soaset["primary_nameserver"] = stripQuotes(obj.S("soa", "primaryNameserver").String())
-		soaset["email"] = stripQuotes(obj.S("soa", "email").String())
-		soaset["refresh"] = stripQuotes(obj.S("soa", "refresh").String())
-		soaset["expire"] = stripQuotes(obj.S("soa", "expire").String())
-		soaset["retry"] = stripQuotes(obj.S("soa", "retry").String())
-		soaset["negcache"] = stripQuotes(obj.S("soa", "negCache").String())
-	}
-
 	d.Set("id", stripQuotes(obj.S("id").String()))
 	d.Set("name", stripQuotes(obj.S("name").String()))
-	d.Set("soa", soaset)
+	d.Set("soa", flattenSOA(obj.S("soa").Data(), ""))
 
 	if disabled, err := strconv.ParseBool(stripQuotes(obj.S("disabled").String())); err == nil {
 		d.Set("disabled", disabled)
@@ -213,7 +150,7 @@ func resourceConstellixDNSImport(d *schema.ResourceData, m interface{}) ([]*sche
 	return []*schema.ResourceData{d}, nil
 }
 
-func resourceConstellixDNSCreate(d *schema.ResourceData, m interface{}) error {
+func resourceConstellixDNSCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 
 	constellixConnect := m.(*client.Client)
 
@@ -257,56 +194,25 @@ func resourceConstellixDNSCreate(d *schema.ResourceData, m interface{}) error {
 		domainAttr.Tags = tagsList
 	}
 
-	var soaAttr *models.Soa
-	if value, ok := d.GetOk("soa"); ok {
-		soaAttr = &models.Soa{}
-		tp := value.(map[string]interface{})
-		if tp["primary_nameserver"] != nil {
-			soaAttr.PrimaryNameServer = tp["primary_nameserver"].(string)
-		}
-		if tp["email"] != nil {
-			soaAttr.Email = tp["email"].(string)
-		}
-		if tp["ttl"] != nil {
-			soaAttr.TTL = tp["ttl"].(string)
-		}
-		if tp["expire"] != nil {
-			soaAttr.Expire = tp["expire"].(string)
-		}
-		if tp["negcache"] != nil {
-			soaAttr.NegCache = tp["negcache"].(string)
-		}
-		if tp["refresh"] != nil {
-			soaAttr.Refresh = tp["refresh"].(string)
-		}
-		if tp["retry"] != nil {
-			soaAttr.Retry = tp["retry"].(string)
-		}
-	}
-
-	domainAttr.Soa = soaAttr
+	domainAttr.Soa = expandSOA(d.Get("soa").([]interface{}))
 
 	jsonLogMsg := fmt.Sprintf(`{"step":"creating-new-domain", "name":"%s"}`, domainAttr.Name)
 	log.Println(jsonLogMsg)
 
 	var domainID string
-	resp, err := constellixConnect.Save(domainAttr, "v1/domains")
+	resp, err := constellixConnect.SaveContext(ctx, domainAttr, "v1/domains")
 	if err != nil {
-		if resp != nil && resp.StatusCode == 400 {
-			parts := strings.Split(err.Error(), "already exists, Domain Id:")
-			if len(parts) == 2 {
-				domainID = strings.TrimSpace(parts[1])
-				jsonLogMsg = fmt.Sprintf(
-					`{"step":"creating-new-domain:already-exists", "name":"%s", "id": "%s"}`,
-					domainAttr.Name, domainID,
-				)
-				log.Println(jsonLogMsg)
-			}
+		if domainID = existingDomainID(err); domainID != "" {
+			jsonLogMsg = fmt.Sprintf(
+				`{"step":"creating-new-domain:already-exists", "name":"%s", "id": "%s"}`,
+				domainAttr.Name, domainID,
+			)
+			log.Println(jsonLogMsg)
 		}
 	} else {
 		domainID, err = extractDomainIDFromDomainCreationResponse(resp.Body)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 
 		jsonLogMsg = fmt.Sprintf(`{"step":"created-new-domain", "name":"%s", "id": "%s"}`,
@@ -319,17 +225,33 @@ func resourceConstellixDNSCreate(d *schema.ResourceData, m interface{}) error {
 				domainAttr.Name, domainID, disabled,
 			)
 			log.Println(jsonLogMsg)
-			err = setDisableAttribute(constellixConnect, domainID, disabled)
+			err = setDisableAttribute(ctx, constellixConnect, domainID, disabled)
 			if err != nil {
-				return err
+				return diag.FromErr(err)
 			}
 		}
 	}
 	if domainID != "" {
 		d.SetId(domainID)
-		return resourceConstellixDNSRead(d, m)
+		return resourceConstellixDNSRead(ctx, d, m)
+	}
+	return diag.FromErr(err)
+}
+
+// existingDomainID returns the ID of the domain the API refused to create
+// because it already exists, or "" for any other error.
+func existingDomainID(err error) string {
+	if !client.IsBadRequest(err) {
+		return ""
+	}
+	apiErr, _ := client.AsAPIError(err)
+	for _, msg := range apiErr.Messages {
+		parts := strings.Split(msg, "already exists, Domain Id:")
+		if len(parts) == 2 {
+			return strings.TrimSpace(parts[1])
+		}
 	}
-	return err
+	return ""
 }
 
 func extractDomainIDFromDomainCreationResponse(respBody io.ReadCloser) (string, error) {
@@ -346,41 +268,26 @@ func extractDomainIDFromDomainCreationResponse(respBody io.ReadCloser) (string,
 	return fmt.Sprintf("%.0f", data["id"]), err
 }
 
-func resourceConstellixDNSRead(d *schema.ResourceData, m interface{}) error {
+func resourceConstellixDNSRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	constellixclient := m.(*client.Client)
 	dn := d.Id()
-	resp, err := constellixclient.GetbyId("v1/domains/" + dn)
-	if err != nil {
-		if resp != nil && resp.StatusCode == 404 {
-			d.SetId("")
-			return nil
-		}
-		return err
+	resp, err := readOrMarkGone(ctx, d, constellixclient, "v1/domains/"+dn)
+	if err != nil || d.Id() == "" {
+		return diag.FromErr(err)
 	}
 	bodyBytes, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	obj, err := gabs.ParseJSON(bodyBytes)
 	if err != nil {
-		return err
-	}
-
-	soaset := make(map[string]interface{})
-	if obj.Exists("soa") {
-		soaset["primary_nameserver"] = stripQuotes(obj.S("soa", "primaryNameserver").String())
-		soaset["email"] = stripQuotes(obj.S("soa", "email").String())
-		soaset["ttl"] = stripQuotes(obj.S("soa", "ttl").String())
-		soaset["refresh"] = stripQuotes(obj.S("soa", "refresh").String())
-		soaset["expire"] = stripQuotes(obj.S("soa", "expire").String())
-		soaset["retry"] = stripQuotes(obj.S("soa", "retry").String())
-		soaset["negcache"] = stripQuotes(obj.S("soa", "negCache").String())
+		return diag.FromErr(err)
 	}
 
 	d.Set("id", stripQuotes(obj.S("id").String()))
 	d.Set("name", stripQuotes(obj.S("name").String()))
-	d.Set("soa", soaset)
+	d.Set("soa", flattenSOA(obj.S("soa").Data(), d.Get("soa.0.email").(string)))
 
 	if disabled, err := strconv.ParseBool(stripQuotes(obj.S("disabled").String())); err == nil {
 		d.Set("disabled", disabled)
@@ -415,7 +322,7 @@ func resourceConstellixDNSRead(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceConstellixDNSUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceConstellixDNSUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	constellixClient := m.(*client.Client)
 	dn := d.Id()
 
@@ -458,41 +365,14 @@ func resourceConstellixDNSUpdate(d *schema.ResourceData, m interface{}) error {
 		}
 	}
 
-	var soaAttr *models.Soa
 	if d.HasChange("soa") {
-		if value, ok := d.GetOk("soa"); ok {
-			soaAttr = &models.Soa{}
-			tp := value.(map[string]interface{})
-			if tp["primary_nameserver"] != nil {
-				soaAttr.PrimaryNameServer = fmt.Sprintf("%v", tp["primary_nameserver"])
-			}
-			if tp["email"] != nil {
-				soaAttr.Email = fmt.Sprintf("%v", tp["email"])
-			}
-			if tp["ttl"] != nil {
-				soaAttr.TTL = tp["ttl"].(string)
-			}
-			if tp["expire"] != nil {
-				soaAttr.Expire = tp["expire"].(string)
-			}
-			if tp["negcache"] != nil {
-				soaAttr.NegCache = tp["negcache"].(string)
-			}
-			if tp["refresh"] != nil {
-				soaAttr.Refresh = tp["refresh"].(string)
-			}
-			if tp["retry"] != nil {
-				soaAttr.Retry = tp["retry"].(string)
-			}
-		}
-
-		domainAttr.Soa = soaAttr
+		domainAttr.Soa = expandSOA(d.Get("soa").([]interface{}))
 	}
 	jsonLogMsg := fmt.Sprintf(`{"step":"updating-domain", "id": "%s"}`, dn)
 	log.Println(jsonLogMsg)
-	_, err := constellixClient.UpdatebyID(domainAttr, "v1/domains/"+dn)
+	_, err := constellixClient.UpdatebyIDContext(ctx, domainAttr, "v1/domains/"+dn)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if d.HasChange("disabled") {
 		if disabled, ok := toBoolValue(d, "disabled"); ok {
@@ -501,13 +381,13 @@ func resourceConstellixDNSUpdate(d *schema.ResourceData, m interface{}) error {
 				dn, disabled,
 			)
 			log.Println(jsonLogMsg)
-			err = setDisableAttribute(constellixClient, dn, disabled)
+			err = setDisableAttribute(ctx, constellixClient, dn, disabled)
 			if err != nil {
-				return err
+				return diag.FromErr(err)
 			}
 		}
 	}
-	return resourceConstellixDNSRead(d, m)
+	return resourceConstellixDNSRead(ctx, d, m)
 }
 
 func toBoolValue(d *schema.ResourceData, key string) (bool, bool) {
@@ -519,26 +399,26 @@ func toBoolValue(d *schema.ResourceData, key string) (bool, bool) {
 	return false, true
 }
 
-func setDisableAttribute(constellixClient *client.Client, domainID string, disabled bool) error {
+func setDisableAttribute(ctx context.Context, constellixClient *client.Client, domainID string, disabled bool) error {
 	disableDomainAttr := DomainAttributesV4{
 		Enabled: !disabled,
 	}
-	_, err := constellixClient.UpdatebyID(disableDomainAttr, "v4/domains/"+domainID)
+	_, err := constellixClient.UpdatebyIDContext(ctx, disableDomainAttr, "v4/domains/"+domainID)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func resourceConstellixDNSDelete(d *schema.ResourceData, m interface{}) error {
+func resourceConstellixDNSDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	constellixConnect := m.(*client.Client)
 
 	dn := d.Id()
 
-	err := constellixConnect.DeletebyId("v1/domains/" + dn)
+	err := constellixConnect.DeletebyIdContext(ctx, "v1/domains/"+dn)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	d.SetId("")
-	return err
+	return diag.FromErr(err)
 }
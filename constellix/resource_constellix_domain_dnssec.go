@@ -0,0 +1,263 @@
+package constellix
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/terraform-providers/terraform-provider-constellix/client"
+)
+
+// dnssecStatus is the answer of the API to v4/domains/{id}/dnssec.
+type dnssecStatus struct {
+	Data struct {
+		Enabled bool `json:"enabled"`
+		Keys    []struct {
+			KeyTag    int    `json:"keyTag"`
+			Flags     int    `json:"flags"`
+			Protocol  int    `json:"protocol"`
+			Algorithm int    `json:"algorithm"`
+			PublicKey string `json:"publicKey"`
+			DS        []struct {
+				DigestType int    `json:"digestType"`
+				Digest     string `json:"digest"`
+			} `json:"ds"`
+		} `json:"keys"`
+	} `json:"data"`
+}
+
+// keySigningKeyFlags are the DNSKEY flags of a key signing key (RFC 4034),
+// the keys the parent zone holds DS records of.
+const keySigningKeyFlags = 257
+
+func resourceConstellixDomainDNSSEC() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceConstellixDomainDNSSECCreate,
+		ReadContext:   resourceConstellixDomainDNSSECRead,
+		UpdateContext: resourceConstellixDomainDNSSECUpdate,
+		DeleteContext: resourceConstellixDomainDNSSECDelete,
+		CustomizeDiff: resourceConstellixDomainDNSSECCustomizeDiff,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"domain_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"allow_disable": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			// Changing key_rollover replaces the key signing key.
+			"key_rollover": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"ds_records": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key_tag": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"algorithm": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"digest_type": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"digest": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+
+			"dnskey_records": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key_tag": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"flags": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"protocol": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"algorithm": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"public_key": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceConstellixDomainDNSSECCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	constellixClient := m.(*client.Client)
+	domainID := d.Get("domain_id").(string)
+
+	log.Printf("[DEBUG] enabling DNSSEC of domain %s", domainID)
+	if _, err := constellixClient.UpdatebyIDContext(ctx, DomainDNSSECAttributesV4{DNSSEC: true}, "v4/domains/"+domainID); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(domainID)
+	return resourceConstellixDomainDNSSECRead(ctx, d, m)
+}
+
+func resourceConstellixDomainDNSSECUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	constellixClient := m.(*client.Client)
+
+	if keyRollover(d.GetChange("key_rollover")) {
+		log.Printf("[DEBUG] rolling the key signing key of domain %s over", d.Id())
+		if _, err := constellixClient.SaveContext(ctx, struct{}{}, "v4/domains/"+d.Id()+"/dnssec/rollover"); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	return resourceConstellixDomainDNSSECRead(ctx, d, m)
+}
+
+// resourceConstellixDomainDNSSECCustomizeDiff refuses at plan time to
+// replace the resource, which disables the DNSSEC of the previous domain,
+// unless allow_disable was applied, and plans the new records of a key
+// rollover. Destroy plans skip it, so the delete checks allow_disable again.
+func resourceConstellixDomainDNSSECCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+	if d.HasChange("domain_id") {
+		if allowDisable, _ := d.GetChange("allow_disable"); !allowDisable.(bool) {
+			return disableRefused(d.Id())
+		}
+	}
+	if keyRollover(d.GetChange("key_rollover")) {
+		for _, name := range []string{"ds_records", "dnskey_records"} {
+			if err := d.SetNewComputed(name); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// keyRollover reports whether key_rollover changing from old to new asks for
+// a key rollover. Setting or removing it does not, so that adding it to the
+// configuration of a signed domain, imported for example, leaves its keys
+// alone.
+func keyRollover(old, new interface{}) bool {
+	return old.(string) != "" && new.(string) != "" && old != new
+}
+
+// disableRefused explains why the DNSSEC of a domain is not disabled.
+func disableRefused(domainID string) error {
+	return fmt.Errorf("DNSSEC of domain %s is not disabled because allow_disable is false; "+
+		"remove its DS records from the parent zone, then set allow_disable = true and apply before destroying or replacing the resource", domainID)
+}
+
+func resourceConstellixDomainDNSSECRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	constellixClient := m.(*client.Client)
+	resp, err := readOrMarkGone(ctx, d, constellixClient, "v4/domains/"+d.Id()+"/dnssec")
+	if err != nil || d.Id() == "" {
+		return diag.FromErr(err)
+	}
+	defer resp.Body.Close()
+	bodyBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	var status dnssecStatus
+	if err := json.Unmarshal(bodyBytes, &status); err != nil {
+		return diag.Errorf("unexpected DNSSEC status of domain %s: %s", d.Id(), bodyBytes)
+	}
+
+	// A domain signed no more, from outside Terraform, is planned to be
+	// signed again.
+	if !status.Data.Enabled {
+		log.Printf("[WARN] DNSSEC of domain %s is disabled, removing it from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	dsRecords := make([]interface{}, 0, len(status.Data.Keys))
+	dnskeyRecords := make([]interface{}, 0, len(status.Data.Keys))
+	for _, key := range status.Data.Keys {
+		if key.Flags != keySigningKeyFlags {
+			continue
+		}
+		dnskeyRecords = append(dnskeyRecords, map[string]interface{}{
+			"key_tag":    key.KeyTag,
+			"flags":      key.Flags,
+			"protocol":   key.Protocol,
+			"algorithm":  key.Algorithm,
+			"public_key": key.PublicKey,
+		})
+		for _, ds := range key.DS {
+			dsRecords = append(dsRecords, map[string]interface{}{
+				"key_tag":     key.KeyTag,
+				"algorithm":   key.Algorithm,
+				"digest_type": ds.DigestType,
+				"digest":      ds.Digest,
+			})
+		}
+	}
+
+	values := map[string]interface{}{
+		"domain_id":      d.Id(),
+		"ds_records":     dsRecords,
+		"dnskey_records": dnskeyRecords,
+	}
+	for name, value := range values {
+		if err := d.Set(name, value); err != nil {
+			return diag.Errorf("setting %s: %s", name, err)
+		}
+	}
+	return nil
+}
+
+func resourceConstellixDomainDNSSECDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	constellixClient := m.(*client.Client)
+	domainID := d.Id()
+
+	// Unsigning a zone whose parent still holds its DS records makes it
+	// unresolvable for validating resolvers, so it takes an explicit opt-in.
+	if !d.Get("allow_disable").(bool) {
+		return diag.FromErr(disableRefused(domainID))
+	}
+
+	log.Printf("[DEBUG] disabling DNSSEC of domain %s", domainID)
+	_, err := constellixClient.UpdatebyIDContext(ctx, DomainDNSSECAttributesV4{DNSSEC: false}, "v4/domains/"+domainID)
+	if err != nil && !isNotFound(err) {
+		return diag.FromErr(err)
+	}
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,133 @@
+package constellix
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestConstellixDomainDNSSECLifecycle(t *testing.T) {
+	f := newFakeAPI(t)
+	domainID := f.domain("example.com")
+
+	f.lifecycle(lifecycleTestCase{
+		resource: "constellix_domain_dnssec",
+		create:   map[string]interface{}{"domain_id": domainID},
+		update:   map[string]interface{}{"domain_id": domainID, "allow_disable": true, "key_rollover": "2026-01"},
+		// The guard and the rollover are not part of the API's state.
+		ignore: []string{"allow_disable", "key_rollover"},
+	})
+
+	if dnssec, _ := f.server.Object("v1/domains/" + domainID); dnssec["dnssec"] != false {
+		t.Errorf("expected DNSSEC to be disabled after destroy, got %v", dnssec["dnssec"])
+	}
+}
+
+func TestConstellixDomainDNSSECRecords(t *testing.T) {
+	f := newFakeAPI(t)
+	domainID := f.domain("example.com")
+	r := f.provider.ResourcesMap["constellix_domain_dnssec"]
+
+	state := f.apply(r, nil, map[string]interface{}{"domain_id": domainID})
+	if state.ID != domainID {
+		t.Fatalf("expected the ID of the domain, got %q", state.ID)
+	}
+	for attr, expected := range map[string]string{
+		"ds_records.#":              "1",
+		"ds_records.0.key_tag":      domainID,
+		"ds_records.0.algorithm":    "13",
+		"ds_records.0.digest_type":  "2",
+		"dnskey_records.#":          "1",
+		"dnskey_records.0.flags":    "257",
+		"dnskey_records.0.protocol": "3",
+	} {
+		if actual := state.Attributes[attr]; actual != expected {
+			t.Errorf("expected %s = %q, got %q", attr, expected, actual)
+		}
+	}
+	if digest := state.Attributes["ds_records.0.digest"]; len(digest) != 64 {
+		t.Errorf("expected a SHA-256 digest, got %q", digest)
+	}
+
+	// Disabling DNSSEC outside Terraform plans to enable it again.
+	if _, err := f.client.UpdatebyID(DomainDNSSECAttributesV4{DNSSEC: false}, "v4/domains/"+domainID); err != nil {
+		t.Fatalf("disabling DNSSEC: %s", err)
+	}
+	if refreshed := f.refresh(r, state); refreshed != nil {
+		t.Errorf("expected the resource to be gone once DNSSEC is disabled, got %v", refreshed)
+	}
+}
+
+func TestConstellixDomainDNSSECDisableGuard(t *testing.T) {
+	f := newFakeAPI(t)
+	domainID := f.domain("example.com")
+	r := f.provider.ResourcesMap["constellix_domain_dnssec"]
+	state := f.apply(r, nil, map[string]interface{}{"domain_id": domainID})
+
+	_, diags := r.Apply(context.Background(), state, &terraform.InstanceDiff{Destroy: true}, f.client)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "allow_disable is false") {
+		t.Fatalf("expected destroy to be refused, got %v", diags)
+	}
+	if domain, _ := f.server.Object("v1/domains/" + domainID); domain["dnssec"] != true {
+		t.Errorf("expected the domain to stay signed, got dnssec %v", domain["dnssec"])
+	}
+
+	// Moving the resource to another domain destroys it too, which the plan
+	// refuses already.
+	otherID := f.domain("example.net")
+	if _, err := f.plan(r, state, map[string]interface{}{"domain_id": otherID}); err == nil || !strings.Contains(err.Error(), "allow_disable is false") {
+		t.Errorf("expected the plan to refuse the replacement, got %v", err)
+	}
+	if _, err := f.plan(r, state, map[string]interface{}{"domain_id": otherID, "allow_disable": true}); err == nil {
+		t.Errorf("expected the plan to refuse the replacement until allow_disable is applied")
+	}
+
+	state = f.apply(r, state, map[string]interface{}{"domain_id": domainID, "allow_disable": true})
+	diff, err := f.plan(r, state, map[string]interface{}{"domain_id": otherID, "allow_disable": true})
+	if err != nil || !diff.RequiresNew() {
+		t.Fatalf("expected a change of domain to replace the resource once allowed, got %v, %v", diff, err)
+	}
+}
+
+func TestConstellixDomainDNSSECKeyRollover(t *testing.T) {
+	f := newFakeAPI(t)
+	domainID := f.domain("example.com")
+	r := f.provider.ResourcesMap["constellix_domain_dnssec"]
+	rollover := "/dnssec/rollover"
+
+	// Setting key_rollover the first time, after an import for example,
+	// keeps the keys.
+	state := f.apply(r, nil, map[string]interface{}{"domain_id": domainID})
+	digest := state.Attributes["ds_records.0.digest"]
+	state = f.apply(r, state, map[string]interface{}{"domain_id": domainID, "key_rollover": "2026-01"})
+	if actual := state.Attributes["ds_records.0.digest"]; actual != digest {
+		t.Errorf("expected the keys to be kept, got digest %q instead of %q", actual, digest)
+	}
+
+	// Changing it plans new records and rolls the key over.
+	config := map[string]interface{}{"domain_id": domainID, "key_rollover": "2026-07"}
+	diff, err := f.plan(r, state, config)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if attr := diff.Attributes["ds_records.#"]; attr == nil || !attr.NewComputed {
+		t.Errorf("expected the DS records to be planned as computed, got %v", attr)
+	}
+	state = f.apply(r, state, config)
+	if actual := state.Attributes["ds_records.0.digest"]; actual == digest || len(actual) != 64 {
+		t.Errorf("expected the digest of a new key, got %q", actual)
+	}
+	f.checkNoChanges(r, state, config)
+
+	rollovers := 0
+	for _, request := range f.server.Requests() {
+		if strings.HasSuffix(request, rollover) {
+			rollovers++
+		}
+	}
+	if rollovers != 1 {
+		t.Errorf("expected one key rollover, got %d", rollovers)
+	}
+}
@@ -0,0 +1,199 @@
+package constellix
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/terraform-providers/terraform-provider-constellix/client"
+)
+
+func resourceConstellixDomainSnapshot() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceConstellixDomainSnapshotCreate,
+		ReadContext:   resourceConstellixDomainSnapshotRead,
+		UpdateContext: resourceConstellixDomainSnapshotUpdate,
+		DeleteContext: resourceConstellixDomainSnapshotDelete,
+		CustomizeDiff: resourceConstellixDomainSnapshotCustomizeDiff,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"domain_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"version": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+
+			"restore": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"timestamp": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"record_count": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// parseSnapshotID splits the domain_id:version ID of a snapshot.
+func parseSnapshotID(id string) (string, int, error) {
+	parts := strings.Split(id, ":")
+	if len(parts) == 2 {
+		if version, err := strconv.Atoi(parts[1]); err == nil && parts[0] != "" {
+			return parts[0], version, nil
+		}
+	}
+	return "", 0, fmt.Errorf("unexpected format of ID (%s), expected domain_id:version", id)
+}
+
+// resourceConstellixDomainSnapshotCustomizeDiff refuses at plan time to
+// create a snapshot with restore set and no version: the snapshot would be
+// of the domain as it is, and restoring it would change nothing.
+func resourceConstellixDomainSnapshotCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.Get("restore").(bool) || d.Id() != "" && !d.HasChange("domain_id") {
+		return nil
+	}
+	config := d.GetRawConfig()
+	if config.IsNull() || !config.GetAttr("version").IsNull() {
+		return nil
+	}
+	return fmt.Errorf("restore needs a version when the snapshot is created, as a snapshot of the current version has nothing to restore")
+}
+
+func resourceConstellixDomainSnapshotCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	constellixClient := m.(*client.Client)
+	domainID := d.Get("domain_id").(string)
+
+	// Without a version, the snapshot is of the domain as it is now.
+	version := d.Get("version").(int)
+	if version == 0 {
+		resp, err := constellixClient.GetbyIdContext(ctx, "v1/domains/"+domainID)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		defer resp.Body.Close()
+		bodyBytes, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		var domain map[string]interface{}
+		if err := json.Unmarshal(bodyBytes, &domain); err != nil {
+			return diag.Errorf("unexpected domain %s: %s", domainID, bodyBytes)
+		}
+		version = toIntValue(domain["version"])
+	}
+
+	log.Printf("[DEBUG] taking a snapshot of version %d of domain %s", version, domainID)
+	endpoint := fmt.Sprintf("v1/domains/%s/history/%d/snapshot", domainID, version)
+	if _, err := constellixClient.SaveContext(ctx, struct{}{}, endpoint); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(fmt.Sprintf("%s:%d", domainID, version))
+
+	if d.Get("restore").(bool) {
+		if err := restoreSnapshot(ctx, constellixClient, domainID, version); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	return resourceConstellixDomainSnapshotRead(ctx, d, m)
+}
+
+func resourceConstellixDomainSnapshotUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	constellixClient := m.(*client.Client)
+
+	if d.HasChange("restore") && d.Get("restore").(bool) {
+		domainID, version, err := parseSnapshotID(d.Id())
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if err := restoreSnapshot(ctx, constellixClient, domainID, version); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	return resourceConstellixDomainSnapshotRead(ctx, d, m)
+}
+
+// restoreSnapshot replaces the records of a domain with those of its
+// snapshot of version.
+func restoreSnapshot(ctx context.Context, constellixClient *client.Client, domainID string, version int) error {
+	log.Printf("[WARN] restoring domain %s to its snapshot of version %d", domainID, version)
+	endpoint := fmt.Sprintf("v1/domains/%s/snapshots/%d/apply", domainID, version)
+	_, err := constellixClient.SaveContext(ctx, struct{}{}, endpoint)
+	return err
+}
+
+func resourceConstellixDomainSnapshotRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	constellixClient := m.(*client.Client)
+	domainID, version, err := parseSnapshotID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	resp, err := readOrMarkGone(ctx, d, constellixClient, fmt.Sprintf("v1/domains/%s/snapshots/%d", domainID, version))
+	if err != nil || d.Id() == "" {
+		return diag.FromErr(err)
+	}
+	defer resp.Body.Close()
+	bodyBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	var data map[string]interface{}
+	if err := json.Unmarshal(bodyBytes, &data); err != nil {
+		return diag.Errorf("unexpected snapshot %s: %s", d.Id(), bodyBytes)
+	}
+
+	values := map[string]interface{}{
+		"domain_id":    domainID,
+		"version":      version,
+		"timestamp":    toStringValue(data["updatedTs"]),
+		"record_count": toIntValue(data["recordCount"]),
+	}
+	for name, value := range values {
+		if err := d.Set(name, value); err != nil {
+			return diag.Errorf("setting %s: %s", name, err)
+		}
+	}
+	return nil
+}
+
+func resourceConstellixDomainSnapshotDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	constellixClient := m.(*client.Client)
+	domainID, version, err := parseSnapshotID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = constellixClient.DeletebyIdContext(ctx, fmt.Sprintf("v1/domains/%s/snapshots/%d", domainID, version))
+	if err != nil && !isNotFound(err) {
+		return diag.FromErr(err)
+	}
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,138 @@
+package constellix
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestConstellixDomainSnapshotLifecycle(t *testing.T) {
+	f := newFakeAPI(t)
+	domainID := f.domain("example.com")
+	f.create("v1/domains/"+domainID+"/records/a", map[string]interface{}{"name": "www", "ttl": 300})
+
+	f.lifecycle(lifecycleTestCase{
+		resource: "constellix_domain_snapshot",
+		create:   map[string]interface{}{"domain_id": domainID},
+		update:   map[string]interface{}{"domain_id": domainID, "restore": true},
+		// Restoring is an action on the domain, not part of the snapshot.
+		ignore: []string{"restore"},
+	})
+}
+
+func TestConstellixDomainSnapshotRestore(t *testing.T) {
+	f := newFakeAPI(t)
+	domainID := f.domain("example.com")
+	records := "v1/domains/" + domainID + "/records/a/"
+	www := f.create(records, map[string]interface{}{"name": "www", "ttl": 300})
+	r := f.provider.ResourcesMap["constellix_domain_snapshot"]
+
+	config := map[string]interface{}{"domain_id": domainID}
+	state := f.apply(r, nil, config)
+	if state.ID != domainID+":2" || state.Attributes["version"] != "2" || state.Attributes["record_count"] != "1" {
+		t.Fatalf("expected a snapshot of version 2 holding one record, got %v", state.Attributes)
+	}
+	if state.Attributes["timestamp"] == "" {
+		t.Errorf("expected the time of the snapshot")
+	}
+
+	// The risky change: www is replaced by api.
+	if err := f.client.DeletebyId(records + www); err != nil {
+		t.Fatalf("deleting www: %s", err)
+	}
+	api := f.create(records, map[string]interface{}{"name": "api", "ttl": 60})
+
+	config["restore"] = true
+	state = f.apply(r, state, config)
+	if _, ok := f.server.Object(records + www); !ok {
+		t.Errorf("expected www to be restored")
+	}
+	if _, ok := f.server.Object(records + api); ok {
+		t.Errorf("expected api to be removed by the restore")
+	}
+	if state.ID != domainID+":2" {
+		t.Errorf("expected the restore to keep the snapshot, got %s", state.ID)
+	}
+	f.checkNoChanges(r, state, config)
+
+	// A pinned version takes a snapshot of an older state of the domain.
+	pinned := f.apply(r, nil, map[string]interface{}{"domain_id": domainID, "version": 1})
+	if pinned.ID != domainID+":1" || pinned.Attributes["record_count"] != "0" {
+		t.Errorf("expected a snapshot of the empty domain, got %v", pinned.Attributes)
+	}
+
+	// Created with restore and no version, the snapshot would be of the
+	// domain as it is, so restoring it would change nothing.
+	if _, err := f.plan(r, nil, map[string]interface{}{"domain_id": domainID, "restore": true}); err == nil {
+		t.Errorf("expected restore without a version to be refused")
+	}
+	if _, err := f.plan(r, nil, map[string]interface{}{"domain_id": domainID, "version": 1, "restore": true}); err != nil {
+		t.Errorf("expected restore with a version to be planned, got %s", err)
+	}
+
+	_, diags := r.Apply(context.Background(), nil, mustDiff(t, f, r, map[string]interface{}{"domain_id": domainID, "version": 99}), f.client)
+	if !diags.HasError() {
+		t.Errorf("expected a snapshot of an unknown version to fail")
+	}
+}
+
+func TestConstellixDomainHistoryDataSource(t *testing.T) {
+	f := newFakeAPI(t)
+	domainID := f.domain("example.com")
+	records := "v1/domains/" + domainID + "/records/"
+	f.create(records+"a", map[string]interface{}{"name": "www", "ttl": 300})
+	f.create(records+"mx", map[string]interface{}{"name": "", "ttl": 3600})
+	r := f.provider.ResourcesMap["constellix_domain_snapshot"]
+	f.apply(r, nil, map[string]interface{}{"domain_id": domainID, "version": 2})
+
+	ds := f.provider.DataSourcesMap["constellix_domain_history"]
+	d := schema.TestResourceDataRaw(t, ds.Schema, map[string]interface{}{"domain_id": domainID})
+	if diags := ds.ReadContext(context.Background(), d, f.client); diags.HasError() {
+		t.Fatalf("read failed: %v", diags)
+	}
+	if latest := d.Get("latest_version").(int); latest != 3 {
+		t.Errorf("expected latest version 3, got %d", latest)
+	}
+	versions := d.Get("versions").([]interface{})
+	if len(versions) != 3 {
+		t.Fatalf("expected 3 versions, got %v", versions)
+	}
+	for i, elem := range versions {
+		version := elem.(map[string]interface{})
+		if version["version"] != i+1 || version["record_count"] != i || version["snapshot"] != (i == 1) {
+			t.Errorf("unexpected version %d: %v", i+1, version)
+		}
+		if version["timestamp"] == "" {
+			t.Errorf("expected the time of version %d", i+1)
+		}
+	}
+
+	d = schema.TestResourceDataRaw(t, ds.Schema, map[string]interface{}{"domain_id": "999"})
+	if diags := ds.ReadContext(context.Background(), d, f.client); !diags.HasError() {
+		t.Errorf("expected the history of an unknown domain to fail")
+	}
+}
+
+func TestParseSnapshotID(t *testing.T) {
+	domainID, version, err := parseSnapshotID("1000:3")
+	if err != nil || domainID != "1000" || version != 3 {
+		t.Errorf("unexpected parse of 1000:3: %s, %d, %v", domainID, version, err)
+	}
+	for _, id := range []string{"1000", ":3", "1000:latest", "1000:3:1"} {
+		if _, _, err := parseSnapshotID(id); err == nil {
+			t.Errorf("expected %q to be refused", id)
+		}
+	}
+}
+
+// mustDiff plans config for a new resource.
+func mustDiff(t *testing.T, f *fakeAPI, r *schema.Resource, config map[string]interface{}) *terraform.InstanceDiff {
+	t.Helper()
+	diff, err := r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(config), f.client)
+	if err != nil {
+		t.Fatalf("planning: %s", err)
+	}
+	return diff
+}
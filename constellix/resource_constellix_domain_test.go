@@ -1,20 +1,23 @@
 package constellix
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"log"
+	"math"
 	"net/http"
 	"os"
 	"regexp"
 	"strings"
 	"testing"
 
-	"github.com/Constellix/constellix-go-client/client"
 	"github.com/Constellix/constellix-go-client/models"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
-	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/terraform-providers/terraform-provider-constellix/client"
 )
 
 func TestAccConstellixDomainCreation(t *testing.T) {
@@ -28,9 +31,9 @@ func TestAccConstellixDomainCreation(t *testing.T) {
 
 	var domain1, domain2 DomainAttributes
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckConstellixDomainDestroy,
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckConstellixDomainDestroy,
 		Steps: []resource.TestStep{
 			{
 				PreConfig: func() {
@@ -91,9 +94,9 @@ func TestAccConstellixDomainCreationIdempotency(t *testing.T) {
 
 	var domain DomainAttributes
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckConstellixDomainDestroy,
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckConstellixDomainDestroy,
 		Steps: []resource.TestStep{
 			{
 				PreConfig: func() {
@@ -134,6 +137,9 @@ func TestAccConstellixDomainCreationIdempotency(t *testing.T) {
 func TestAccConstellixDomainCreationExisting(t *testing.T) {
 	// Should be able to import existing domain via create operation"
 	// when domain metadata in terraform config matches domain's metadata on server"
+	if os.Getenv(resource.EnvTfAcc) == "" {
+		t.Skipf("acceptance tests skipped unless env '%s' set", resource.EnvTfAcc)
+	}
 	testName := "terraform-domain-create-import-existing-same-metadata"
 	domainName := testName + ".test"
 	resourceName := "constellix_domain." + testName
@@ -148,9 +154,9 @@ func TestAccConstellixDomainCreationExisting(t *testing.T) {
 
 	var domain DomainAttributes
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckConstellixDomainDestroy,
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckConstellixDomainDestroy,
 		Steps: []resource.TestStep{
 			{
 				PreConfig: func() {
@@ -197,9 +203,9 @@ func TestAccConstellixDomainCreationFailure(t *testing.T) {
 	invalidDomainName := "terraform_test_invalid_domain_name"
 	resourceName := "constellix_domain." + testName
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckConstellixDomainDestroy,
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckConstellixDomainDestroy,
 		Steps: []resource.TestStep{
 			{
 				PreConfig: func() {
@@ -245,9 +251,9 @@ func TestAccConstellixDomainUpdate(t *testing.T) {
 
 	var domain1, domain2, domain3 DomainAttributes
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckConstellixDomainDestroy,
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckConstellixDomainDestroy,
 		Steps: []resource.TestStep{
 			{
 				PreConfig: func() {
@@ -308,9 +314,9 @@ func TestAccConstellixDomainImport(t *testing.T) {
 
 	var domain DomainAttributes
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckConstellixDomainDestroy,
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckConstellixDomainDestroy,
 		Steps: []resource.TestStep{
 			{
 				PreConfig: func() {
@@ -349,13 +355,13 @@ func testAccCheckConstellixDomainConfig(testName, domainName string, note string
 	return fmt.Sprintf(`
 	resource "constellix_domain" "%s" {
 		name = "%s"
-		soa = {
+		soa {
 			ttl = 1800
 			primary_nameserver = "ns41.constellix.com."
 			email = "dns.constellix.com."
-			refresh = 48100
+			refresh = 43200
 			retry = 7200
-			expire = 1209
+			expire = 1209600
 			negcache = 8000
 		}
 		note = "%s"
@@ -399,7 +405,7 @@ func cleanupDomain(domainID string) func() {
 	return func() {
 		cl := givenClient()
 		err := cl.DeletebyId("v1/domains/" + domainID)
-		if err != nil && !strings.Contains(err.Error(), "Domain not found with id "+domainID) {
+		if err != nil && !client.IsNotFound(err) {
 			log.Println("error deleting domain with ID", domainID)
 		}
 	}
@@ -413,15 +419,15 @@ func givenDomainOnServer(domainName, note string, disabled bool) (string, error)
 			HasGtdRegions: false,
 			HasGeoIP:      false,
 			Note:          note,
-			Soa: &models.Soa{
-				PrimaryNameServer: "ns41.constellix.com.",
-				Email:             "dns.constellix.com.",
-				TTL:               "1800",
-				Refresh:           "48100",
-				Retry:             "7200",
-				Expire:            "1209",
-				NegCache:          "8000",
-			},
+		},
+		Soa: &SOAAttributes{
+			PrimaryNameServer: "ns41.constellix.com.",
+			Email:             "dns.constellix.com.",
+			TTL:               1800,
+			Refresh:           43200,
+			Retry:             7200,
+			Expire:            1209600,
+			NegCache:          8000,
 		},
 	}
 	cl := givenClient()
@@ -433,7 +439,8 @@ func givenDomainOnServer(domainName, note string, disabled bool) (string, error)
 }
 
 func givenClient() *client.Client {
-	return client.GetClient(os.Getenv("apikey"), os.Getenv("secretkey"))
+	cl, _ := client.GetClient(os.Getenv("apikey"), os.Getenv("secretkey"))
+	return cl
 }
 
 func domainFromResponse(resp *http.Response) (*DomainAttributes, error) {
@@ -498,3 +505,158 @@ func testAccCheckConstellixDomainAttributes(domain *DomainAttributes, expectedNa
 		return nil
 	}
 }
+
+func TestConstellixDomainLifecycle(t *testing.T) {
+	f := newFakeAPI(t)
+
+	domain := func(note string, disabled bool) map[string]interface{} {
+		return map[string]interface{}{
+			"name":     "example.com",
+			"note":     note,
+			"disabled": disabled,
+			"soa": []interface{}{map[string]interface{}{
+				"primary_nameserver": "ns41.constellix.com.",
+				"email":              "hostmaster.example.com.",
+				"ttl":                1800,
+				"refresh":            43200,
+				"retry":              7200,
+				"expire":             1209600,
+				"negcache":           8000,
+			}},
+		}
+	}
+	f.lifecycle(lifecycleTestCase{
+		resource:   "constellix_domain",
+		create:     domain("created by terraform", false),
+		update:     domain("updated by terraform", true),
+		dataSource: map[string]interface{}{"name": "example.com"},
+	})
+}
+
+func TestConstellixDomainCreationAdoptsExisting(t *testing.T) {
+	f := newFakeAPI(t)
+	domainID := f.domain("example.com")
+
+	r := f.provider.ResourcesMap["constellix_domain"]
+	config := map[string]interface{}{"name": "example.com"}
+	state := f.apply(r, nil, config)
+	if state.ID != domainID {
+		t.Fatalf("expected the existing domain %s to be adopted, got %s", domainID, state.ID)
+	}
+	f.checkNoChanges(r, state, config)
+}
+
+func TestConstellixDomainSOA(t *testing.T) {
+	f := newFakeAPI(t)
+	r := f.provider.ResourcesMap["constellix_domain"]
+
+	domain := func(soa map[string]interface{}) map[string]interface{} {
+		return map[string]interface{}{"name": "example.com", "soa": []interface{}{soa}}
+	}
+	config := domain(map[string]interface{}{
+		"email":    "hostmaster@example.com",
+		"ttl":      3600,
+		"refresh":  3600,
+		"retry":    900,
+		"expire":   1209600,
+		"negcache": 300,
+	})
+	state := f.apply(r, nil, config)
+	obj, _ := f.server.Object("v1/domains/" + state.ID)
+	soa, _ := obj["soa"].(map[string]interface{})
+	if soa["email"] != "hostmaster.example.com." || soa["refresh"] != float64(3600) || soa["negCache"] != float64(300) {
+		t.Errorf("expected the SOA to be sent with its email as a name and integer timers, got %v", soa)
+	}
+	if state.Attributes["soa.0.email"] != "hostmaster@example.com" || state.Attributes["soa.0.serial"] != "2020010101" {
+		t.Errorf("expected the configured email and the serial of the API, got %v", state.Attributes)
+	}
+	state = f.refresh(r, state)
+	f.checkNoChanges(r, state, config)
+
+	// Both spellings of the email are the same mailbox.
+	f.checkNoChanges(r, state, domain(map[string]interface{}{
+		"email":    "Hostmaster.Example.com.",
+		"ttl":      3600,
+		"refresh":  3600,
+		"retry":    900,
+		"expire":   1209600,
+		"negcache": 300,
+	}))
+
+	for email, expected := range map[string]string{
+		"hostmaster@example.com":   "hostmaster.example.com.",
+		"john.doe@example.com":     `john\.doe.example.com.`,
+		"hostmaster.example.com":   "hostmaster.example.com.",
+		"hostmaster.example.com.":  "hostmaster.example.com.",
+		" hostmaster@example.com ": "hostmaster.example.com.",
+	} {
+		if actual := soaEmail(email); actual != expected {
+			t.Errorf("expected %q to be spelled %q, got %q", email, expected, actual)
+		}
+	}
+
+	// Timers which do not fit the SOA record, and the serial, are refused
+	// while validating. Timers outside the ranges RFC 1912 recommends, as
+	// the defaults of Constellix are, are only warned about.
+	for _, soa := range []map[string]interface{}{
+		{"ttl": 0},
+		{"retry": -1},
+		{"expire": math.MaxInt32 + 1},
+		{"serial": 2021010101},
+	} {
+		if diags := r.Validate(terraform.NewResourceConfigRaw(domain(soa))); !diags.HasError() {
+			t.Errorf("expected soa %v to be invalid", soa)
+		}
+	}
+	for _, soa := range []map[string]interface{}{
+		{"refresh": 600},
+		{"refresh": 86400},
+		{"expire": 3600000},
+		{"negcache": 172800},
+	} {
+		diags := r.Validate(terraform.NewResourceConfigRaw(domain(soa)))
+		if diags.HasError() || len(diags) == 0 {
+			t.Errorf("expected soa %v to be warned about, got %v", soa, diags)
+		}
+	}
+
+	// The timers are checked against each other as RFC 1912 requires.
+	for expected, soa := range map[string]map[string]interface{}{
+		"retry (3600) must be less than refresh (3600)":            {"refresh": 3600, "retry": 3600},
+		"expire (1209600) must be greater than retry (1209600)":    {"refresh": 2419200, "retry": 1209600, "expire": 1209600},
+		"expire (1209600) must be greater than negcache (1209600)": {"expire": 1209600, "negcache": 1209600},
+	} {
+		if _, err := f.plan(r, nil, domain(soa)); err == nil || !strings.Contains(err.Error(), expected) {
+			t.Errorf("expected soa %v to be refused with %q, got %v", soa, expected, err)
+		}
+	}
+
+	// State saved when soa was a map of strings upgrades without changes,
+	// from both earlier versions.
+	for _, upgrader := range r.StateUpgraders {
+		upgraded, err := upgrader.Upgrade(context.Background(), map[string]interface{}{
+			"id":   state.ID,
+			"name": "example.com",
+			"soa": map[string]interface{}{
+				"primary_nameserver": "ns11.constellix.com.",
+				"email":              "hostmaster@example.com",
+				"ttl":                "3600",
+				"refresh":            "3600",
+				"retry":              "900",
+				"expire":             "1209600",
+				"negcache":           "300",
+			},
+		}, f.client)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if upgraded, err = r.StateUpgraders[len(r.StateUpgraders)-1].Upgrade(context.Background(), upgraded, f.client); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		value, err := schema.JSONMapToStateValue(upgraded, r.CoreConfigSchema())
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		f.checkNoChanges(r, f.refresh(r, terraform.NewInstanceStateShimmedFromValue(value, r.SchemaVersion)), config)
+	}
+}
@@ -1,26 +1,28 @@
 package constellix
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"log"
 	"strings"
 
-	"github.com/Constellix/constellix-go-client/client"
 	"github.com/Constellix/constellix-go-client/models"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/terraform-providers/terraform-provider-constellix/client"
 )
 
 func resourceConstellixIPFilter() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceConstellixIPFilterCreate,
-		Read:   resourceConstellixIPFilterRead,
-		Update: resourceConstellixIPFilterUpdate,
-		Delete: resourceConstellixIPFilterDelete,
+		CreateContext: resourceConstellixIPFilterCreate,
+		ReadContext:   resourceConstellixIPFilterRead,
+		UpdateContext: resourceConstellixIPFilterUpdate,
+		DeleteContext: resourceConstellixIPFilterDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: resourceConstellixIPFilterImport,
+			StateContext: resourceConstellixIPFilterImport,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -85,17 +87,13 @@ func resourceConstellixIPFilter() *schema.Resource {
 	}
 }
 
-func resourceConstellixIPFilterImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+func resourceConstellixIPFilterImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
 	log.Printf("[DEBUG] %s: Beginning Import", d.Id())
 	constellixClient := m.(*client.Client)
 	nsid := d.Id()
 
-	resp, err := constellixClient.GetbyId("v1/geoFilters/" + nsid)
+	resp, err := constellixClient.GetbyIdContext(ctx, "v1/geoFilters/"+nsid)
 	if err != nil {
-		if resp.StatusCode == 404 {
-			d.SetId("")
-			return nil, err
-		}
 		return nil, err
 	}
 	bodyBytes, err := ioutil.ReadAll(resp.Body)
@@ -158,7 +156,7 @@ func resourceConstellixIPFilterImport(d *schema.ResourceData, m interface{}) ([]
 	return []*schema.ResourceData{d}, nil
 }
 
-func resourceConstellixIPFilterCreate(d *schema.ResourceData, m interface{}) error {
+func resourceConstellixIPFilterCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	constellixConnect := m.(*client.Client)
 
 	ipfilterattr := models.IPFilterAttributes{}
@@ -227,37 +225,33 @@ func resourceConstellixIPFilterCreate(d *schema.ResourceData, m interface{}) err
 
 	ipfilterattr.IPAddresses = mainList
 
-	resp, err := constellixConnect.Save(ipfilterattr, "v1/geoFilters")
+	resp, err := constellixConnect.SaveContext(ctx, ipfilterattr, "v1/geoFilters")
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	bodyBytes, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	bodyString := string(bodyBytes)
 	var data map[string]interface{}
 	json.Unmarshal([]byte(bodyString[1:len(bodyString)-1]), &data)
 	d.SetId(fmt.Sprintf("%.0f", data["id"]))
-	return resourceConstellixIPFilterRead(d, m)
+	return resourceConstellixIPFilterRead(ctx, d, m)
 }
 
-func resourceConstellixIPFilterRead(d *schema.ResourceData, m interface{}) error {
+func resourceConstellixIPFilterRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	constellixClient := m.(*client.Client)
 	nsid := d.Id()
 
-	resp, err := constellixClient.GetbyId("v1/geoFilters/" + nsid)
-	if err != nil {
-		if resp.StatusCode == 404 {
-			d.SetId("")
-			return nil
-		}
-		return err
+	resp, err := readOrMarkGone(ctx, d, constellixClient, "v1/geoFilters/"+nsid)
+	if err != nil || d.Id() == "" {
+		return diag.FromErr(err)
 	}
 	bodyBytes, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	bodyString := string(bodyBytes)
 	var data map[string]interface{}
@@ -317,19 +311,19 @@ func resourceConstellixIPFilterRead(d *schema.ResourceData, m interface{}) error
 	return nil
 }
 
-func resourceConstellixIPFilterDelete(d *schema.ResourceData, m interface{}) error {
+func resourceConstellixIPFilterDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	constellixConnect := m.(*client.Client)
 
 	dn := d.Id()
-	err := constellixConnect.DeletebyId("v1/geoFilters/" + dn)
+	err := constellixConnect.DeletebyIdContext(ctx, "v1/geoFilters/"+dn)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	d.SetId("")
-	return err
+	return diag.FromErr(err)
 }
 
-func resourceConstellixIPFilterUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceConstellixIPFilterUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	constellixClient := m.(*client.Client)
 
 	ipfilterattr := models.IPFilterAttributes{}
@@ -401,9 +395,9 @@ func resourceConstellixIPFilterUpdate(d *schema.ResourceData, m interface{}) err
 
 	ipfilterattr.IPAddresses = mainList
 	nsRecord := d.Id()
-	_, err := constellixClient.UpdatebyID(ipfilterattr, "v1/geoFilters/"+nsRecord)
+	_, err := constellixClient.UpdatebyIDContext(ctx, ipfilterattr, "v1/geoFilters/"+nsRecord)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	return resourceConstellixIPFilterRead(d, m)
+	return resourceConstellixIPFilterRead(ctx, d, m)
 }
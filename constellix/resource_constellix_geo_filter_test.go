@@ -7,18 +7,18 @@ import (
 	"net/http"
 	"testing"
 
-	"github.com/Constellix/constellix-go-client/client"
 	"github.com/Constellix/constellix-go-client/models"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
-	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/terraform-providers/terraform-provider-constellix/client"
 )
 
 func TestAccIpFilter_Basic(t *testing.T) {
 	var htp models.IPFilterAttributes
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckConstellixIpFilterDestroy,
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckConstellixIpFilterDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCheckConstellixIpFilterConfig_basic("1.1.1.0/32"),
@@ -35,9 +35,9 @@ func TestAccConstellixIpFilter_Update(t *testing.T) {
 	var model models.IPFilterAttributes
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckConstellixIpFilterDestroy,
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckConstellixIpFilterDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCheckConstellixIpFilterConfig_basic("1.1.1.0/32"),
@@ -132,3 +132,24 @@ func ipfilterfromcontainer(resp *http.Response) (*models.IPFilterAttributes, err
 	return &model, nil
 
 }
+
+func TestConstellixGeoFilterLifecycle(t *testing.T) {
+	f := newFakeAPI(t)
+
+	filter := func(countries ...interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"name":             "apac",
+			"geoip_continents": []interface{}{"AS"},
+			"geoip_countries":  countries,
+			"asn":              []interface{}{64496},
+			"ipv4":             []interface{}{"192.0.2.0/24"},
+			"ipv6":             []interface{}{"2001:db8::/32"},
+		}
+	}
+	f.lifecycle(lifecycleTestCase{
+		resource:   "constellix_geo_filter",
+		create:     filter("IN"),
+		update:     filter("IN", "JP"),
+		dataSource: map[string]interface{}{"name": "apac"},
+	})
+}
@@ -1,26 +1,28 @@
 package constellix
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"log"
 	"strconv"
 
-	"github.com/Constellix/constellix-go-client/client"
 	"github.com/Constellix/constellix-go-client/models"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/terraform-providers/terraform-provider-constellix/client"
 )
 
 func resourceConstellixGeoProximity() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceConstellixGeoProximityCreate,
-		Update: resourceConstellixGeoProximityUpdate,
-		Read:   resourceConstellixGeoProximityRead,
-		Delete: resourceConstellixGeoProximityDelete,
+		CreateContext: resourceConstellixGeoProximityCreate,
+		UpdateContext: resourceConstellixGeoProximityUpdate,
+		ReadContext:   resourceConstellixGeoProximityRead,
+		DeleteContext: resourceConstellixGeoProximityDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: resourceConstellixGeoProximityImport,
+			StateContext: resourceConstellixGeoProximityImport,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -91,17 +93,13 @@ func resourceConstellixGeoProximity() *schema.Resource {
 	}
 }
 
-func resourceConstellixGeoProximityImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+func resourceConstellixGeoProximityImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
 	log.Printf("[DEBUG] %s: Beginning Import", d.Id())
 	constellixClient := m.(*client.Client)
 	geoproximityid := d.Id()
 
-	resp, err := constellixClient.GetbyId("v1/geoProximities/" + geoproximityid)
+	resp, err := constellixClient.GetbyIdContext(ctx, "v1/geoProximities/"+geoproximityid)
 	if err != nil {
-		if resp.StatusCode == 404 {
-			d.SetId("")
-			return nil, err
-		}
 		return nil, err
 	}
 	bodybytes, err := ioutil.ReadAll(resp.Body)
@@ -118,10 +116,11 @@ func resourceConstellixGeoProximityImport(d *schema.ResourceData, m interface{})
 	d.Set("region", data["region"])
 	d.Set("latitude", data["latitude"])
 	d.Set("longitude", data["longitude"])
+	d.Set("city", data["city"])
 	log.Printf("[DEBUG] %s finished import", d.Id())
 	return []*schema.ResourceData{d}, nil
 }
-func resourceConstellixGeoProximityCreate(d *schema.ResourceData, m interface{}) error {
+func resourceConstellixGeoProximityCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*client.Client)
 
 	GeoProximityAttr := models.GeoProximityAttributes{}
@@ -150,25 +149,25 @@ func resourceConstellixGeoProximityCreate(d *schema.ResourceData, m interface{})
 		GeoProximityAttr.Longitude = long.(float64)
 	}
 
-	resp, err := client.Save(GeoProximityAttr, "v1/geoProximities/")
+	resp, err := client.SaveContext(ctx, GeoProximityAttr, "v1/geoProximities/")
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	//Managing response and extracting id of resource
 	bodybtes, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	bodystring := string(bodybtes)
 	var data map[string]interface{}
 	json.Unmarshal([]byte(bodystring[1:len(bodystring)-1]), &data)
 
 	d.SetId(fmt.Sprintf("%.0f", data["id"]))
-	return resourceConstellixGeoProximityRead(d, m)
+	return resourceConstellixGeoProximityRead(ctx, d, m)
 }
 
-func resourceConstellixGeoProximityUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceConstellixGeoProximityUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*client.Client)
 	geoproximityAttr := models.GeoProximityAttributes{}
 
@@ -185,28 +184,24 @@ func resourceConstellixGeoProximityUpdate(d *schema.ResourceData, m interface{})
 	geoproximityAttr.Longitude = d.Get("longitude").(float64)
 
 	geoproximityid := d.Id()
-	_, err := client.UpdatebyID(geoproximityAttr, "v1/geoProximities/"+geoproximityid)
+	_, err := client.UpdatebyIDContext(ctx, geoproximityAttr, "v1/geoProximities/"+geoproximityid)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	return resourceConstellixGeoProximityRead(d, m)
+	return resourceConstellixGeoProximityRead(ctx, d, m)
 }
 
-func resourceConstellixGeoProximityRead(d *schema.ResourceData, m interface{}) error {
+func resourceConstellixGeoProximityRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*client.Client)
 	geoproximityid := d.Id()
 
-	resp, err := client.GetbyId("v1/geoProximities/" + geoproximityid)
-	if err != nil {
-		if resp.StatusCode == 404 {
-			d.SetId("")
-			return nil
-		}
-		return err
+	resp, err := readOrMarkGone(ctx, d, client, "v1/geoProximities/"+geoproximityid)
+	if err != nil || d.Id() == "" {
+		return diag.FromErr(err)
 	}
 	bodybytes, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	bodystring := string(bodybytes)
 
@@ -218,17 +213,18 @@ func resourceConstellixGeoProximityRead(d *schema.ResourceData, m interface{}) e
 	d.Set("region", data["region"])
 	d.Set("latitude", data["latitude"])
 	d.Set("longitude", data["longitude"])
+	d.Set("city", data["city"])
 
 	return nil
 }
 
-func resourceConstellixGeoProximityDelete(d *schema.ResourceData, m interface{}) error {
+func resourceConstellixGeoProximityDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*client.Client)
 	dn := d.Id()
 
-	err := client.DeletebyId("v1/geoProximities/" + dn)
+	err := client.DeletebyIdContext(ctx, "v1/geoProximities/"+dn)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	d.SetId("")
 	return nil
@@ -8,18 +8,18 @@ import (
 	"strconv"
 	"testing"
 
-	"github.com/Constellix/constellix-go-client/client"
 	"github.com/Constellix/constellix-go-client/models"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
-	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/terraform-providers/terraform-provider-constellix/client"
 )
 
 func TestAccGeoProximity_Basic(t *testing.T) {
 	var gp models.GeoProximityAttributes
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckConstellixGeoProximityDestroy,
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckConstellixGeoProximityDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCheckConstellixGeoProximityConfig_basic(273890),
@@ -36,9 +36,9 @@ func TestAccConstellixGeoProximity_Update(t *testing.T) {
 	var gp models.GeoProximityAttributes
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckConstellixGeoProximityDestroy,
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckConstellixGeoProximityDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCheckConstellixGeoProximityConfig_basic(0),
@@ -128,3 +128,21 @@ func geoproximityfromcontainer(resp *http.Response) (*models.GeoProximityAttribu
 	return &gp, nil
 
 }
+
+func TestConstellixGeoProximityLifecycle(t *testing.T) {
+	f := newFakeAPI(t)
+
+	proximity := func(city int) map[string]interface{} {
+		return map[string]interface{}{
+			"name":    "muscat",
+			"country": "OM",
+			"city":    city,
+		}
+	}
+	f.lifecycle(lifecycleTestCase{
+		resource:   "constellix_geo_proximity",
+		create:     proximity(287286),
+		update:     proximity(287832),
+		dataSource: map[string]interface{}{"name": "muscat"},
+	})
+}
@@ -8,18 +8,18 @@ import (
 	"strconv"
 	"testing"
 
-	"github.com/Constellix/constellix-go-client/client"
 	"github.com/Constellix/constellix-go-client/models"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
-	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/terraform-providers/terraform-provider-constellix/client"
 )
 
 func TestAccHinfo_Basic(t *testing.T) {
 	var hinfo models.HinfoAttributes
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckConstellixHinfoDestroy,
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckConstellixHinfoDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCheckConstellixHinfoConfig_basic(1800),
@@ -36,9 +36,9 @@ func TestAccConstellixHinfo_Update(t *testing.T) {
 	var hinfo models.HinfoAttributes
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckConstellixHinfoDestroy,
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckConstellixHinfoDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCheckConstellixHinfoConfig_basic(1800),
@@ -62,13 +62,13 @@ func testAccCheckConstellixHinfoConfig_basic(ttl int) string {
 	return fmt.Sprintf(`
 	resource "constellix_domain" "domain1" {
 		name = "checkhinfo.com"
-		soa = {
+		soa {
 			email = "com.com."
 			primary_nameserver = "ns41.constellix.com."
 			ttl = 1900
-			refresh = 48100
+			refresh = 43200
 			retry = 7200
-			expire = 1209
+			expire = 1209600
 			negcache = 8000
 		}
 	}
@@ -82,12 +82,12 @@ func testAccCheckConstellixHinfoConfig_basic(ttl int) string {
 		roundrobin {
 			cpu = "quard core"
 			os = "linux2"
-			disable_flag = "false"
+			disable_flag = false
 		}
 		roundrobin{
 			cpu = "abc"
 			os = "windows"
-			disable_flag = "true"
+			disable_flag = true
 		}
 	}
 	`, ttl)
@@ -178,3 +178,27 @@ func hinfofromcontainer(resp *http.Response) (*models.HinfoAttributes, error) {
 	return &hinfo, nil
 
 }
+
+func TestConstellixHinfoRecordLifecycle(t *testing.T) {
+	f := newFakeAPI(t)
+	domainID := f.domain("example.com")
+
+	hinfo := func(ttl int, os string) map[string]interface{} {
+		return map[string]interface{}{
+			"domain_id":   domainID,
+			"source_type": "domains",
+			"name":        "host",
+			"ttl":         ttl,
+			"roundrobin": []interface{}{
+				map[string]interface{}{"cpu": "x86_64", "os": os, "disable_flag": false},
+			},
+		}
+	}
+	f.lifecycle(lifecycleTestCase{
+		resource:   "constellix_hinfo_record",
+		create:     hinfo(300, "linux"),
+		update:     hinfo(600, "freebsd"),
+		importID:   recordImportID,
+		dataSource: map[string]interface{}{"domain_id": domainID, "source_type": "domains", "name": "host"},
+	})
+}
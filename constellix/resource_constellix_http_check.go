@@ -1,27 +1,29 @@
 package constellix
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"log"
 	"strings"
 
-	"github.com/Constellix/constellix-go-client/client"
 	"github.com/Constellix/constellix-go-client/models"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/terraform-providers/terraform-provider-constellix/client"
 )
 
 func resourceConstellixHTTPCheck() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceConstellixHTTPCheckCreate,
-		Read:   resourceConstellixHTTPCheckRead,
-		Update: resourceConstellixHTTPCheckUpdate,
-		Delete: resourceConstellixHTTPCheckDelete,
+		CreateContext: resourceConstellixHTTPCheckCreate,
+		ReadContext:   resourceConstellixHTTPCheckRead,
+		UpdateContext: resourceConstellixHTTPCheckUpdate,
+		DeleteContext: resourceConstellixHTTPCheckDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: resourceConstellixHTTPCheckImport,
+			StateContext: resourceConstellixHTTPCheckImport,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -130,12 +132,12 @@ func resourceConstellixHTTPCheck() *schema.Resource {
 	}
 }
 
-func resourceConstellixHTTPCheckImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+func resourceConstellixHTTPCheckImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
 	log.Printf("[DEBUG] %s: Beginning Import", d.Id())
 	constellixClient := m.(*client.Client)
 	dn := d.Id()
 
-	resp, err := constellixClient.GetbyId("https://api.sonar.constellix.com/rest/api/http/" + dn)
+	resp, err := constellixClient.GetbyIdContext(ctx, constellixClient.SonarEndpoint("rest/api/http/")+dn)
 	if err != nil {
 		return nil, err
 	}
@@ -167,7 +169,7 @@ func resourceConstellixHTTPCheckImport(d *schema.ResourceData, m interface{}) ([
 	return []*schema.ResourceData{d}, nil
 }
 
-func resourceConstellixHTTPCheckCreate(d *schema.ResourceData, m interface{}) error {
+func resourceConstellixHTTPCheckCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*client.Client)
 
 	httpcheckAttr := models.HttpcheckAttr{}
@@ -229,9 +231,9 @@ func resourceConstellixHTTPCheckCreate(d *schema.ResourceData, m interface{}) er
 		httpcheckAttr.ExpectedStatus = expected_status_code.(int)
 	}
 
-	resp, err := client.Save(httpcheckAttr, "https://api.sonar.constellix.com/rest/api/http")
+	resp, err := client.SaveContext(ctx, httpcheckAttr, client.SonarEndpoint("rest/api/http"))
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	var location string
@@ -243,15 +245,15 @@ func resourceConstellixHTTPCheckCreate(d *schema.ResourceData, m interface{}) er
 		}
 	}
 	if flag == false {
-		return fmt.Errorf("response contains empty location value")
+		return diag.Errorf("response contains empty location value")
 	}
 
 	locArr := strings.Split(location, "/")
 	d.SetId(locArr[len(locArr)-1])
-	return resourceConstellixHTTPCheckRead(d, m)
+	return resourceConstellixHTTPCheckRead(ctx, d, m)
 }
 
-func resourceConstellixHTTPCheckUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceConstellixHTTPCheckUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*client.Client)
 
 	httpcheckAttr := models.HttpcheckAttr{}
@@ -306,24 +308,24 @@ func resourceConstellixHTTPCheckUpdate(d *schema.ResourceData, m interface{}) er
 	}
 
 	dn := d.Id()
-	_, err := client.UpdatebyID(httpcheckAttr, "https://api.sonar.constellix.com/rest/api/http/"+dn)
+	_, err := client.UpdatebyIDContext(ctx, httpcheckAttr, client.SonarEndpoint("rest/api/http/")+dn)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	return resourceConstellixHTTPCheckRead(d, m)
+	return resourceConstellixHTTPCheckRead(ctx, d, m)
 }
 
-func resourceConstellixHTTPCheckRead(d *schema.ResourceData, m interface{}) error {
+func resourceConstellixHTTPCheckRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*client.Client)
 	dn := d.Id()
 
-	resp, err := client.GetbyId("https://api.sonar.constellix.com/rest/api/http/" + dn)
-	if err != nil {
-		return err
+	resp, err := readOrMarkGone(ctx, d, client, client.SonarEndpoint("rest/api/http/")+dn)
+	if err != nil || d.Id() == "" {
+		return diag.FromErr(err)
 	}
 	bodybytes, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	bodystring := string(bodybytes)
 
@@ -348,13 +350,13 @@ func resourceConstellixHTTPCheckRead(d *schema.ResourceData, m interface{}) erro
 	return nil
 }
 
-func resourceConstellixHTTPCheckDelete(d *schema.ResourceData, m interface{}) error {
+func resourceConstellixHTTPCheckDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*client.Client)
 	dn := d.Id()
 
-	err := client.DeletebyId("https://api.sonar.constellix.com/rest/api/http/" + dn)
+	err := client.DeletebyIdContext(ctx, client.SonarEndpoint("rest/api/http/")+dn)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	d.SetId("")
 	return nil
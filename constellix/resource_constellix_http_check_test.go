@@ -8,18 +8,18 @@ import (
 	"strconv"
 	"testing"
 
-	"github.com/Constellix/constellix-go-client/client"
 	"github.com/Constellix/constellix-go-client/models"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
-	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/terraform-providers/terraform-provider-constellix/client"
 )
 
 func TestAccHTTPCheck_Basic(t *testing.T) {
 	var httpCheck models.HttpcheckAttr
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckConstellixHTTPCheckDestroy,
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckConstellixHTTPCheckDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCheckConstellixHTTPCheckConfig_basic(443),
@@ -36,9 +36,9 @@ func TestAccConstellixHTTPCheck_Update(t *testing.T) {
 	var httpCheck models.HttpcheckAttr
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckConstellixHTTPCheckDestroy,
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckConstellixHTTPCheckDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCheckConstellixHTTPCheckConfig_basic(443),
@@ -85,7 +85,7 @@ func testAccCheckConstellixHTTPCheckExists(httpName string, http *models.Httpche
 
 		client := testAccProvider.Meta().(*client.Client)
 
-		resp, err1 := client.GetbyId("https://api.sonar.constellix.com/rest/api/http/" + rs.Primary.ID)
+		resp, err1 := client.GetbyId(client.SonarEndpoint("rest/api/http/") + rs.Primary.ID)
 
 		if err1 != nil {
 			return err1
@@ -116,7 +116,7 @@ func testAccCheckConstellixHTTPCheckDestroy(s *terraform.State) error {
 	for _, rs := range s.RootModule().Resources {
 
 		if rs.Type == "constellix_http_check" {
-			_, err := client.GetbyId("https://api.sonar.constellix.com/rest/api/http/" + rs.Primary.ID)
+			_, err := client.GetbyId(client.SonarEndpoint("rest/api/http/") + rs.Primary.ID)
 			if err == nil {
 				return fmt.Errorf("HTTP check resource still exists")
 			}
@@ -144,3 +144,24 @@ func httpCheckfromcontainer(resp *http.Response) (*models.HttpcheckAttr, error)
 	return &http, nil
 
 }
+
+func TestConstellixHTTPCheckLifecycle(t *testing.T) {
+	f := newFakeAPI(t)
+
+	check := func(port int) map[string]interface{} {
+		return map[string]interface{}{
+			"name":          "website",
+			"host":          "www.example.com",
+			"ip_version":    "IPV4",
+			"port":          port,
+			"protocol_type": "HTTPS",
+			"check_sites":   []interface{}{1, 2},
+		}
+	}
+	f.lifecycle(lifecycleTestCase{
+		resource:   "constellix_http_check",
+		create:     check(443),
+		update:     check(8443),
+		dataSource: map[string]interface{}{"name": "website"},
+	})
+}
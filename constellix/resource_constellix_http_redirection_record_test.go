@@ -8,18 +8,18 @@ import (
 	"strconv"
 	"testing"
 
-	"github.com/Constellix/constellix-go-client/client"
 	"github.com/Constellix/constellix-go-client/models"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
-	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/terraform-providers/terraform-provider-constellix/client"
 )
 
 func TestAccHTTPRedirection_Basic(t *testing.T) {
 	var htp models.HTTPRedirectionAttributes
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckConstellixHTTPRedirectionDestroy,
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckConstellixHTTPRedirectionDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCheckConstellixHTTPRedirectionConfig_basic(1800),
@@ -36,9 +36,9 @@ func TestAccConstellixHTTPRedirection_Update(t *testing.T) {
 	var htp models.HTTPRedirectionAttributes
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckConstellixHTTPRedirectionDestroy,
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckConstellixHTTPRedirectionDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCheckConstellixHTTPRedirectionConfig_basic(1800),
@@ -62,13 +62,13 @@ func testAccCheckConstellixHTTPRedirectionConfig_basic(ttl int) string {
 	return fmt.Sprintf(`
 	resource "constellix_domain" "domain1" {
 		name = "checkhttp.com"
-		soa = {
+		soa {
 			email = "com.com."
 			primary_nameserver = "ns41.constellix.com."
 			ttl = 1900
-			refresh = 48100
+			refresh = 43200
 			retry = 7200
-			expire = 1209
+			expire = 1209600
 			negcache = 8000
 		}
 	}
@@ -173,3 +173,26 @@ func testAccCheckConstellixHTTPRedirectionAttributes(ttl interface{}, htp *model
 		return nil
 	}
 }
+
+func TestConstellixHTTPRedirectionRecordLifecycle(t *testing.T) {
+	f := newFakeAPI(t)
+	domainID := f.domain("example.com")
+
+	redirect := func(ttl int, url string) map[string]interface{} {
+		return map[string]interface{}{
+			"domain_id":        domainID,
+			"source_type":      "domains",
+			"name":             "go",
+			"ttl":              ttl,
+			"redirect_type_id": 1,
+			"url":              url,
+		}
+	}
+	f.lifecycle(lifecycleTestCase{
+		resource:   "constellix_http_redirection_record",
+		create:     redirect(300, "https://example.net/"),
+		update:     redirect(600, "https://example.org/"),
+		importID:   recordImportID,
+		dataSource: map[string]interface{}{"domain_id": domainID, "source_type": "domains", "name": "go"},
+	})
+}
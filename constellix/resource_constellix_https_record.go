@@ -0,0 +1,20 @@
+package constellix
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// httpsRecord binds HTTP origins to the endpoints serving them, with the
+// same values as SVCB records (RFC 9460).
+var httpsRecord = &recordType{
+	path:       "https",
+	roundRobin: serviceBindingValues(),
+}
+
+func resourceConstellixHTTPS() *schema.Resource {
+	return httpsRecord.resource()
+}
+
+func datasourceConstellixHTTPS() *schema.Resource {
+	return httpsRecord.dataSource()
+}
@@ -0,0 +1,50 @@
+package constellix
+
+import (
+	"testing"
+)
+
+func TestConstellixHTTPSRecordLifecycle(t *testing.T) {
+	f := newFakeAPI(t)
+	domainID := f.domain("example.com")
+
+	https := func(ttl int, values ...interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"domain_id":   domainID,
+			"source_type": "domains",
+			"name":        "",
+			"ttl":         ttl,
+			"roundrobin":  values,
+		}
+	}
+	cdn := map[string]interface{}{
+		"priority": 1,
+		"target":   ".",
+		"svc_params": []interface{}{map[string]interface{}{
+			"alpn":     []interface{}{"h3", "h2"},
+			"ipv4hint": []interface{}{"192.0.2.1"},
+			"ipv6hint": []interface{}{"2001:db8::1"},
+		}},
+	}
+	fallback := map[string]interface{}{
+		"priority": 2,
+		"target":   "fallback.example.net.",
+		"svc_params": []interface{}{map[string]interface{}{
+			"port": 8443,
+			"ech":  "AEX+DQBBpQAgACBvCZSk",
+		}},
+	}
+	f.lifecycle(lifecycleTestCase{
+		resource:   "constellix_https_record",
+		create:     https(300, cdn),
+		update:     https(600, cdn, fallback),
+		importID:   recordImportID,
+		dataSource: map[string]interface{}{"domain_id": domainID, "source_type": "domains", "name": ""},
+	})
+
+	record := httpsRecord.roundRobin.expand([]interface{}{fallback})
+	params := record[0].(map[string]interface{})["svcParams"].(map[string]interface{})
+	if params["port"] != 8443 || params["ech"] != "AEX+DQBBpQAgACBvCZSk" || params["alpn"] != nil {
+		t.Errorf("expected only the configured SvcParams to be sent, got %v", params)
+	}
+}
@@ -1,325 +1,48 @@
 package constellix
 
 import (
-	"encoding/json"
-	"fmt"
-	"io/ioutil"
-	"log"
-	"strings"
-
-	"github.com/Constellix/constellix-go-client/client"
-	"github.com/Constellix/constellix-go-client/models"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
-func resourceConstellixMX() *schema.Resource {
-	return &schema.Resource{
-		Create: resourceConstellixMXCreate,
-		Update: resourceConstellixMXUpdate,
-		Read:   resourceConstellixMXRead,
-		Delete: resourceConstellixMXDelete,
-
-		Importer: &schema.ResourceImporter{
-			State: resourceConstellixMXImport,
-		},
-
-		Schema: map[string]*schema.Schema{
-			"domain_id": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
-			},
-
-			"source_type": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
-			},
-
-			"name": &schema.Schema{
-				Type:     schema.TypeString,
-				Optional: true,
-				ForceNew: true,
+// mxRecord lists the mail exchangers of a name.
+var mxRecord = &recordType{
+	path:          "mx",
+	schemaVersion: 1,
+	roundRobin: &recordValues{
+		fields: []recordField{
+			{
+				name:     "value",
+				json:     "value",
+				hostname: true,
+				schema: &schema.Schema{
+					Type:     schema.TypeString,
+					Required: true,
+				},
 			},
-
-			"ttl": &schema.Schema{
-				Type:     schema.TypeInt,
-				Required: true,
+			{
+				name: "level",
+				json: "level",
+				schema: &schema.Schema{
+					Type:     schema.TypeString,
+					Required: true,
+				},
+				toAPI: apiInt,
 			},
-
-			"noanswer": &schema.Schema{
+			disableFlagField(&schema.Schema{
 				Type:     schema.TypeBool,
 				Optional: true,
 				Computed: true,
-			},
-
-			"note": &schema.Schema{
-				Type:     schema.TypeString,
-				Optional: true,
-				Computed: true,
-			},
-
-			"gtd_region": &schema.Schema{
-				Type:     schema.TypeInt,
-				Optional: true,
-				Computed: true,
-			},
-
-			"type": &schema.Schema{
-				Type:     schema.TypeString,
-				Optional: true,
-				Computed: true,
-			},
-
-			"roundrobin": &schema.Schema{
-				Type:     schema.TypeSet,
-				Required: true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"value": &schema.Schema{
-							Type:     schema.TypeString,
-							Required: true,
-						},
-
-						"level": &schema.Schema{
-							Type:     schema.TypeString,
-							Required: true,
-						},
-
-						"disable_flag": &schema.Schema{
-							Type:     schema.TypeString,
-							Optional: true,
-							Computed: true,
-						},
-					},
-				},
-			},
+			}),
 		},
-	}
-}
-
-func resourceConstellixMXImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-	log.Printf("[DEBUG] %s: Beginning Import", d.Id())
-	constellixClient := m.(*client.Client)
-	params := strings.Split(d.Id(), ":")
-	resp, err := constellixClient.GetbyId("v1/" + params[0] + "/" + params[1] + "/records/mx/" + params[2])
-	if err != nil {
-		if resp.StatusCode == 404 {
-			d.SetId("")
-			return nil, err
-		}
-		return nil, err
-	}
-	bodybytes, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-	bodystring := string(bodybytes)
-
-	var data map[string]interface{}
-	json.Unmarshal([]byte(bodystring), &data)
-	d.SetId(fmt.Sprintf("%.0f", data["id"]))
-	d.Set("name", data["name"])
-	d.Set("ttl", data["ttl"])
-	d.Set("noanswer", data["noAnswer"])
-	d.Set("note", data["note"])
-	d.Set("gtd_region", data["gtdRegion"])
-	d.Set("type", data["type"])
-	d.Set("parentid", data["parentId"])
-	d.Set("parent", data["parent"])
-	d.Set("source", data["source"])
-
-	resrr := (data["roundRobin"]).([]interface{})
-	mapListRR := make([]interface{}, 0, 1)
-	for _, val := range resrr {
-		tpMap := make(map[string]interface{})
-		inner := val.(map[string]interface{})
-		tpMap["value"] = fmt.Sprintf("%v", inner["value"])
-		tpMap["level"] = fmt.Sprintf("%v", inner["level"])
-		tpMap["disable_flag"] = fmt.Sprintf("%v", inner["disableFlag"])
-		mapListRR = append(mapListRR, tpMap)
-	}
-
-	d.Set("roundrobin", mapListRR)
-	d.Set("domain_id", params[1])
-	d.Set("source_type", params[0])
-	log.Printf("[DEBUG] %s finished import", d.Id())
-	return []*schema.ResourceData{d}, nil
-}
-
-func resourceConstellixMXCreate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*client.Client)
-
-	mxAttr := models.MXAttributes{}
-
-	if name, ok := d.GetOk("name"); ok {
-		mxAttr.Name = name.(string)
-	}
-
-	if ttl, ok := d.GetOk("ttl"); ok {
-		mxAttr.TTL = ttl.(int)
-	}
-
-	if noans, ok := d.GetOk("noanswer"); ok {
-		mxAttr.NoAnswer = noans.(bool)
-	}
-
-	if note, ok := d.GetOk("note"); ok {
-		mxAttr.Note = note.(string)
-	}
-
-	if gtdr, ok := d.GetOk("gtd_region"); ok {
-		mxAttr.GtdRegion = gtdr.(int)
-	}
-
-	if tp, ok := d.GetOk("type"); ok {
-		mxAttr.Type = tp.(string)
-	}
-
-	if rr, ok := d.GetOk("roundrobin"); ok {
-		mapListRR := make([]interface{}, 0, 1)
-		tp := rr.(*schema.Set).List()
-		for _, val := range tp {
-			tpMap := make(map[string]interface{})
-			inner := val.(map[string]interface{})
-			tpMap["value"] = fmt.Sprintf("%v", inner["value"])
-			tpMap["level"] = fmt.Sprintf("%v", inner["level"])
-			tpMap["disableFlag"] = fmt.Sprintf("%v", inner["disable_flag"])
-			mapListRR = append(mapListRR, tpMap)
-		}
-		mxAttr.RoundRobin = mapListRR
-	}
-
-	id := d.Get("domain_id").(string)
-	source := d.Get("source_type").(string)
-
-	resp, err := client.Save(mxAttr, "v1/"+source+"/"+id+"/records/mx")
-	if err != nil {
-		return err
-	}
-
-	//Managing response and extracting id of resource
-	bodybtes, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return err
-	}
-	bodystring := string(bodybtes)
-	var data map[string]interface{}
-	json.Unmarshal([]byte(bodystring[1:len(bodystring)-1]), &data)
-
-	d.SetId(fmt.Sprintf("%.0f", data["id"]))
-	return resourceConstellixMXRead(d, m)
+	},
 }
 
-func resourceConstellixMXUpdate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*client.Client)
-	mxAttr := models.MXAttributes{}
-
-	if name, ok := d.GetOk("name"); ok {
-		mxAttr.Name = name.(string)
-	}
-
-	if ttl, ok := d.GetOk("ttl"); ok {
-		mxAttr.TTL = ttl.(int)
-	}
-
-	if _, ok := d.GetOk("noanswer"); ok {
-		mxAttr.NoAnswer = d.Get("noanswer").(bool)
-	}
-
-	if _, ok := d.GetOk("note"); ok {
-		mxAttr.Note = d.Get("note").(string)
-	}
-
-	if _, ok := d.GetOk("gtd_region"); ok {
-		mxAttr.GtdRegion = d.Get("gtd_region").(int)
-	}
-
-	if _, ok := d.GetOk("type"); ok {
-		mxAttr.Type = d.Get("type").(string)
-	}
-
-	if rr, ok := d.GetOk("roundrobin"); ok {
-		mapListRR := make([]interface{}, 0, 1)
-		tp := rr.(*schema.Set).List()
-		for _, val := range tp {
-			tpMap := make(map[string]interface{})
-			inner := val.(map[string]interface{})
-			tpMap["value"] = fmt.Sprintf("%v", inner["value"])
-			tpMap["level"] = fmt.Sprintf("%v", inner["level"])
-			tpMap["disableFlag"] = fmt.Sprintf("%v", inner["disable_flag"])
-			mapListRR = append(mapListRR, tpMap)
-		}
-		mxAttr.RoundRobin = mapListRR
-	}
-
-	domainid := d.Get("domain_id").(string)
-	mxid := d.Id()
-	source := d.Get("source_type").(string)
-
-	_, err := client.UpdatebyID(mxAttr, "v1/"+source+"/"+domainid+"/records/mx/"+mxid)
-	if err != nil {
-		return err
-	}
-	return resourceConstellixMXRead(d, m)
-}
-
-func resourceConstellixMXRead(d *schema.ResourceData, m interface{}) error {
-	client := m.(*client.Client)
-	domainid := d.Get("domain_id").(string)
-	mxid := d.Id()
-	source := d.Get("source_type").(string)
-
-	resp, err := client.GetbyId("v1/" + source + "/" + domainid + "/records/mx/" + mxid)
-	if err != nil {
-		if resp.StatusCode == 404 {
-			d.SetId("")
-			return nil
-		}
-		return err
-	}
-	bodybytes, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return err
-	}
-	bodystring := string(bodybytes)
-
-	var data map[string]interface{}
-	json.Unmarshal([]byte(bodystring), &data)
-	d.SetId(fmt.Sprintf("%.0f", data["id"]))
-	d.Set("name", data["name"])
-	d.Set("ttl", data["ttl"])
-	d.Set("noanswer", data["noAnswer"])
-	d.Set("note", data["note"])
-	d.Set("gtd_region", data["gtdRegion"])
-	d.Set("type", data["type"])
-	d.Set("parentid", data["parentId"])
-	d.Set("parent", data["parent"])
-	d.Set("source", data["source"])
-
-	resrr := (data["roundRobin"]).([]interface{})
-	mapListRR := make([]interface{}, 0, 1)
-	for _, val := range resrr {
-		tpMap := make(map[string]interface{})
-		inner := val.(map[string]interface{})
-		tpMap["value"] = fmt.Sprintf("%v", inner["value"])
-		tpMap["level"] = fmt.Sprintf("%v", inner["level"])
-		tpMap["disable_flag"] = fmt.Sprintf("%v", inner["disableFlag"])
-		mapListRR = append(mapListRR, tpMap)
-	}
-
-	d.Set("roundrobin", mapListRR)
-	return nil
+func resourceConstellixMX() *schema.Resource {
+	r := mxRecord.resource()
+	r.StateUpgraders = []schema.StateUpgrader{disableFlagUpgrader(r, 0)}
+	return r
 }
 
-func resourceConstellixMXDelete(d *schema.ResourceData, m interface{}) error {
-	constellixConnect := m.(*client.Client)
-	domainid := d.Get("domain_id").(string)
-	mxid := d.Id()
-	source := d.Get("source_type").(string)
-
-	err := constellixConnect.DeletebyId("v1/" + source + "/" + domainid + "/records/mx/" + mxid)
-	if err != nil {
-		return err
-	}
-	d.SetId("")
-	return nil
+func datasourceConstellixMX() *schema.Resource {
+	return mxRecord.dataSource()
 }
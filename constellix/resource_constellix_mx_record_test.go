@@ -8,18 +8,18 @@ import (
 	"strconv"
 	"testing"
 
-	"github.com/Constellix/constellix-go-client/client"
 	"github.com/Constellix/constellix-go-client/models"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
-	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/terraform-providers/terraform-provider-constellix/client"
 )
 
 func TestAccMX_Basic(t *testing.T) {
 	var mx models.MXAttributes
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckConstellixMXDestroy,
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckConstellixMXDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCheckConstellixMXConfig_basic(1800),
@@ -35,9 +35,9 @@ func TestAccMX_Basic(t *testing.T) {
 func TestAccConstellixMx_Update(t *testing.T) {
 	var mx models.MXAttributes
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckConstellixMXDestroy,
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckConstellixMXDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCheckConstellixMXConfig_basic(1800),
@@ -61,13 +61,13 @@ func testAccCheckConstellixMXConfig_basic(ttl int) string {
 	return fmt.Sprintf(`
 	resource "constellix_domain" "domain1" {
 		name = "checkmx.com"
-		soa = {
+		soa {
 			email = "com.com."
 			primary_nameserver = "ns41.constellix.com."
 			ttl = 1900
-			refresh = 48100
+			refresh = 43200
 			retry = 7200
-			expire = 1209
+			expire = 1209600
 			negcache = 8000
 		}
 	}
@@ -172,3 +172,28 @@ func mxfromcontainer(resp *http.Response) (*models.MXAttributes, error) {
 	return &mx, nil
 
 }
+
+func TestConstellixMXRecordLifecycle(t *testing.T) {
+	f := newFakeAPI(t)
+	domainID := f.domain("example.com")
+
+	mx := func(ttl int, level string) map[string]interface{} {
+		return map[string]interface{}{
+			"domain_id":   domainID,
+			"source_type": "domains",
+			"name":        "mail",
+			"ttl":         ttl,
+			"note":        "managed by terraform",
+			"roundrobin": []interface{}{
+				map[string]interface{}{"value": "mx1.example.com.", "level": level, "disable_flag": false},
+			},
+		}
+	}
+	f.lifecycle(lifecycleTestCase{
+		resource:   "constellix_mx_record",
+		create:     mx(300, "10"),
+		update:     mx(600, "20"),
+		importID:   recordImportID,
+		dataSource: map[string]interface{}{"domain_id": domainID, "source_type": "domains", "name": "mail"},
+	})
+}
@@ -8,18 +8,18 @@ import (
 	"strconv"
 	"testing"
 
-	"github.com/Constellix/constellix-go-client/client"
 	"github.com/Constellix/constellix-go-client/models"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
-	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/terraform-providers/terraform-provider-constellix/client"
 )
 
 func TestAccNaptr_Basic(t *testing.T) {
 	var naptr models.NAPTRAttributes
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckConstellixNaptrDestroy,
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckConstellixNaptrDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCheckConstellixNaptrConfig_basic(1800),
@@ -36,9 +36,9 @@ func TestAccConstellixNaptr_Update(t *testing.T) {
 	var naptr models.NAPTRAttributes
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckConstellixNaptrDestroy,
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckConstellixNaptrDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCheckConstellixNaptrConfig_basic(1800),
@@ -62,13 +62,13 @@ func testAccCheckConstellixNaptrConfig_basic(ttl int) string {
 	return fmt.Sprintf(`
 	resource "constellix_domain" "domain1" {
 		name = "checknaptr.com"
-		soa = {
+		soa {
 			email = "com.com."
 			primary_nameserver = "ns41.constellix.com."
 			ttl = 1900
-			refresh = 48100
+			refresh = 43200
 			retry = 7200
-			expire = 1209
+			expire = 1209600
 			negcache = 8000
 		}
 	}
@@ -86,7 +86,7 @@ func testAccCheckConstellixNaptrConfig_basic(ttl int) string {
 			       service = "SIP+D2U"
 			       regular_expression = "hello"
 			       replacement = "foobar.example.com."
-			       disable_flag = "false"
+			       disable_flag = false
 			     }
 	}
 	`, ttl)
@@ -178,3 +178,35 @@ func naptrfromcontainer(resp *http.Response) (*models.NAPTRAttributes, error) {
 	return &naptr, nil
 
 }
+
+func TestConstellixNAPTRRecordLifecycle(t *testing.T) {
+	f := newFakeAPI(t)
+	domainID := f.domain("example.com")
+
+	naptr := func(ttl, order int) map[string]interface{} {
+		return map[string]interface{}{
+			"domain_id":   domainID,
+			"source_type": "domains",
+			"name":        "sip",
+			"ttl":         ttl,
+			"roundrobin": []interface{}{
+				map[string]interface{}{
+					"order":              order,
+					"preference":         100,
+					"flags":              "s",
+					"service":            "SIP+D2U",
+					"regular_expression": "",
+					"replacement":        "_sip._udp.example.com.",
+					"disable_flag":       false,
+				},
+			},
+		}
+	}
+	f.lifecycle(lifecycleTestCase{
+		resource:   "constellix_naptr_record",
+		create:     naptr(300, 10),
+		update:     naptr(600, 20),
+		importID:   recordImportID,
+		dataSource: map[string]interface{}{"domain_id": domainID, "source_type": "domains", "name": "sip"},
+	})
+}
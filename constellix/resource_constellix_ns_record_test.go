@@ -8,18 +8,18 @@ import (
 	"strconv"
 	"testing"
 
-	"github.com/Constellix/constellix-go-client/client"
 	"github.com/Constellix/constellix-go-client/models"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
-	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/terraform-providers/terraform-provider-constellix/client"
 )
 
 func TestAccNs_Basic(t *testing.T) {
 	var ns models.NSAttributes
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckConstellixNsDestroy,
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckConstellixNsDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCheckConstellixNsConfig_basic(1800),
@@ -36,9 +36,9 @@ func TestAccConstellixNs_Update(t *testing.T) {
 	var ns models.NSAttributes
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckConstellixNsDestroy,
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckConstellixNsDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCheckConstellixNsConfig_basic(1800),
@@ -62,13 +62,13 @@ func testAccCheckConstellixNsConfig_basic(ttl int) string {
 	return fmt.Sprintf(`
 	resource "constellix_domain" "domain1" {
 		name = "checkns.com"
-		soa = {
+		soa {
 			email = "com.com."
 			primary_nameserver = "ns41.constellix.com."
 			ttl = 1900
-			refresh = 48100
+			refresh = 43200
 			retry = 7200
-			expire = 1209
+			expire = 1209600
 			negcache = 8000
 		}
 	}
@@ -81,7 +81,7 @@ func testAccCheckConstellixNsConfig_basic(ttl int) string {
 		note = "Practice record naptr"
 		roundrobin {
 			       value = "f5."
-			       disable_flag = "false"
+			       disable_flag = false
 			   }
 	}
 	`, ttl)
@@ -173,3 +173,29 @@ func nsfromcontainer(resp *http.Response) (*models.NSAttributes, error) {
 	return &ns, nil
 
 }
+
+func TestConstellixNSRecordLifecycle(t *testing.T) {
+	f := newFakeAPI(t)
+	domainID := f.domain("example.com")
+
+	ns := func(ttl int, values ...string) map[string]interface{} {
+		roundrobin := make([]interface{}, 0, len(values))
+		for _, value := range values {
+			roundrobin = append(roundrobin, map[string]interface{}{"value": value, "disable_flag": false})
+		}
+		return map[string]interface{}{
+			"domain_id":   domainID,
+			"source_type": "domains",
+			"name":        "sub",
+			"ttl":         ttl,
+			"roundrobin":  roundrobin,
+		}
+	}
+	f.lifecycle(lifecycleTestCase{
+		resource:   "constellix_ns_record",
+		create:     ns(300, "ns1.example.net."),
+		update:     ns(600, "ns1.example.net.", "ns2.example.net."),
+		importID:   recordImportID,
+		dataSource: map[string]interface{}{"domain_id": domainID, "source_type": "domains", "name": "sub"},
+	})
+}
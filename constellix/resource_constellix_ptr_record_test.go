@@ -9,18 +9,18 @@ import (
 	"strconv"
 	"testing"
 
-	"github.com/Constellix/constellix-go-client/client"
 	"github.com/Constellix/constellix-go-client/models"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
-	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/terraform-providers/terraform-provider-constellix/client"
 )
 
 func TestAccPtr_Basic(t *testing.T) {
 	var ptr models.PtrAttributes
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckConstellixPtrDestroy,
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckConstellixPtrDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCheckConstellixPtrConfig_basic(1800),
@@ -37,9 +37,9 @@ func TestAccConstellixPtr_Update(t *testing.T) {
 	var ptr models.PtrAttributes
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckConstellixPtrDestroy,
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckConstellixPtrDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCheckConstellixPtrConfig_basic(1800),
@@ -63,13 +63,13 @@ func testAccCheckConstellixPtrConfig_basic(ttl int) string {
 	return fmt.Sprintf(`
 	resource "constellix_domain" "domain1" {
 		name = "checkptr.com"
-		soa = {
+		soa {
 			email = "com.com."
 			primary_nameserver = "ns41.constellix.com."
 			ttl = 1900
-			refresh = 48100
+			refresh = 43200
 			retry = 7200
-			expire = 1209
+			expire = 1209600
 			negcache = 8000
 		}
 	}
@@ -80,12 +80,12 @@ func testAccCheckConstellixPtrConfig_basic(ttl int) string {
 		name = "tempptrrecord"
 		ttl = "%d"
 		roundrobin {
-			value = 13
-			disable_flag = "true"
+			value = "host1.checkptr.com."
+			disable_flag = true
 		}
 		roundrobin {
-			value = 14
-			disable_flag = "false"
+			value = "host2.checkptr.com."
+			disable_flag = false
 		}
 	}
 	`, ttl)
@@ -145,7 +145,7 @@ func ptrfromcontainer(resp *http.Response) (*models.PtrAttributes, error) {
 		log.Println("RR are : ", val)
 		tpMap := make(map[string]interface{})
 		inner := val.(map[string]interface{})
-		tpMap["value"], _ = strconv.Atoi(fmt.Sprintf("%d", inner["value"]))
+		tpMap["value"] = fmt.Sprintf("%v", inner["value"])
 		mapListRR = append(mapListRR, tpMap)
 	}
 	ptr.RoundRobin = mapListRR
@@ -187,3 +187,27 @@ func testAccCheckConstellixPtrAttributes(ttl interface{}, ptr *models.PtrAttribu
 		return nil
 	}
 }
+
+func TestConstellixPtrRecordLifecycle(t *testing.T) {
+	f := newFakeAPI(t)
+	domainID := f.domain("2.0.192.in-addr.arpa")
+
+	ptr := func(ttl int, value string) map[string]interface{} {
+		return map[string]interface{}{
+			"domain_id":   domainID,
+			"source_type": "domains",
+			"name":        "1",
+			"ttl":         ttl,
+			"roundrobin": []interface{}{
+				map[string]interface{}{"value": value, "disable_flag": false},
+			},
+		}
+	}
+	f.lifecycle(lifecycleTestCase{
+		resource:   "constellix_ptr_record",
+		create:     ptr(300, "host1.example.com."),
+		update:     ptr(600, "host2.example.com."),
+		importID:   recordImportID,
+		dataSource: map[string]interface{}{"domain_id": domainID, "source_type": "domains", "name": "1"},
+	})
+}
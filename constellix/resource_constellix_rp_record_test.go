@@ -9,18 +9,18 @@ import (
 	"strconv"
 	"testing"
 
-	"github.com/Constellix/constellix-go-client/client"
 	"github.com/Constellix/constellix-go-client/models"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
-	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/terraform-providers/terraform-provider-constellix/client"
 )
 
 func TestAccRP_Basic(t *testing.T) {
 	var rp models.RPAttributes
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckConstellixRPDestroy,
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckConstellixRPDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCheckConstellixRPConfig_basic(1800),
@@ -37,9 +37,9 @@ func TestAccConstellixRP_Update(t *testing.T) {
 	var rp models.RPAttributes
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckConstellixRPDestroy,
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckConstellixRPDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCheckConstellixRPConfig_basic(1800),
@@ -63,13 +63,13 @@ func testAccCheckConstellixRPConfig_basic(ttl int) string {
 	return fmt.Sprintf(`
 	resource "constellix_domain" "domain1" {
 		name = "checkrp.com"
-		soa = {
+		soa {
 			email = "com.com."
 			primary_nameserver = "ns41.constellix.com."
 			ttl = 1900
-			refresh = 48100
+			refresh = 43200
 			retry = 7200
-			expire = 1209
+			expire = 1209600
 			negcache = 8000
 		}
 	}
@@ -173,3 +173,27 @@ func rpfromcontainer(resp *http.Response) (*models.RPAttributes, error) {
 	return &rp, nil
 
 }
+
+func TestConstellixRPRecordLifecycle(t *testing.T) {
+	f := newFakeAPI(t)
+	domainID := f.domain("example.com")
+
+	rp := func(ttl int, mailbox string) map[string]interface{} {
+		return map[string]interface{}{
+			"domain_id":   domainID,
+			"source_type": "domains",
+			"name":        "www",
+			"ttl":         ttl,
+			"roundrobin": []interface{}{
+				map[string]interface{}{"mailbox": mailbox, "txt": "contact.example.com.", "disable_flag": false},
+			},
+		}
+	}
+	f.lifecycle(lifecycleTestCase{
+		resource:   "constellix_rp_record",
+		create:     rp(300, "admin.example.com."),
+		update:     rp(600, "hostmaster.example.com."),
+		importID:   recordImportID,
+		dataSource: map[string]interface{}{"domain_id": domainID, "source_type": "domains", "name": "www"},
+	})
+}
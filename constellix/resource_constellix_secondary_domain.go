@@ -0,0 +1,214 @@
+package constellix
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/terraform-providers/terraform-provider-constellix/client"
+)
+
+func resourceConstellixSecondaryDomain() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceConstellixSecondaryDomainCreate,
+		ReadContext:   resourceConstellixSecondaryDomainRead,
+		UpdateContext: resourceConstellixSecondaryDomainUpdate,
+		DeleteContext: resourceConstellixSecondaryDomainDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"masters": &schema.Schema{
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"ip": &schema.Schema{
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.IsIPAddress,
+						},
+						"port": &schema.Schema{
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      53,
+							ValidateFunc: validation.IsPortNumber,
+						},
+					},
+				},
+			},
+
+			// The TSIG key is referenced rather than held, so that its
+			// secret stays out of the plan and the state.
+			"tsig_key_id": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+
+			"allow_notify": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.Any(validation.IsIPAddress, validation.IsCIDR),
+				},
+			},
+
+			"note": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"disabled": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"transfer_status": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"last_transfer": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"serial": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// secondaryDomainAttributes returns the attributes of the secondary domain
+// configured in d.
+func secondaryDomainAttributes(d *schema.ResourceData) SecondaryDomainAttributes {
+	model := SecondaryDomainAttributes{
+		Name:        d.Get("name").(string),
+		Masters:     make([]SecondaryDomainMaster, 0),
+		AllowNotify: make([]string, 0),
+		Note:        d.Get("note").(string),
+		Disabled:    d.Get("disabled").(bool),
+	}
+	for _, master := range d.Get("masters").([]interface{}) {
+		master := master.(map[string]interface{})
+		model.Masters = append(model.Masters, SecondaryDomainMaster{
+			IP:   master["ip"].(string),
+			Port: master["port"].(int),
+		})
+	}
+	if keyID := d.Get("tsig_key_id").(int); keyID != 0 {
+		model.TSIGKeyID = &keyID
+	}
+	for _, source := range d.Get("allow_notify").(*schema.Set).List() {
+		model.AllowNotify = append(model.AllowNotify, source.(string))
+	}
+	return model
+}
+
+func resourceConstellixSecondaryDomainCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	constellixClient := m.(*client.Client)
+
+	resp, err := constellixClient.SaveContext(ctx, secondaryDomainAttributes(d), "v1/secondaryDomains")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer resp.Body.Close()
+	bodyBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	var created []map[string]interface{}
+	if err := json.Unmarshal(bodyBytes, &created); err != nil || len(created) == 0 {
+		return diag.Errorf("unexpected response creating secondary domain %s: %s", d.Get("name"), bodyBytes)
+	}
+	d.SetId(fmt.Sprintf("%.0f", created[0]["id"]))
+	return resourceConstellixSecondaryDomainRead(ctx, d, m)
+}
+
+func resourceConstellixSecondaryDomainUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	constellixClient := m.(*client.Client)
+
+	if _, err := constellixClient.UpdatebyIDContext(ctx, secondaryDomainAttributes(d), "v1/secondaryDomains/"+d.Id()); err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceConstellixSecondaryDomainRead(ctx, d, m)
+}
+
+func resourceConstellixSecondaryDomainRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	constellixClient := m.(*client.Client)
+	resp, err := readOrMarkGone(ctx, d, constellixClient, "v1/secondaryDomains/"+d.Id())
+	if err != nil || d.Id() == "" {
+		return diag.FromErr(err)
+	}
+	defer resp.Body.Close()
+	bodyBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	var data map[string]interface{}
+	if err := json.Unmarshal(bodyBytes, &data); err != nil {
+		return diag.Errorf("unexpected secondary domain %s: %s", d.Id(), bodyBytes)
+	}
+
+	masters := make([]interface{}, 0)
+	elems, _ := data["masters"].([]interface{})
+	for _, elem := range elems {
+		master, _ := elem.(map[string]interface{})
+		masters = append(masters, map[string]interface{}{
+			"ip":   toStringValue(master["ip"]),
+			"port": toIntValue(master["port"]),
+		})
+	}
+
+	allowNotify := make([]interface{}, 0)
+	sources, _ := data["allowNotify"].([]interface{})
+	for _, source := range sources {
+		allowNotify = append(allowNotify, toStringValue(source))
+	}
+
+	values := map[string]interface{}{
+		"name":            toStringValue(data["name"]),
+		"masters":         masters,
+		"tsig_key_id":     toIntValue(data["tsigKeyId"]),
+		"allow_notify":    allowNotify,
+		"note":            toStringValue(data["note"]),
+		"disabled":        toBool(data["disabled"]),
+		"transfer_status": toStringValue(data["transferStatus"]),
+		"last_transfer":   toStringValue(data["lastTransfer"]),
+		"serial":          toIntValue(data["serial"]),
+	}
+	for name, value := range values {
+		if err := d.Set(name, value); err != nil {
+			return diag.Errorf("setting %s: %s", name, err)
+		}
+	}
+	return nil
+}
+
+func resourceConstellixSecondaryDomainDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	constellixClient := m.(*client.Client)
+
+	if err := constellixClient.DeletebyIdContext(ctx, "v1/secondaryDomains/"+d.Id()); err != nil && !isNotFound(err) {
+		return diag.FromErr(err)
+	}
+	d.SetId("")
+	return nil
+}
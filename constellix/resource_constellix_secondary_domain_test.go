@@ -0,0 +1,94 @@
+package constellix
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestConstellixSecondaryDomainLifecycle(t *testing.T) {
+	f := newFakeAPI(t)
+
+	f.lifecycle(lifecycleTestCase{
+		resource: "constellix_secondary_domain",
+		create: map[string]interface{}{
+			"name": "hidden.example.com",
+			"masters": []interface{}{
+				map[string]interface{}{"ip": "192.0.2.53"},
+			},
+		},
+		update: map[string]interface{}{
+			"name": "hidden.example.com",
+			"masters": []interface{}{
+				map[string]interface{}{"ip": "192.0.2.53"},
+				map[string]interface{}{"ip": "2001:db8::53", "port": 5353},
+			},
+			"tsig_key_id":  7,
+			"allow_notify": []interface{}{"192.0.2.53", "198.51.100.0/24"},
+			"note":         "hidden primary on BIND",
+			"disabled":     true,
+		},
+	})
+}
+
+func TestConstellixSecondaryDomainTransfer(t *testing.T) {
+	f := newFakeAPI(t)
+	r := f.provider.ResourcesMap["constellix_secondary_domain"]
+	config := map[string]interface{}{
+		"name": "hidden.example.com",
+		"masters": []interface{}{
+			map[string]interface{}{"ip": "192.0.2.53"},
+		},
+		"tsig_key_id": 7,
+	}
+	state := f.apply(r, nil, config)
+	for attr, expected := range map[string]string{
+		"masters.0.port":  "53",
+		"transfer_status": "PENDING",
+		"serial":          "0",
+		"last_transfer":   "",
+	} {
+		if actual := state.Attributes[attr]; actual != expected {
+			t.Errorf("expected %s = %q, got %q", attr, expected, actual)
+		}
+	}
+	stored, _ := f.server.Object("v1/secondaryDomains/" + state.ID)
+	if keyID, _ := stored["tsigKeyId"].(float64); keyID != 7 {
+		t.Errorf("expected TSIG key 7, got %v", stored["tsigKeyId"])
+	}
+	f.checkNoChanges(r, state, config)
+
+	// Removing the key stops signing the transfers.
+	delete(config, "tsig_key_id")
+	state = f.apply(r, state, config)
+	if stored, _ := f.server.Object("v1/secondaryDomains/" + state.ID); stored["tsigKeyId"] != nil {
+		t.Errorf("expected the TSIG key to be removed, got %v", stored["tsigKeyId"])
+	}
+	if keyID := state.Attributes["tsig_key_id"]; keyID != "0" {
+		t.Errorf("expected no TSIG key in state, got %s", keyID)
+	}
+}
+
+func TestConstellixSecondaryDomainValidation(t *testing.T) {
+	r := resourceConstellixSecondaryDomain()
+	master := map[string]interface{}{"ip": "192.0.2.53"}
+	cases := map[string]map[string]interface{}{
+		"no masters":      {"masters": []interface{}{}},
+		"master hostname": {"masters": []interface{}{map[string]interface{}{"ip": "ns1.example.com"}}},
+		"master port":     {"masters": []interface{}{map[string]interface{}{"ip": "192.0.2.53", "port": 70000}}},
+		"tsig key id":     {"tsig_key_id": 0},
+		"notify hostname": {"allow_notify": []interface{}{"ns1.example.com"}},
+	}
+	for name, args := range cases {
+		config := map[string]interface{}{
+			"name":    "hidden.example.com",
+			"masters": []interface{}{master},
+		}
+		for k, v := range args {
+			config[k] = v
+		}
+		if diags := r.Validate(terraform.NewResourceConfigRaw(config)); !diags.HasError() {
+			t.Errorf("%s: expected validation to fail", name)
+		}
+	}
+}
@@ -8,18 +8,18 @@ import (
 	"strconv"
 	"testing"
 
-	"github.com/Constellix/constellix-go-client/client"
 	"github.com/Constellix/constellix-go-client/models"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
-	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/terraform-providers/terraform-provider-constellix/client"
 )
 
 func TestAccSPF_Basic(t *testing.T) {
 	var spf models.SpfAttributes
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckConstellixSPFDestroy,
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckConstellixSPFDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCheckConstellixSPFConfig_basic(1800),
@@ -36,9 +36,9 @@ func TestAccConstellixSPF_Update(t *testing.T) {
 	var spf models.SpfAttributes
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckConstellixSPFDestroy,
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckConstellixSPFDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCheckConstellixSPFConfig_basic(1800),
@@ -62,13 +62,13 @@ func testAccCheckConstellixSPFConfig_basic(ttl int) string {
 	return fmt.Sprintf(`
 	resource "constellix_domain" "domainSPF" {
 		name = "checkspf.com"
-		soa = {
+		soa {
 			email = "com.com."
 			primary_nameserver = "ns41.constellix.com."
 			ttl = 1900
-			refresh = 48100
+			refresh = 43200
 			retry = 7200
-			expire = 1209
+			expire = 1209600
 			negcache = 8000
 		}
 	}
@@ -80,11 +80,11 @@ func testAccCheckConstellixSPFConfig_basic(ttl int) string {
 		ttl = %d
 		roundrobin{
 		  value = "1.2.3.5"
-		  disable_flag = "false"
+		  disable_flag = false
 		}
 		roundrobin{
 		  value = "124.56.8.1"
-		  disable_flag = "true"
+		  disable_flag = true
 		}
 	  }
 	`, ttl)
@@ -175,3 +175,27 @@ func spffromcontainer(resp *http.Response) (*models.SpfAttributes, error) {
 
 	return &model, nil
 }
+
+func TestConstellixSPFRecordLifecycle(t *testing.T) {
+	f := newFakeAPI(t)
+	domainID := f.domain("example.com")
+
+	spf := func(ttl int, value string) map[string]interface{} {
+		return map[string]interface{}{
+			"domain_id":   domainID,
+			"source_type": "domains",
+			"name":        "",
+			"ttl":         ttl,
+			"roundrobin": []interface{}{
+				map[string]interface{}{"value": value, "disable_flag": false},
+			},
+		}
+	}
+	f.lifecycle(lifecycleTestCase{
+		resource:   "constellix_spf_record",
+		create:     spf(300, "v=spf1 mx -all"),
+		update:     spf(600, "v=spf1 mx include:example.net -all"),
+		importID:   recordImportID,
+		dataSource: map[string]interface{}{"domain_id": domainID, "source_type": "domains", "name": ""},
+	})
+}
@@ -9,18 +9,18 @@ import (
 	"strconv"
 	"testing"
 
-	"github.com/Constellix/constellix-go-client/client"
 	"github.com/Constellix/constellix-go-client/models"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
-	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/terraform-providers/terraform-provider-constellix/client"
 )
 
 func TestAccSRV_Basic(t *testing.T) {
 	var srv models.SRVAttributes
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckConstellixSRVDestroy,
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckConstellixSRVDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCheckConstellixSRVConfig_basic(1800),
@@ -36,9 +36,9 @@ func TestAccSRV_Basic(t *testing.T) {
 func TestAccConstellixSRV_Update(t *testing.T) {
 	var model models.SRVAttributes
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckConstellixSRVDestroy,
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckConstellixSRVDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCheckConstellixSRVConfig_basic(1800),
@@ -63,13 +63,13 @@ func testAccCheckConstellixSRVConfig_basic(ttl int) string {
 	return fmt.Sprintf(`
 	resource "constellix_domain" "domain1" {
 		name = "domaintestsrv.com"
-		soa = {
+		soa {
 			email = "com.com."
 			primary_nameserver = "ns41.constellix.com."
 			ttl = 1900
-			refresh = 48100
+			refresh = 43200
 			retry = 7200
-			expire = 1209
+			expire = 1209600
 			negcache = 8000
 		}
 	}
@@ -173,3 +173,27 @@ func srvfromcontainer(resp *http.Response) (*models.SRVAttributes, error) {
 	model.Note = fmt.Sprintf("%v", data["note"])
 	return &model, nil
 }
+
+func TestConstellixSRVRecordLifecycle(t *testing.T) {
+	f := newFakeAPI(t)
+	domainID := f.domain("example.com")
+
+	srv := func(ttl, port int) map[string]interface{} {
+		return map[string]interface{}{
+			"domain_id":   domainID,
+			"source_type": "domains",
+			"name":        "_sip._tcp",
+			"ttl":         ttl,
+			"roundrobin": []interface{}{
+				map[string]interface{}{"value": "sip.example.com.", "port": port, "priority": 10, "weight": 5, "disable_flag": false},
+			},
+		}
+	}
+	f.lifecycle(lifecycleTestCase{
+		resource:   "constellix_srv_record",
+		create:     srv(300, 5060),
+		update:     srv(600, 5061),
+		importID:   recordImportID,
+		dataSource: map[string]interface{}{"domain_id": domainID, "source_type": "domains", "name": "_sip._tcp"},
+	})
+}
@@ -0,0 +1,76 @@
+package constellix
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// sshfpFingerprintLengths are the lengths in hex characters of the
+// fingerprints of SSHFP records by fingerprint type.
+var sshfpFingerprintLengths = map[int]int{
+	1: 40, // SHA-1
+	2: 64, // SHA-256
+}
+
+// sshfpRecord publishes the fingerprints of the SSH host keys of a name
+// (RFC 4255).
+var sshfpRecord = &recordType{
+	path: "sshfp",
+	roundRobin: &recordValues{
+		fields: []recordField{
+			{
+				name: "algorithm",
+				json: "algorithm",
+				schema: &schema.Schema{
+					Type:     schema.TypeInt,
+					Required: true,
+					// RSA, DSA, ECDSA, Ed25519 and Ed448.
+					ValidateFunc: validation.IntInSlice([]int{1, 2, 3, 4, 6}),
+				},
+			},
+			{
+				name: "fingerprint_type",
+				json: "fingerprintType",
+				schema: &schema.Schema{
+					Type:         schema.TypeInt,
+					Required:     true,
+					ValidateFunc: validation.IntBetween(1, 2),
+				},
+			},
+			{
+				name: "fingerprint",
+				json: "fingerprint",
+				schema: &schema.Schema{
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringMatch(hexDigest, "must be hexadecimal"),
+				},
+			},
+			disableFlagField(&schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			}),
+		},
+		validate: validateSSHFP,
+	},
+}
+
+func resourceConstellixSSHFP() *schema.Resource {
+	return sshfpRecord.resource()
+}
+
+func datasourceConstellixSSHFP() *schema.Resource {
+	return sshfpRecord.dataSource()
+}
+
+func validateSSHFP(value map[string]interface{}) error {
+	fingerprintType := value["fingerprint_type"].(int)
+	fingerprint := value["fingerprint"].(string)
+	if length, ok := sshfpFingerprintLengths[fingerprintType]; ok && fingerprint != "" && len(fingerprint) != length {
+		return fmt.Errorf("fingerprint of type %d must be %d hex characters, got %d", fingerprintType, length, len(fingerprint))
+	}
+	return nil
+}
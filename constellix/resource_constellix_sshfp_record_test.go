@@ -0,0 +1,77 @@
+package constellix
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+const testSHA1Digest = "0123456789abcdef0123456789abcdef01234567"
+
+func TestConstellixSSHFPRecordLifecycle(t *testing.T) {
+	f := newFakeAPI(t)
+	domainID := f.domain("example.com")
+
+	sshfp := func(ttl int, algorithms ...int) map[string]interface{} {
+		roundrobin := make([]interface{}, 0, len(algorithms))
+		for _, algorithm := range algorithms {
+			roundrobin = append(roundrobin, map[string]interface{}{
+				"algorithm":        algorithm,
+				"fingerprint_type": 2,
+				"fingerprint":      testSHA256Digest,
+			})
+		}
+		return map[string]interface{}{
+			"domain_id":   domainID,
+			"source_type": "domains",
+			"name":        "host",
+			"ttl":         ttl,
+			"roundrobin":  roundrobin,
+		}
+	}
+	f.lifecycle(lifecycleTestCase{
+		resource:   "constellix_sshfp_record",
+		create:     sshfp(300, 4),
+		update:     sshfp(600, 1, 4),
+		importID:   recordImportID,
+		dataSource: map[string]interface{}{"domain_id": domainID, "source_type": "domains", "name": "host"},
+	})
+}
+
+func TestConstellixSSHFPRecordValidation(t *testing.T) {
+	r := resourceConstellixSSHFP()
+	config := func(value map[string]interface{}) *terraform.ResourceConfig {
+		return terraform.NewResourceConfigRaw(map[string]interface{}{
+			"domain_id":   "1",
+			"source_type": "domains",
+			"ttl":         300,
+			"roundrobin":  []interface{}{value},
+		})
+	}
+
+	cases := map[string]map[string]interface{}{
+		"unknown algorithm":        {"algorithm": 5, "fingerprint_type": 2, "fingerprint": testSHA256Digest},
+		"unknown fingerprint type": {"algorithm": 4, "fingerprint_type": 3, "fingerprint": testSHA256Digest},
+		"fingerprint not hex":      {"algorithm": 4, "fingerprint_type": 2, "fingerprint": "zz"},
+	}
+	for name, value := range cases {
+		if diags := r.Validate(config(value)); !diags.HasError() {
+			t.Errorf("%s: expected validation to fail", name)
+		}
+	}
+
+	valid := map[string]interface{}{"algorithm": 1, "fingerprint_type": 1, "fingerprint": testSHA1Digest}
+	if diags := r.Validate(config(valid)); diags.HasError() {
+		t.Errorf("expected a SHA-1 fingerprint to be valid, got %v", diags)
+	}
+	if _, err := r.Diff(context.Background(), nil, config(valid), nil); err != nil {
+		t.Errorf("expected a SHA-1 fingerprint to be planned, got %s", err)
+	}
+
+	wrongLength := map[string]interface{}{"algorithm": 1, "fingerprint_type": 1, "fingerprint": testSHA256Digest}
+	if _, err := r.Diff(context.Background(), nil, config(wrongLength), nil); err == nil || !strings.Contains(err.Error(), "40 hex characters") {
+		t.Errorf("expected a SHA-256 fingerprint of type 1 to be refused, got %v", err)
+	}
+}
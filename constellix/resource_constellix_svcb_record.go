@@ -0,0 +1,180 @@
+package constellix
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// svcbTarget matches the target of a service binding: "." for the owner name
+// of the record, or a hostname.
+var svcbTarget = regexp.MustCompile(`^(\.|([a-zA-Z0-9_]([a-zA-Z0-9_-]{0,61}[a-zA-Z0-9_])?\.)*[a-zA-Z0-9_]([a-zA-Z0-9_-]{0,61}[a-zA-Z0-9_])?\.?)$`)
+
+// alpnID matches an ALPN protocol ID as presented in zone files.
+var alpnID = regexp.MustCompile(`^[\x21-\x2b\x2d-\x7e]{1,255}$`)
+
+// svcbRecord binds a service to the endpoints serving it (RFC 9460).
+var svcbRecord = &recordType{
+	path:       "svcb",
+	roundRobin: serviceBindingValues(),
+}
+
+func resourceConstellixSVCB() *schema.Resource {
+	return svcbRecord.resource()
+}
+
+func datasourceConstellixSVCB() *schema.Resource {
+	return svcbRecord.dataSource()
+}
+
+// serviceBindingValues describes the values of SVCB records and of HTTPS
+// records, which are SVCB records for HTTP origins.
+func serviceBindingValues() *recordValues {
+	return &recordValues{
+		fields: []recordField{
+			{
+				name: "priority",
+				json: "priority",
+				schema: &schema.Schema{
+					Type:         schema.TypeInt,
+					Required:     true,
+					ValidateFunc: validation.IntBetween(0, 65535),
+				},
+			},
+			{
+				name: "target",
+				json: "target",
+				schema: &schema.Schema{
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringMatch(svcbTarget, `must be "." or a hostname`),
+				},
+			},
+			{
+				name: "svc_params",
+				json: "svcParams",
+				schema: &schema.Schema{
+					Type:     schema.TypeList,
+					Optional: true,
+					MaxItems: 1,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"alpn": &schema.Schema{
+								Type:     schema.TypeList,
+								Optional: true,
+								Elem: &schema.Schema{
+									Type:         schema.TypeString,
+									ValidateFunc: validation.StringMatch(alpnID, "must be an ALPN protocol ID"),
+								},
+							},
+							"port": &schema.Schema{
+								Type:         schema.TypeInt,
+								Optional:     true,
+								ValidateFunc: validation.IntBetween(1, 65535),
+							},
+							"ipv4hint": &schema.Schema{
+								Type:     schema.TypeList,
+								Optional: true,
+								Elem: &schema.Schema{
+									Type:         schema.TypeString,
+									ValidateFunc: validation.IsIPv4Address,
+								},
+							},
+							"ipv6hint": &schema.Schema{
+								Type:     schema.TypeList,
+								Optional: true,
+								Elem: &schema.Schema{
+									Type:         schema.TypeString,
+									ValidateFunc: validateIPv6Address,
+								},
+							},
+							"ech": &schema.Schema{
+								Type:         schema.TypeString,
+								Optional:     true,
+								ValidateFunc: validation.StringIsBase64,
+							},
+						},
+					},
+				},
+				toAPI:   toSvcParams,
+				fromAPI: fromSvcParams,
+			},
+			disableFlagField(&schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			}),
+		},
+		validate: validateServiceBinding,
+	}
+}
+
+// validateIPv6Address refuses IPv4 addresses, which validation.IsIPv6Address
+// accepts.
+func validateIPv6Address(i interface{}, k string) (warnings []string, errors []error) {
+	v, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+	if ip := net.ParseIP(v); ip == nil || ip.To4() != nil {
+		return nil, []error{fmt.Errorf("expected %s to contain a valid IPv6 address, got: %s", k, v)}
+	}
+	return nil, nil
+}
+
+// svcParamLists are the SvcParams holding lists.
+var svcParamLists = []string{"alpn", "ipv4hint", "ipv6hint"}
+
+func toSvcParams(value interface{}) interface{} {
+	params := make(map[string]interface{})
+	configured, _ := value.([]interface{})
+	if len(configured) == 0 || configured[0] == nil {
+		return params
+	}
+	inner := configured[0].(map[string]interface{})
+	for _, name := range svcParamLists {
+		if list, ok := inner[name].([]interface{}); ok && len(list) > 0 {
+			params[name] = toListOfString(list)
+		}
+	}
+	if port := toIntValue(inner["port"]); port != 0 {
+		params["port"] = port
+	}
+	if ech := toStringValue(inner["ech"]); ech != "" {
+		params["ech"] = ech
+	}
+	return params
+}
+
+func fromSvcParams(value interface{}) interface{} {
+	answered, _ := value.(map[string]interface{})
+	if len(answered) == 0 {
+		return []interface{}{}
+	}
+	params := map[string]interface{}{
+		"port": toIntValue(answered["port"]),
+		"ech":  toStringValue(answered["ech"]),
+	}
+	for _, name := range svcParamLists {
+		elems, _ := answered[name].([]interface{})
+		list := make([]interface{}, 0, len(elems))
+		for _, elem := range elems {
+			list = append(list, toStringValue(elem))
+		}
+		params[name] = list
+	}
+	return []interface{}{params}
+}
+
+// validateServiceBinding checks that aliases, the values with priority 0,
+// have no SvcParams (RFC 9460, section 2.4.2).
+func validateServiceBinding(value map[string]interface{}) error {
+	params, _ := value["svc_params"].([]interface{})
+	if value["priority"].(int) == 0 && len(toSvcParams(params).(map[string]interface{})) > 0 {
+		return fmt.Errorf("a value with priority 0 is an alias and cannot have svc_params")
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package constellix
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestConstellixSVCBRecordLifecycle(t *testing.T) {
+	f := newFakeAPI(t)
+	domainID := f.domain("example.com")
+
+	svcb := func(ttl int, values ...interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"domain_id":   domainID,
+			"source_type": "domains",
+			"name":        "_dns",
+			"ttl":         ttl,
+			"roundrobin":  values,
+		}
+	}
+	alias := map[string]interface{}{"priority": 0, "target": "dns.example.net."}
+	doh := map[string]interface{}{
+		"priority": 1,
+		"target":   "dns.example.net.",
+		"svc_params": []interface{}{map[string]interface{}{
+			"alpn": []interface{}{"h2"},
+			"port": 443,
+		}},
+	}
+	f.lifecycle(lifecycleTestCase{
+		resource:   "constellix_svcb_record",
+		create:     svcb(300, alias),
+		update:     svcb(600, doh),
+		importID:   recordImportID,
+		dataSource: map[string]interface{}{"domain_id": domainID, "source_type": "domains", "name": "_dns"},
+	})
+}
+
+func TestConstellixSVCBRecordValidation(t *testing.T) {
+	r := resourceConstellixSVCB()
+	config := func(value map[string]interface{}) *terraform.ResourceConfig {
+		return terraform.NewResourceConfigRaw(map[string]interface{}{
+			"domain_id":   "1",
+			"source_type": "domains",
+			"ttl":         300,
+			"roundrobin":  []interface{}{value},
+		})
+	}
+	params := func(p map[string]interface{}) map[string]interface{} {
+		return map[string]interface{}{"priority": 1, "target": "svc.example.com.", "svc_params": []interface{}{p}}
+	}
+
+	cases := map[string]map[string]interface{}{
+		"priority out of range": {"priority": 65536, "target": "."},
+		"malformed target":      {"priority": 1, "target": "bad target"},
+		"empty alpn":            params(map[string]interface{}{"alpn": []interface{}{""}}),
+		"alpn with comma":       params(map[string]interface{}{"alpn": []interface{}{"h2,h3"}}),
+		"port out of range":     params(map[string]interface{}{"port": 70000}),
+		"ipv6 in ipv4hint":      params(map[string]interface{}{"ipv4hint": []interface{}{"2001:db8::1"}}),
+		"ipv4 in ipv6hint":      params(map[string]interface{}{"ipv6hint": []interface{}{"192.0.2.1"}}),
+		"ech not base64":        params(map[string]interface{}{"ech": "not base64!"}),
+	}
+	for name, value := range cases {
+		if diags := r.Validate(config(value)); !diags.HasError() {
+			t.Errorf("%s: expected validation to fail", name)
+		}
+	}
+
+	valid := params(map[string]interface{}{
+		"alpn":     []interface{}{"h3", "h2"},
+		"port":     8443,
+		"ipv4hint": []interface{}{"192.0.2.1"},
+		"ipv6hint": []interface{}{"2001:db8::1"},
+		"ech":      "AEX+DQBBpQAgACBvCZSk",
+	})
+	if diags := r.Validate(config(valid)); diags.HasError() {
+		t.Errorf("expected all SvcParams to be valid, got %v", diags)
+	}
+
+	f := newFakeAPI(t)
+	alias := map[string]interface{}{
+		"domain_id":   f.domain("example.com"),
+		"source_type": "domains",
+		"ttl":         300,
+		"roundrobin": []interface{}{map[string]interface{}{
+			"priority":   0,
+			"target":     "svc.example.com.",
+			"svc_params": []interface{}{map[string]interface{}{"alpn": []interface{}{"h2"}}},
+		}},
+	}
+	diff, err := r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(alias), f.client)
+	if err == nil {
+		_, diags := r.Apply(context.Background(), nil, diff, f.client)
+		if !diags.HasError() || !strings.Contains(diags[0].Summary, "alias") {
+			t.Errorf("expected an alias with SvcParams to be refused, got %v", diags)
+		}
+	} else if !strings.Contains(err.Error(), "alias") {
+		t.Errorf("expected an alias with SvcParams to be refused, got %s", err)
+	}
+	for _, request := range f.server.Requests() {
+		if strings.HasPrefix(request, "POST v1/domains/") && strings.Contains(request, "/records/") {
+			t.Errorf("expected no record to be sent to the API, got %s", request)
+		}
+	}
+}
@@ -1,25 +1,27 @@
 package constellix
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"log"
 
-	"github.com/Constellix/constellix-go-client/client"
 	"github.com/Constellix/constellix-go-client/models"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/terraform-providers/terraform-provider-constellix/client"
 )
 
 func resourceConstellixTags() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceConstellixTagsCreate,
-		Update: resourceConstellixTagsUpdate,
-		Read:   resourceConstellixTagsRead,
-		Delete: resourceConstellixTagsDelete,
+		CreateContext: resourceConstellixTagsCreate,
+		UpdateContext: resourceConstellixTagsUpdate,
+		ReadContext:   resourceConstellixTagsRead,
+		DeleteContext: resourceConstellixTagsDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: resourceConstellixTagsImport,
+			StateContext: resourceConstellixTagsImport,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -31,16 +33,12 @@ func resourceConstellixTags() *schema.Resource {
 	}
 }
 
-func resourceConstellixTagsImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+func resourceConstellixTagsImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
 	log.Printf("[DEBUG] %s: Beginning Import", d.Id())
 	constellixClient := m.(*client.Client)
 	dn := d.Id()
-	resp, err := constellixClient.GetbyId("v2/tags/" + dn)
+	resp, err := constellixClient.GetbyIdContext(ctx, "v2/tags/"+dn)
 	if err != nil {
-		if resp.StatusCode == 404 {
-			d.SetId("")
-			return nil, err
-		}
 		return nil, err
 	}
 	bodybytes, err := ioutil.ReadAll(resp.Body)
@@ -57,7 +55,7 @@ func resourceConstellixTagsImport(d *schema.ResourceData, m interface{}) ([]*sch
 	return []*schema.ResourceData{d}, nil
 }
 
-func resourceConstellixTagsCreate(d *schema.ResourceData, m interface{}) error {
+func resourceConstellixTagsCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*client.Client)
 
 	model := models.Tags{}
@@ -66,14 +64,14 @@ func resourceConstellixTagsCreate(d *schema.ResourceData, m interface{}) error {
 		model.Name = name.(string)
 	}
 
-	resp, err := client.Save(model, "v2/tags")
+	resp, err := client.SaveContext(ctx, model, "v2/tags")
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	bodybtes, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	bodystring := string(bodybtes)
 	var data map[string]interface{}
@@ -86,10 +84,10 @@ func resourceConstellixTagsCreate(d *schema.ResourceData, m interface{}) error {
 	log.Println("Struct for Hinfo :", idStruct["id"])
 
 	d.SetId(fmt.Sprintf("%.0f", idStruct["id"]))
-	return resourceConstellixTagsRead(d, m)
+	return resourceConstellixTagsRead(ctx, d, m)
 }
 
-func resourceConstellixTagsUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceConstellixTagsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*client.Client)
 
 	model := models.Tags{}
@@ -100,27 +98,23 @@ func resourceConstellixTagsUpdate(d *schema.ResourceData, m interface{}) error {
 
 	dn := d.Id()
 
-	_, err := client.UpdatebyID(model, "v2/tags/"+dn)
+	_, err := client.UpdatebyIDContext(ctx, model, "v2/tags/"+dn)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	return resourceConstellixTagsRead(d, m)
+	return resourceConstellixTagsRead(ctx, d, m)
 }
 
-func resourceConstellixTagsRead(d *schema.ResourceData, m interface{}) error {
+func resourceConstellixTagsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*client.Client)
 	dn := d.Id()
-	resp, err := client.GetbyId("v2/tags/" + dn)
-	if err != nil {
-		if resp.StatusCode == 404 {
-			d.SetId("")
-			return nil
-		}
-		return err
+	resp, err := readOrMarkGone(ctx, d, client, "v2/tags/"+dn)
+	if err != nil || d.Id() == "" {
+		return diag.FromErr(err)
 	}
 	bodybytes, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	bodystring := string(bodybytes)
 
@@ -131,13 +125,13 @@ func resourceConstellixTagsRead(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceConstellixTagsDelete(d *schema.ResourceData, m interface{}) error {
+func resourceConstellixTagsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*client.Client)
 	dn := d.Id()
 
-	err := client.DeletebyId("v2/tags/" + dn)
+	err := client.DeletebyIdContext(ctx, "v2/tags/"+dn)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	d.SetId("")
 	return nil
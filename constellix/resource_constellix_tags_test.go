@@ -7,18 +7,18 @@ import (
 	"net/http"
 	"testing"
 
-	"github.com/Constellix/constellix-go-client/client"
 	"github.com/Constellix/constellix-go-client/models"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
-	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/terraform-providers/terraform-provider-constellix/client"
 )
 
 func TestAccTags_Basic(t *testing.T) {
 	var tags models.Tags
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckConstellixTagsDestroy,
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckConstellixTagsDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCheckConstellixTagsConfig_basic("checkTag"),
@@ -35,9 +35,9 @@ func TestAccConstellixTags_Update(t *testing.T) {
 	var tags models.Tags
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckConstellixTagsDestroy,
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckConstellixTagsDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCheckConstellixTagsConfig_basic("Name1"),
@@ -132,3 +132,14 @@ func tagsfromcontainer(resp *http.Response) (*models.Tags, error) {
 
 	return &model, nil
 }
+
+func TestConstellixTagsLifecycle(t *testing.T) {
+	f := newFakeAPI(t)
+
+	f.lifecycle(lifecycleTestCase{
+		resource:   "constellix_tags",
+		create:     map[string]interface{}{"name": "staging"},
+		update:     map[string]interface{}{"name": "production"},
+		dataSource: map[string]interface{}{"name": "production"},
+	})
+}
@@ -1,27 +1,29 @@
 package constellix
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"log"
 	"strings"
 
-	"github.com/Constellix/constellix-go-client/client"
 	"github.com/Constellix/constellix-go-client/models"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/terraform-providers/terraform-provider-constellix/client"
 )
 
 func resourceConstellixTCPCheck() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceConstellixTCPCheckCreate,
-		Update: resourceConstellixTCPCheckUpdate,
-		Read:   resourceConstellixTCPCheckRead,
-		Delete: resourceConstellixTCPCheckDelete,
+		CreateContext: resourceConstellixTCPCheckCreate,
+		UpdateContext: resourceConstellixTCPCheckUpdate,
+		ReadContext:   resourceConstellixTCPCheckRead,
+		DeleteContext: resourceConstellixTCPCheckDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: resourceConstellixTCPCheckImport,
+			StateContext: resourceConstellixTCPCheckImport,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -115,12 +117,12 @@ func resourceConstellixTCPCheck() *schema.Resource {
 	}
 }
 
-func resourceConstellixTCPCheckImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+func resourceConstellixTCPCheckImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
 	log.Printf("[DEBUG] %s: Beginning Import", d.Id())
 	constellixClient := m.(*client.Client)
 	dn := d.Id()
 
-	resp, err := constellixClient.GetbyId("https://api.sonar.constellix.com/rest/api/tcp/" + dn)
+	resp, err := constellixClient.GetbyIdContext(ctx, constellixClient.SonarEndpoint("rest/api/tcp/")+dn)
 	if err != nil {
 		return nil, err
 	}
@@ -148,7 +150,7 @@ func resourceConstellixTCPCheckImport(d *schema.ResourceData, m interface{}) ([]
 	return []*schema.ResourceData{d}, nil
 }
 
-func resourceConstellixTCPCheckCreate(d *schema.ResourceData, m interface{}) error {
+func resourceConstellixTCPCheckCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 
 	client := m.(*client.Client)
 
@@ -201,9 +203,9 @@ func resourceConstellixTCPCheckCreate(d *schema.ResourceData, m interface{}) err
 		tcpcheckAttr.StringToReceive = string_to_receive.(string)
 	}
 
-	resp, err := client.Save(tcpcheckAttr, "https://api.sonar.constellix.com/rest/api/tcp")
+	resp, err := client.SaveContext(ctx, tcpcheckAttr, client.SonarEndpoint("rest/api/tcp"))
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	var location string
@@ -215,15 +217,15 @@ func resourceConstellixTCPCheckCreate(d *schema.ResourceData, m interface{}) err
 		}
 	}
 	if flag == false {
-		return fmt.Errorf("Response contains empty location value")
+		return diag.Errorf("Response contains empty location value")
 	}
 
 	locArr := strings.Split(location, "/")
 	d.SetId(locArr[len(locArr)-1])
-	return resourceConstellixTCPCheckRead(d, m)
+	return resourceConstellixTCPCheckRead(ctx, d, m)
 }
 
-func resourceConstellixTCPCheckUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceConstellixTCPCheckUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*client.Client)
 
 	tcpcheckAttr := models.TCPCheckAttributes{}
@@ -268,24 +270,24 @@ func resourceConstellixTCPCheckUpdate(d *schema.ResourceData, m interface{}) err
 	}
 
 	dn := d.Id()
-	_, err := client.UpdatebyID(tcpcheckAttr, "https://api.sonar.constellix.com/rest/api/tcp/"+dn)
+	_, err := client.UpdatebyIDContext(ctx, tcpcheckAttr, client.SonarEndpoint("rest/api/tcp/")+dn)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	return resourceConstellixTCPCheckRead(d, m)
+	return resourceConstellixTCPCheckRead(ctx, d, m)
 }
 
-func resourceConstellixTCPCheckRead(d *schema.ResourceData, m interface{}) error {
+func resourceConstellixTCPCheckRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*client.Client)
 	dn := d.Id()
 
-	resp, err := client.GetbyId("https://api.sonar.constellix.com/rest/api/tcp/" + dn)
-	if err != nil {
-		return err
+	resp, err := readOrMarkGone(ctx, d, client, client.SonarEndpoint("rest/api/tcp/")+dn)
+	if err != nil || d.Id() == "" {
+		return diag.FromErr(err)
 	}
 	bodybytes, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	bodystring := string(bodybytes)
 
@@ -306,13 +308,13 @@ func resourceConstellixTCPCheckRead(d *schema.ResourceData, m interface{}) error
 	return nil
 }
 
-func resourceConstellixTCPCheckDelete(d *schema.ResourceData, m interface{}) error {
+func resourceConstellixTCPCheckDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*client.Client)
 	dn := d.Id()
 
-	err := client.DeletebyId("https://api.sonar.constellix.com/rest/api/tcp/" + dn)
+	err := client.DeletebyIdContext(ctx, client.SonarEndpoint("rest/api/tcp/")+dn)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	d.SetId("")
 	return nil
@@ -0,0 +1,24 @@
+package constellix
+
+import "testing"
+
+func TestConstellixTCPCheckLifecycle(t *testing.T) {
+	f := newFakeAPI(t)
+
+	check := func(interval string) map[string]interface{} {
+		return map[string]interface{}{
+			"name":        "smtp",
+			"host":        "mail.example.com",
+			"ip_version":  "IPV4",
+			"port":        25,
+			"check_sites": []interface{}{1, 2},
+			"interval":    interval,
+		}
+	}
+	f.lifecycle(lifecycleTestCase{
+		resource:   "constellix_tcp_check",
+		create:     check("ONEMINUTE"),
+		update:     check("FIVEMINUTES"),
+		dataSource: map[string]interface{}{"name": "smtp"},
+	})
+}
@@ -1,25 +1,27 @@
 package constellix
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"log"
 
-	"github.com/Constellix/constellix-go-client/client"
 	"github.com/Constellix/constellix-go-client/models"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/terraform-providers/terraform-provider-constellix/client"
 )
 
 func resourceConstellixTemplate() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceConstellixTemplateCreate,
-		Read:   resourceConstellixTemplateRead,
-		Update: resourceConstellixTemplateUpdate,
-		Delete: resourceConstellixTemplateDelete,
+		CreateContext: resourceConstellixTemplateCreate,
+		ReadContext:   resourceConstellixTemplateRead,
+		UpdateContext: resourceConstellixTemplateUpdate,
+		DeleteContext: resourceConstellixTemplateDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: resourceConstellixTemplateImport,
+			StateContext: resourceConstellixTemplateImport,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -53,16 +55,12 @@ func resourceConstellixTemplate() *schema.Resource {
 	}
 }
 
-func resourceConstellixTemplateImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+func resourceConstellixTemplateImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
 	log.Printf("[DEBUG] %s: Beginning Import", d.Id())
 	constellixClient := m.(*client.Client)
 	dn := d.Id()
-	resp, err := constellixClient.GetbyId("v1/templates/" + dn)
+	resp, err := constellixClient.GetbyIdContext(ctx, "v1/templates/"+dn)
 	if err != nil {
-		if resp.StatusCode == 404 {
-			d.SetId("")
-			return nil, err
-		}
 		return nil, err
 	}
 	bodyBytes, err := ioutil.ReadAll(resp.Body)
@@ -82,7 +80,7 @@ func resourceConstellixTemplateImport(d *schema.ResourceData, m interface{}) ([]
 	return []*schema.ResourceData{d}, nil
 }
 
-func resourceConstellixTemplateCreate(d *schema.ResourceData, m interface{}) error {
+func resourceConstellixTemplateCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	constellixConnect := m.(*client.Client)
 
 	templateAttr := models.TemplateAttributes{}
@@ -104,36 +102,32 @@ func resourceConstellixTemplateCreate(d *schema.ResourceData, m interface{}) err
 		templateAttr.Version = version.(int)
 	}
 
-	resp, err := constellixConnect.Save(templateAttr, "v1/templates/")
+	resp, err := constellixConnect.SaveContext(ctx, templateAttr, "v1/templates/")
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	bodyBytes, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	bodyString := string(bodyBytes)
 	var data map[string]interface{}
 	json.Unmarshal([]byte(bodyString[1:len(bodyString)-1]), &data)
 	d.SetId(fmt.Sprintf("%.0f", data["id"]))
-	return resourceConstellixTemplateRead(d, m)
+	return resourceConstellixTemplateRead(ctx, d, m)
 }
 
-func resourceConstellixTemplateRead(d *schema.ResourceData, m interface{}) error {
+func resourceConstellixTemplateRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	constellixclient := m.(*client.Client)
 	dn := d.Id()
-	resp, err := constellixclient.GetbyId("v1/templates/" + dn)
-	if err != nil {
-		if resp.StatusCode == 404 {
-			d.SetId("")
-			return nil
-		}
-		return err
+	resp, err := readOrMarkGone(ctx, d, constellixclient, "v1/templates/"+dn)
+	if err != nil || d.Id() == "" {
+		return diag.FromErr(err)
 	}
 	bodyBytes, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	bodyString := string(bodyBytes)
 	var data map[string]interface{}
@@ -149,7 +143,7 @@ func resourceConstellixTemplateRead(d *schema.ResourceData, m interface{}) error
 
 }
 
-func resourceConstellixTemplateUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceConstellixTemplateUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	constellixClient := m.(*client.Client)
 
 	templateAttr := models.TemplateAttributes{}
@@ -172,22 +166,22 @@ func resourceConstellixTemplateUpdate(d *schema.ResourceData, m interface{}) err
 
 	dn := d.Id()
 
-	_, err := constellixClient.UpdatebyID(templateAttr, "v1/templates/"+dn)
+	_, err := constellixClient.UpdatebyIDContext(ctx, templateAttr, "v1/templates/"+dn)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	return resourceConstellixTemplateRead(d, m)
+	return resourceConstellixTemplateRead(ctx, d, m)
 }
 
-func resourceConstellixTemplateDelete(d *schema.ResourceData, m interface{}) error {
+func resourceConstellixTemplateDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	constellixConnect := m.(*client.Client)
 
 	dn := d.Id()
 
-	err := constellixConnect.DeletebyId("v1/templates/" + dn)
+	err := constellixConnect.DeletebyIdContext(ctx, "v1/templates/"+dn)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	d.SetId("")
-	return err
+	return diag.FromErr(err)
 }
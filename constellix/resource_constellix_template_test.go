@@ -8,18 +8,18 @@ import (
 	"strconv"
 	"testing"
 
-	"github.com/Constellix/constellix-go-client/client"
 	"github.com/Constellix/constellix-go-client/models"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
-	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/terraform-providers/terraform-provider-constellix/client"
 )
 
 func TestAccTemplate_Basic(t *testing.T) {
 	var template models.TemplateAttributes
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckConstellixTemplateDestroy,
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckConstellixTemplateDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCheckConstellixTemplateConfig_basic("checktemplate"),
@@ -36,9 +36,9 @@ func TestAccConstellixTemplate_Update(t *testing.T) {
 	var template models.TemplateAttributes
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckConstellixTemplateDestroy,
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckConstellixTemplateDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCheckConstellixTemplateConfig_basic("checkone"),
@@ -146,3 +146,21 @@ func testAccCheckConstellixTemplateAttributes(name string, template *models.Temp
 		return nil
 	}
 }
+
+func TestConstellixTemplateLifecycle(t *testing.T) {
+	f := newFakeAPI(t)
+
+	template := func(hasGeoIP bool) map[string]interface{} {
+		return map[string]interface{}{
+			"name":            "standard",
+			"has_geoip":       hasGeoIP,
+			"has_gtd_regions": false,
+		}
+	}
+	f.lifecycle(lifecycleTestCase{
+		resource:   "constellix_template",
+		create:     template(false),
+		update:     template(true),
+		dataSource: map[string]interface{}{"name": "standard"},
+	})
+}
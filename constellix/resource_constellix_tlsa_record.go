@@ -0,0 +1,89 @@
+package constellix
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// hexDigest matches a non-empty string of hexadecimal octets.
+var hexDigest = regexp.MustCompile(`^([0-9a-fA-F]{2})+$`)
+
+// tlsaDigestLengths are the lengths in hex characters of the certificate data
+// of TLSA records by matching type. Matching type 0 holds the full
+// certificate or key, of any length.
+var tlsaDigestLengths = map[int]int{
+	1: 64,  // SHA-256
+	2: 128, // SHA-512
+}
+
+// tlsaRecord associates certificates or public keys with a service for DANE
+// (RFC 6698).
+var tlsaRecord = &recordType{
+	path: "tlsa",
+	roundRobin: &recordValues{
+		fields: []recordField{
+			{
+				name: "usage",
+				json: "usage",
+				schema: &schema.Schema{
+					Type:         schema.TypeInt,
+					Required:     true,
+					ValidateFunc: validation.IntBetween(0, 3),
+				},
+			},
+			{
+				name: "selector",
+				json: "selector",
+				schema: &schema.Schema{
+					Type:         schema.TypeInt,
+					Required:     true,
+					ValidateFunc: validation.IntBetween(0, 1),
+				},
+			},
+			{
+				name: "matching_type",
+				json: "matchingType",
+				schema: &schema.Schema{
+					Type:         schema.TypeInt,
+					Required:     true,
+					ValidateFunc: validation.IntBetween(0, 2),
+				},
+			},
+			{
+				name: "certificate",
+				json: "certificate",
+				schema: &schema.Schema{
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringMatch(hexDigest, "must be hexadecimal"),
+				},
+			},
+			disableFlagField(&schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			}),
+		},
+		validate: validateTLSA,
+	},
+}
+
+func resourceConstellixTLSA() *schema.Resource {
+	return tlsaRecord.resource()
+}
+
+func datasourceConstellixTLSA() *schema.Resource {
+	return tlsaRecord.dataSource()
+}
+
+func validateTLSA(value map[string]interface{}) error {
+	matchingType := value["matching_type"].(int)
+	certificate := value["certificate"].(string)
+	if length, ok := tlsaDigestLengths[matchingType]; ok && certificate != "" && len(certificate) != length {
+		return fmt.Errorf("certificate of matching type %d must be %d hex characters, got %d", matchingType, length, len(certificate))
+	}
+	return nil
+}
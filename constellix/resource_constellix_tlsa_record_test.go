@@ -0,0 +1,83 @@
+package constellix
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+const (
+	testSHA256Digest = "8f2f3b1c5ac2b4d1a1a3e2b7f3e4c9d0a5b6c7d8e9f0a1b2c3d4e5f6a7b8c9d0"
+	testSHA512Digest = testSHA256Digest + testSHA256Digest
+)
+
+func TestConstellixTLSARecordLifecycle(t *testing.T) {
+	f := newFakeAPI(t)
+	domainID := f.domain("example.com")
+
+	tlsa := func(ttl int, digests ...string) map[string]interface{} {
+		roundrobin := make([]interface{}, 0, len(digests))
+		for _, digest := range digests {
+			roundrobin = append(roundrobin, map[string]interface{}{
+				"usage":         3,
+				"selector":      1,
+				"matching_type": len(digest) / 64,
+				"certificate":   digest,
+			})
+		}
+		return map[string]interface{}{
+			"domain_id":   domainID,
+			"source_type": "domains",
+			"name":        "_443._tcp.www",
+			"ttl":         ttl,
+			"roundrobin":  roundrobin,
+		}
+	}
+	f.lifecycle(lifecycleTestCase{
+		resource:   "constellix_tlsa_record",
+		create:     tlsa(300, testSHA256Digest),
+		update:     tlsa(600, testSHA256Digest, testSHA512Digest),
+		importID:   recordImportID,
+		dataSource: map[string]interface{}{"domain_id": domainID, "source_type": "domains", "name": "_443._tcp.www"},
+	})
+}
+
+func TestConstellixTLSARecordValidation(t *testing.T) {
+	r := resourceConstellixTLSA()
+	config := func(value map[string]interface{}) *terraform.ResourceConfig {
+		return terraform.NewResourceConfigRaw(map[string]interface{}{
+			"domain_id":   "1",
+			"source_type": "domains",
+			"ttl":         300,
+			"roundrobin":  []interface{}{value},
+		})
+	}
+
+	cases := map[string]map[string]interface{}{
+		"usage out of range":         {"usage": 4, "selector": 1, "matching_type": 1, "certificate": testSHA256Digest},
+		"selector out of range":      {"usage": 3, "selector": 2, "matching_type": 1, "certificate": testSHA256Digest},
+		"matching type out of range": {"usage": 3, "selector": 1, "matching_type": 3, "certificate": testSHA256Digest},
+		"digest not hex":             {"usage": 3, "selector": 1, "matching_type": 0, "certificate": "not-hex"},
+		"digest of odd length":       {"usage": 3, "selector": 1, "matching_type": 0, "certificate": "abc"},
+	}
+	for name, value := range cases {
+		if diags := r.Validate(config(value)); !diags.HasError() {
+			t.Errorf("%s: expected validation to fail", name)
+		}
+	}
+
+	valid := map[string]interface{}{"usage": 3, "selector": 1, "matching_type": 0, "certificate": "3082010a"}
+	if diags := r.Validate(config(valid)); diags.HasError() {
+		t.Errorf("expected full certificate data of any length to be valid, got %v", diags)
+	}
+
+	for matchingType, digest := range map[int]string{1: testSHA512Digest, 2: testSHA256Digest} {
+		value := map[string]interface{}{"usage": 3, "selector": 1, "matching_type": matchingType, "certificate": digest}
+		_, err := r.Diff(context.Background(), nil, config(value), nil)
+		if err == nil || !strings.Contains(err.Error(), "hex characters") {
+			t.Errorf("matching type %d: expected a digest of the wrong length to be refused, got %v", matchingType, err)
+		}
+	}
+}
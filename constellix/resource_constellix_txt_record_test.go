@@ -9,18 +9,18 @@ import (
 	"strconv"
 	"testing"
 
-	"github.com/Constellix/constellix-go-client/client"
 	"github.com/Constellix/constellix-go-client/models"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
-	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/terraform-providers/terraform-provider-constellix/client"
 )
 
 func TestAccTxt_Basic(t *testing.T) {
 	var tx models.TxtAttributes
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckConstellixTxtDestroy,
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckConstellixTxtDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCheckConstellixTxtConfig_basic(1800),
@@ -37,9 +37,9 @@ func TestAccConstellixTxt_Update(t *testing.T) {
 	var tx models.TxtAttributes
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckConstellixTxtDestroy,
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckConstellixTxtDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCheckConstellixTxtConfig_basic(1800),
@@ -63,13 +63,13 @@ func testAccCheckConstellixTxtConfig_basic(ttl int) string {
 	return fmt.Sprintf(`
 	resource "constellix_domain" "domain1" {
 		name = "checktxt.com"
-		soa = {
+		soa {
 			email = "com.com."
 			primary_nameserver = "ns41.constellix.com."
 			ttl = 1900
-			refresh = 48100
+			refresh = 43200
 			retry = 7200
-			expire = 1209
+			expire = 1209600
 			negcache = 8000
 		}
 	}
@@ -81,11 +81,11 @@ func testAccCheckConstellixTxtConfig_basic(ttl int) string {
 		ttl = "%d"
 		roundrobin {
 			value = "mail.com."
-			disable_flag = "true"
+			disable_flag = true
 		}
 		roundrobin {
 			value = "google.com."
-			disable_flag = "false"
+			disable_flag = false
 		}
 	}
 	`, ttl)
@@ -186,3 +186,29 @@ func testAccCheckConstellixTxtAttributes(ttl interface{}, tx *models.TxtAttribut
 		return nil
 	}
 }
+
+func TestConstellixTxtRecordLifecycle(t *testing.T) {
+	f := newFakeAPI(t)
+	domainID := f.domain("example.com")
+
+	txt := func(ttl int, values ...string) map[string]interface{} {
+		roundrobin := make([]interface{}, 0, len(values))
+		for _, value := range values {
+			roundrobin = append(roundrobin, map[string]interface{}{"value": value, "disable_flag": false})
+		}
+		return map[string]interface{}{
+			"domain_id":   domainID,
+			"source_type": "domains",
+			"name":        "_verify",
+			"ttl":         ttl,
+			"roundrobin":  roundrobin,
+		}
+	}
+	f.lifecycle(lifecycleTestCase{
+		resource:   "constellix_txt_record",
+		create:     txt(300, "token=abc"),
+		update:     txt(600, "token=abc", "token=def"),
+		importID:   recordImportID,
+		dataSource: map[string]interface{}{"domain_id": domainID, "source_type": "domains", "name": "_verify"},
+	})
+}
@@ -1,25 +1,27 @@
 package constellix
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"log"
 
-	"github.com/Constellix/constellix-go-client/client"
 	"github.com/Constellix/constellix-go-client/models"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/terraform-providers/terraform-provider-constellix/client"
 )
 
 func resourceConstellixVanityNameserver() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceConstellixVanityNameserverCreate,
-		Update: resourceConstellixVanityNameserverUpdate,
-		Read:   resourceConstellixVanityNameserverRead,
-		Delete: resourceConstellixVanityNameserverDelete,
+		CreateContext: resourceConstellixVanityNameserverCreate,
+		UpdateContext: resourceConstellixVanityNameserverUpdate,
+		ReadContext:   resourceConstellixVanityNameserverRead,
+		DeleteContext: resourceConstellixVanityNameserverDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: resourceConstellixVanityNameserverImport,
+			StateContext: resourceConstellixVanityNameserverImport,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -59,17 +61,13 @@ func resourceConstellixVanityNameserver() *schema.Resource {
 	}
 }
 
-func resourceConstellixVanityNameserverImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+func resourceConstellixVanityNameserverImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
 	log.Printf("[DEBUG] %s: Beginning Import", d.Id())
 	constellixClient := m.(*client.Client)
 	dn := d.Id()
 
-	resp, err := constellixClient.GetbyId("v1/vanityNameservers/" + dn)
+	resp, err := constellixClient.GetbyIdContext(ctx, "v1/vanityNameservers/"+dn)
 	if err != nil {
-		if resp.StatusCode == 404 {
-			d.SetId("")
-			return nil, err
-		}
 		return nil, err
 	}
 	bodybytes, err := ioutil.ReadAll(resp.Body)
@@ -91,7 +89,7 @@ func resourceConstellixVanityNameserverImport(d *schema.ResourceData, m interfac
 	return []*schema.ResourceData{d}, nil
 }
 
-func resourceConstellixVanityNameserverCreate(d *schema.ResourceData, m interface{}) error {
+func resourceConstellixVanityNameserverCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*client.Client)
 
 	model := models.VanityNameserverAttributes{}
@@ -120,25 +118,25 @@ func resourceConstellixVanityNameserverCreate(d *schema.ResourceData, m interfac
 		model.NameserverListString = liststr.(string)
 	}
 
-	resp, err := client.Save(model, "v1/vanityNameservers/")
+	resp, err := client.SaveContext(ctx, model, "v1/vanityNameservers/")
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	//Managing response and extracting id of resource
 	bodybtes, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	bodystring := string(bodybtes)
 	var data map[string]interface{}
 	json.Unmarshal([]byte(bodystring), &data)
 
 	d.SetId(fmt.Sprintf("%.0f", data["id"]))
-	return resourceConstellixVanityNameserverRead(d, m)
+	return resourceConstellixVanityNameserverRead(ctx, d, m)
 }
 
-func resourceConstellixVanityNameserverUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceConstellixVanityNameserverUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*client.Client)
 
 	model := models.VanityNameserverAttributes{}
@@ -169,28 +167,24 @@ func resourceConstellixVanityNameserverUpdate(d *schema.ResourceData, m interfac
 
 	dn := d.Id()
 
-	_, err := client.UpdatebyID(model, "v1/vanityNameservers/"+dn)
+	_, err := client.UpdatebyIDContext(ctx, model, "v1/vanityNameservers/"+dn)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	return resourceConstellixVanityNameserverRead(d, m)
+	return resourceConstellixVanityNameserverRead(ctx, d, m)
 }
 
-func resourceConstellixVanityNameserverRead(d *schema.ResourceData, m interface{}) error {
+func resourceConstellixVanityNameserverRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*client.Client)
 	dn := d.Id()
 
-	resp, err := client.GetbyId("v1/vanityNameservers/" + dn)
-	if err != nil {
-		if resp.StatusCode == 404 {
-			d.SetId("")
-			return nil
-		}
-		return err
+	resp, err := readOrMarkGone(ctx, d, client, "v1/vanityNameservers/"+dn)
+	if err != nil || d.Id() == "" {
+		return diag.FromErr(err)
 	}
 	bodybytes, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	bodystring := string(bodybytes)
 
@@ -206,13 +200,13 @@ func resourceConstellixVanityNameserverRead(d *schema.ResourceData, m interface{
 	return nil
 }
 
-func resourceConstellixVanityNameserverDelete(d *schema.ResourceData, m interface{}) error {
+func resourceConstellixVanityNameserverDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*client.Client)
 	dn := d.Id()
 
-	err := client.DeletebyId("v1/vanityNameservers/" + dn)
+	err := client.DeletebyIdContext(ctx, "v1/vanityNameservers/"+dn)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	d.SetId("")
 	return nil
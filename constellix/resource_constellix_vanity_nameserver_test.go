@@ -7,18 +7,18 @@ import (
 	"net/http"
 	"testing"
 
-	"github.com/Constellix/constellix-go-client/client"
 	"github.com/Constellix/constellix-go-client/models"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
-	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/terraform-providers/terraform-provider-constellix/client"
 )
 
 func TestAccVanitynameserver_Basic(t *testing.T) {
 	var VNS models.VanityNameserverAttributes
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckConstellixVanitynameserverDestroy,
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckConstellixVanitynameserverDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCheckConstellixVanitynameserverConfig_basic("create"),
@@ -35,9 +35,9 @@ func TestAccConstellixVanitynameserver_Update(t *testing.T) {
 	var VNS models.VanityNameserverAttributes
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckConstellixVanitynameserverDestroy,
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckConstellixVanitynameserverDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCheckConstellixVanitynameserverConfig_basic("Name1"),
@@ -138,3 +138,21 @@ func vnsfromcontainer(resp *http.Response) (*models.VanityNameserverAttributes,
 
 	return &model, nil
 }
+
+func TestConstellixVanityNameserverLifecycle(t *testing.T) {
+	f := newFakeAPI(t)
+
+	vanity := func(nameservers string) map[string]interface{} {
+		return map[string]interface{}{
+			"name":                   "branded",
+			"nameserver_group":       1,
+			"nameserver_list_string": nameservers,
+		}
+	}
+	f.lifecycle(lifecycleTestCase{
+		resource:   "constellix_vanity_nameserver",
+		create:     vanity("ns1.example.com.\nns2.example.com."),
+		update:     vanity("ns1.example.com.\nns2.example.com.\nns3.example.com."),
+		dataSource: map[string]interface{}{"name": "branded"},
+	})
+}
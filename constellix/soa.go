@@ -0,0 +1,231 @@
+package constellix
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// The soa block of a domain holds the timers of its SOA record as integers,
+// checked against the ranges and the constraints of RFC 1912, and its
+// serial, which Constellix increments itself and is therefore only read. The
+// email of the administrator may be written as an address,
+// hostmaster@example.com, or as the SOA record spells it,
+// hostmaster.example.com., and is sent in the latter form.
+
+// soaSchema returns the soa block of a domain.
+func soaSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		MaxItems: 1,
+		Optional: true,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"primary_nameserver": &schema.Schema{
+					Type:     schema.TypeString,
+					Optional: true,
+					Computed: true,
+				},
+				"email": &schema.Schema{
+					Type:             schema.TypeString,
+					Optional:         true,
+					Computed:         true,
+					DiffSuppressFunc: suppressEquivalentSOAEmail,
+				},
+				"ttl": &schema.Schema{
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Computed:     true,
+					ValidateFunc: validation.IntBetween(1, math.MaxInt32),
+				},
+				"serial": &schema.Schema{
+					Type:     schema.TypeInt,
+					Computed: true,
+				},
+				// RFC 1912 recommends 20 minutes to 12 hours.
+				"refresh": &schema.Schema{
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Computed:     true,
+					ValidateFunc: soaTimer(1200, 43200),
+				},
+				"retry": &schema.Schema{
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Computed:     true,
+					ValidateFunc: validation.IntBetween(1, math.MaxInt32),
+				},
+				// RFC 1912 recommends 2 to 4 weeks.
+				"expire": &schema.Schema{
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Computed:     true,
+					ValidateFunc: soaTimer(1209600, 2419200),
+				},
+				// The minimum of the SOA record is the TTL of negative
+				// answers since RFC 2308, which finds more than a day
+				// problematic.
+				"negcache": &schema.Schema{
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Computed:     true,
+					ValidateFunc: soaTimer(1, 86400),
+				},
+			},
+		},
+	}
+}
+
+// soaTimer returns the validation of a timer of the SOA record, which must
+// fit in its 32 bits. Seconds outside the low to high range recommended for
+// the timer are warned about rather than refused, as some defaults of
+// Constellix are.
+func soaTimer(low, high int) schema.SchemaValidateFunc {
+	return func(i interface{}, k string) ([]string, []error) {
+		warnings, errors := validation.IntBetween(1, math.MaxInt32)(i, k)
+		if v, ok := i.(int); ok && len(errors) == 0 && (v < low || v > high) {
+			warnings = append(warnings, fmt.Sprintf("%s of %d seconds is outside the recommended %d to %d seconds", k, v, low, high))
+		}
+		return warnings, errors
+	}
+}
+
+// validateSOA checks the timers of the SOA record against each other, as
+// RFC 1912 requires: retry is shorter than refresh, and expire longer than
+// retry and negcache.
+func validateSOA(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	soa, _ := d.Get("soa").([]interface{})
+	if len(soa) == 0 || soa[0] == nil {
+		return nil
+	}
+	inner := soa[0].(map[string]interface{})
+	refresh, retry := toIntValue(inner["refresh"]), toIntValue(inner["retry"])
+	expire, negCache := toIntValue(inner["expire"]), toIntValue(inner["negcache"])
+	if refresh != 0 && retry >= refresh {
+		return fmt.Errorf("soa: retry (%d) must be less than refresh (%d)", retry, refresh)
+	}
+	if expire != 0 && retry >= expire {
+		return fmt.Errorf("soa: expire (%d) must be greater than retry (%d)", expire, retry)
+	}
+	if expire != 0 && negCache >= expire {
+		return fmt.Errorf("soa: expire (%d) must be greater than negcache (%d)", expire, negCache)
+	}
+	return nil
+}
+
+// soaEmail returns the mailbox of email as the SOA record spells it: the @
+// of an address becomes a dot, the dots before it are escaped, and the name
+// ends with a dot.
+func soaEmail(email string) string {
+	email = strings.TrimSpace(email)
+	if i := strings.LastIndex(email, "@"); i >= 0 {
+		email = strings.ReplaceAll(email[:i], ".", `\.`) + "." + email[i+1:]
+	}
+	if email != "" && !strings.HasSuffix(email, ".") {
+		email += "."
+	}
+	return email
+}
+
+// suppressEquivalentSOAEmail ignores changes between spellings of the same
+// mailbox.
+func suppressEquivalentSOAEmail(k, old, new string, d *schema.ResourceData) bool {
+	return strings.EqualFold(soaEmail(old), soaEmail(new))
+}
+
+// keepSOAEmailSpelling returns configured when it spells the same mailbox as
+// answered, and answered otherwise.
+func keepSOAEmailSpelling(configured, answered string) string {
+	if configured != "" && strings.EqualFold(soaEmail(configured), soaEmail(answered)) {
+		return configured
+	}
+	return answered
+}
+
+// expandSOA returns the SOA sent to the API for the soa block, or nil when
+// there is none. The serial is left to Constellix.
+func expandSOA(soa []interface{}) *SOAAttributes {
+	if len(soa) == 0 || soa[0] == nil {
+		return nil
+	}
+	inner := soa[0].(map[string]interface{})
+	return &SOAAttributes{
+		PrimaryNameServer: toStringValue(inner["primary_nameserver"]),
+		Email:             soaEmail(toStringValue(inner["email"])),
+		TTL:               toIntValue(inner["ttl"]),
+		Refresh:           toIntValue(inner["refresh"]),
+		Retry:             toIntValue(inner["retry"]),
+		Expire:            toIntValue(inner["expire"]),
+		NegCache:          toIntValue(inner["negcache"]),
+	}
+}
+
+// flattenSOA returns the soa block of the SOA the API answered with, keeping
+// the spelling of the configured email.
+func flattenSOA(answered interface{}, configuredEmail string) []interface{} {
+	soa, ok := answered.(map[string]interface{})
+	if !ok {
+		return []interface{}{}
+	}
+	return []interface{}{map[string]interface{}{
+		"primary_nameserver": toStringValue(soa["primaryNameserver"]),
+		"email":              keepSOAEmailSpelling(configuredEmail, toStringValue(soa["email"])),
+		"ttl":                toIntValue(soa["ttl"]),
+		"serial":             toIntValue(soa["serial"]),
+		"refresh":            toIntValue(soa["refresh"]),
+		"retry":              toIntValue(soa["retry"]),
+		"expire":             toIntValue(soa["expire"]),
+		"negcache":           toIntValue(soa["negCache"]),
+	}}
+}
+
+// soaUpgrader upgrades the state of domains that declared soa as a map of
+// strings before version+1 of their schema.
+func soaUpgrader(r *schema.Resource, version int) schema.StateUpgrader {
+	previous := make(map[string]*schema.Schema, len(r.Schema))
+	for name, attr := range r.Schema {
+		previous[name] = attr
+	}
+	previous["soa"] = &schema.Schema{
+		Type:     schema.TypeMap,
+		Optional: true,
+		Computed: true,
+		Elem:     &schema.Schema{Type: schema.TypeString},
+	}
+	return schema.StateUpgrader{
+		Version: version,
+		Type:    (&schema.Resource{Schema: previous}).CoreConfigSchema().ImpliedType(),
+		Upgrade: upgradeSOA,
+	}
+}
+
+func upgradeSOA(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	if rawState == nil {
+		return rawState, nil
+	}
+	soa, ok := rawState["soa"].(map[string]interface{})
+	if !ok {
+		// Converted already by the upgrade of version 0 states.
+		return rawState, nil
+	}
+	if len(soa) == 0 {
+		rawState["soa"] = []interface{}{}
+		return rawState, nil
+	}
+	rawState["soa"] = []interface{}{map[string]interface{}{
+		"primary_nameserver": toStringValue(soa["primary_nameserver"]),
+		"email":              toStringValue(soa["email"]),
+		"ttl":                toIntValue(soa["ttl"]),
+		"serial":             toIntValue(soa["serial"]),
+		"refresh":            toIntValue(soa["refresh"]),
+		"retry":              toIntValue(soa["retry"]),
+		"expire":             toIntValue(soa["expire"]),
+		"negcache":           toIntValue(soa["negcache"]),
+	}}
+	return rawState, nil
+}
@@ -6,12 +6,12 @@ provider "constellix" {
 //RESOURCES
 resource "constellix_domain" "domain1" {
   name = "domain1.com"
-  soa = {
+  soa {
     primary_nameserver = "ns41.constellix.com."
     ttl                = 1800
-    refresh            = 48100
+    refresh            = 43200
     retry              = 7200
-    expire             = 1209
+    expire             = 1209600
     negcache           = 8000
   }
 }
@@ -38,20 +38,20 @@ resource "constellix_aname_record" "aname_record1" {
   record_failover_values {
     value        = "www.w3schools.com."
     sort_order   = 1
-    disable_flag = "false"
+    disable_flag = false
   }
   record_failover_values {
     value        = "www.messenger.com."
     sort_order   = 2
-    disable_flag = "false"
+    disable_flag = false
   }
   record_failover_values {
     value        = "www.gmail.com."
     sort_order   = 3
-    disable_flag = "false"
+    disable_flag = false
   }
   record_failover_failover_type = 1
-  record_failover_disable_flag  = "false"
+  record_failover_disable_flag  = false
 }
 
 resource "constellix_srv_record" "srvrecord1" {
@@ -84,12 +84,12 @@ resource "constellix_hinfo_record" "hinfo1" {
   roundrobin {
     cpu          = "quard core"
     os           = "linux2"
-    disable_flag = "false"
+    disable_flag = false
   }
   roundrobin {
     cpu          = "abc"
     os           = "winddows"
-    disable_flag = "true"
+    disable_flag = true
   }
 }
 
@@ -123,14 +123,14 @@ resource "constellix_caa_record" "caacheck" {
     tag             = "issue"
     data            = "como.com"
     flag            = "0"
-    disable_flag    = "false"
+    disable_flag    = false
   }
   roundrobin {
     caa_provider_id = 4
     tag             = "issue"
     data            = "como01.com"
     flag            = "1"
-    disable_flag    = "true"
+    disable_flag    = true
   }
 }
 
@@ -146,12 +146,12 @@ resource "constellix_mx_record" "mx1" {
   roundrobin {
     value        = "abc"
     level        = "100"
-    disable_flag = "false"
+    disable_flag = false
   }
   roundrobin {
     value        = "dce"
     level        = "200"
-    disable_flag = "true"
+    disable_flag = true
   }
 }
 
@@ -167,12 +167,12 @@ resource "constellix_rp_record" "rp1" {
   roundrobin {
     mailbox      = "one.com"
     txt          = "domain.com"
-    disable_flag = "false"
+    disable_flag = false
   }
   roundrobin {
     mailbox      = "second.com"
     txt          = "two.com"
-    disable_flag = "true"
+    disable_flag = true
   }
 }
 
@@ -218,9 +218,9 @@ resource "constellix_a_record" "firstrecord" {
   record_option = "roundRobinFailover"
   ttl           = 100
   name          = "firstrecord"
-  geo_location = {
-    geo_ip_user_region = 1
-    drop               = "false"
+  geo_location {
+    geo_ip_user_region = [1]
+    drop               = false
   }
   pools       = [123]
   contact_ids = [1234]
@@ -230,30 +230,30 @@ resource "constellix_a_record" "firstrecord" {
   noanswer    = false
   roundrobin {
     value        = "5.45.25.35"
-    disable_flag = "false"
+    disable_flag = false
   }
   roundrobin_failover {
     value        = "5.45.2.35"
     sort_order   = 1
-    disable_flag = "false"
+    disable_flag = false
   }
   roundrobin_failover {
     value        = "5.45.25.3"
-    sort_order   = 1
-    disable_flag = "false"
+    sort_order   = 2
+    disable_flag = false
   }
   record_failover_values {
     value        = "5.45.25.5"
     sort_order   = 1
-    disable_flag = "false"
+    disable_flag = false
   }
   record_failover_values {
     value        = "5.45.25.5"
     sort_order   = 2
-    disable_flag = "false"
+    disable_flag = false
   }
   record_failover_failover_type = 2
-  record_failover_disable_flag  = "false"
+  record_failover_disable_flag  = false
 }
 
 resource "constellix_aaaa_record" "firstrecord" {
@@ -262,9 +262,9 @@ resource "constellix_aaaa_record" "firstrecord" {
   record_option = "roundRobinFailover"
   ttl           = 100
   name          = "firstrecord"
-  geo_location = {
-    geo_ip_user_region = 1
-    drop               = "false"
+  geo_location {
+    geo_ip_user_region = [1]
+    drop               = false
   }
   pools       = [123]
   contact_ids = [1234]
@@ -274,30 +274,30 @@ resource "constellix_aaaa_record" "firstrecord" {
   noanswer    = false
   roundrobin {
     value        = "5:0:0:0:0:0:0:6"
-    disable_flag = "false"
+    disable_flag = false
   }
   roundrobin_failover {
     value        = "4:0:0:0:0:0:0:6"
     sort_order   = 1
-    disable_flag = "false"
+    disable_flag = false
   }
   roundrobin_failover {
     value        = "3:0:0:0:0:0:0:6"
-    sort_order   = 1
-    disable_flag = "false"
+    sort_order   = 2
+    disable_flag = false
   }
   record_failover_values {
     value        = "5:0:0:0:0:0:1:6"
     sort_order   = 1
-    disable_flag = "false"
+    disable_flag = false
   }
   record_failover_values {
     value        = "5:0:0:0:1:0:0:6"
     sort_order   = 2
-    disable_flag = "false"
+    disable_flag = false
   }
   record_failover_failover_type = 2
-  record_failover_disable_flag  = "false"
+  record_failover_disable_flag  = false
 }
 
 resource "constellix_cname_record" "firstrecord" {
@@ -307,9 +307,9 @@ resource "constellix_cname_record" "firstrecord" {
   ttl           = 100
   name          = "arecordname350"
   host          = "abcd.com."
-  geo_location = {
-    geo_ip_user_region = 1
-    drop               = "false"
+  geo_location {
+    geo_ip_user_region = [1]
+    drop               = false
   }
   pools       = [123]
   contact_ids = [1234]
@@ -320,15 +320,15 @@ resource "constellix_cname_record" "firstrecord" {
   record_failover_values {
     value        = "abc.com."
     sort_order   = 1
-    disable_flag = "false"
+    disable_flag = false
   }
   record_failover_values {
     value        = "ab.com."
     sort_order   = 2
-    disable_flag = "false"
+    disable_flag = false
   }
   record_failover_failover_type = 2
-  record_failover_disable_flag  = "false"
+  record_failover_disable_flag  = false
 }
 
 resource "constellix_naptr_record" "firstrecord" {
@@ -343,7 +343,7 @@ resource "constellix_naptr_record" "firstrecord" {
     service            = "SIP+D2U"
     regular_expression = "hello"
     replacement        = "foobar.example.com."
-    disable_flag       = "true"
+    disable_flag       = true
   }
   type       = "NAPTR"
   gtd_region = 1
@@ -359,7 +359,7 @@ resource "constellix_ns_record" "firstrecord" {
   name        = "firstrecord"
   roundrobin {
     value        = "prac."
-    disable_flag = "false"
+    disable_flag = false
   }
   type       = "NS"
   gtd_region = 1
@@ -399,7 +399,7 @@ resource "constellix_cert_record" "firstrecord" {
     key_tag          = 30
     certificate      = "certificate1"
     algorithm        = 100
-    disable_flag     = "true"
+    disable_flag     = true
   }
 }
 
@@ -444,7 +444,7 @@ resource "constellix_ptr_record" "ptr1" {
   type        = "PTR"
   roundrobin {
     value        = 13
-    disable_flag = "true"
+    disable_flag = true
   }
 }
 
@@ -459,7 +459,7 @@ resource "constellix_spf_record" "spf1" {
   note        = "Practice record"
   roundrobin {
     value        = "124.56.8.1"
-    disable_flag = "false"
+    disable_flag = false
   }
 
 }
@@ -0,0 +1,125 @@
+package export
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/terraform-providers/terraform-provider-constellix/internal/tfconfig"
+)
+
+const usage = `Usage: terraform-provider-constellix export [-out file] [-import-script file]
+
+Writes the configuration of the domains, templates, records, pools, geo
+filters, tags, contact lists and Sonar checks of a Constellix account, with an
+import block for each resource. The credentials and endpoints are read from
+the environment variables the provider reads them from, such as
+CONSTELLIX_API_KEY and CONSTELLIX_SECRET_KEY.
+
+`
+
+// Main runs the export command with the given arguments, reading with
+// provider once it is configured from the environment, and returns its exit
+// status.
+func Main(ctx context.Context, provider *schema.Provider, args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprint(stderr, usage)
+		flags.PrintDefaults()
+	}
+	out := flags.String("out", "", "file to write the configuration to instead of standard output")
+	script := flags.String("import-script", "", "file to write terraform import commands to, instead of writing import blocks")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() > 0 {
+		flags.Usage()
+		return 2
+	}
+
+	if diags := provider.Configure(ctx, terraform.NewResourceConfigRaw(map[string]interface{}{})); diags.HasError() {
+		for _, d := range diags {
+			fmt.Fprintf(stderr, "export: %s\n", d.Summary)
+		}
+		return 1
+	}
+	e := New(provider)
+	objects, err := e.Export(ctx)
+	if err != nil {
+		fmt.Fprintf(stderr, "export: %s\n", err)
+		return 1
+	}
+	for _, problem := range e.Problems {
+		fmt.Fprintf(stderr, "export: skipped %s\n", problem)
+	}
+
+	if err := write(*out, stdout, func(w io.Writer) error {
+		return tfconfig.Write(w, Blocks(objects, *script == ""))
+	}); err != nil {
+		fmt.Fprintf(stderr, "export: %s\n", err)
+		return 1
+	}
+	if *script != "" {
+		if err := write(*script, stdout, func(w io.Writer) error {
+			return WriteImportScript(w, objects)
+		}); err != nil {
+			fmt.Fprintf(stderr, "export: %s\n", err)
+			return 1
+		}
+	}
+	return 0
+}
+
+// Blocks returns the configuration of objects, each resource followed by
+// its import block when imports is set.
+func Blocks(objects []*Object, imports bool) []*tfconfig.Block {
+	blocks := make([]*tfconfig.Block, 0, 2*len(objects))
+	for _, obj := range objects {
+		blocks = append(blocks, obj.Block)
+		if imports {
+			imp := tfconfig.NewBlock("import")
+			imp.Set("to", tfconfig.Expr(obj.Address()))
+			imp.Set("id", obj.ImportID)
+			blocks = append(blocks, imp)
+		}
+	}
+	return blocks
+}
+
+// WriteImportScript writes the terraform import commands of objects as a
+// shell script.
+func WriteImportScript(w io.Writer, objects []*Object) error {
+	bw := bufio.NewWriter(w)
+	bw.WriteString("#!/bin/sh\nset -e\n\n")
+	for _, obj := range objects {
+		fmt.Fprintf(bw, "terraform import %s %s\n", shellQuote(obj.Address()), shellQuote(obj.ImportID))
+	}
+	return bw.Flush()
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// write calls f with the file at path, or with stdout when path is empty.
+func write(path string, stdout io.Writer, f func(io.Writer) error) error {
+	if path == "" {
+		return f(stdout)
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := f(file); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
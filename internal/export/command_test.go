@@ -0,0 +1,63 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/terraform-providers/terraform-provider-constellix/constellix"
+	"github.com/terraform-providers/terraform-provider-constellix/internal/fakeapi"
+)
+
+func TestMainCommand(t *testing.T) {
+	a := newAccount(t)
+	t.Setenv("CONSTELLIX_API_KEY", fakeapi.APIKey)
+	t.Setenv("CONSTELLIX_SECRET_KEY", fakeapi.SecretKey)
+	t.Setenv("CONSTELLIX_API_ENDPOINT", a.server.URL)
+	t.Setenv("CONSTELLIX_SONAR_ENDPOINT", a.server.URL)
+
+	var stdout, stderr bytes.Buffer
+	if status := Main(context.Background(), constellix.Provider(), nil, &stdout, &stderr); status != 0 {
+		t.Fatalf("expected status 0, got %d: %s", status, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "import {\n  to = constellix_domain.example_com\n  id = \""+a.ids["domain"]+"\"\n}\n") {
+		t.Errorf("expected an import block for the domain, got\n%s", stdout.String())
+	}
+
+	dir, err := ioutil.TempDir("", "export")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	out := filepath.Join(dir, "main.tf")
+	script := filepath.Join(dir, "import.sh")
+	stdout.Reset()
+	if status := Main(context.Background(), constellix.Provider(), []string{"-out", out, "-import-script", script}, &stdout, &stderr); status != 0 {
+		t.Fatalf("expected status 0, got %d: %s", status, stderr.String())
+	}
+	config, _ := ioutil.ReadFile(out)
+	commands, _ := ioutil.ReadFile(script)
+	if stdout.Len() != 0 || strings.Contains(string(config), "import {") {
+		t.Errorf("expected the configuration without import blocks in %s, got\n%s", out, config)
+	}
+	expected := "terraform import 'constellix_a_record.example_com_www' 'domains:" + a.ids["domain"] + ":" + a.ids["www"] + "'\n"
+	if !strings.HasPrefix(string(commands), "#!/bin/sh\n") || !strings.Contains(string(commands), expected) {
+		t.Errorf("expected the import commands in %s, got\n%s", script, commands)
+	}
+}
+
+func TestMainWithoutCredentials(t *testing.T) {
+	t.Setenv("CONSTELLIX_API_KEY", "")
+	t.Setenv("CONSTELLIX_SECRET_KEY", "")
+	var stdout, stderr bytes.Buffer
+	if status := Main(context.Background(), constellix.Provider(), nil, &stdout, &stderr); status != 1 || stderr.Len() == 0 {
+		t.Errorf("expected status 1 and an error, got %d and %q", status, stderr.String())
+	}
+	if status := Main(context.Background(), constellix.Provider(), []string{"extra"}, &stdout, &stderr); status != 2 {
+		t.Errorf("expected status 2 for an extra argument, got %d", status)
+	}
+}
@@ -0,0 +1,417 @@
+// Package export writes the configuration of the objects of a Constellix
+// account, with the import blocks or commands that bring them under the
+// management of Terraform.
+package export
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/terraform-providers/terraform-provider-constellix/client"
+	"github.com/terraform-providers/terraform-provider-constellix/internal/tfconfig"
+)
+
+// recordResources are the record types of the API, by the segment of their
+// records/<type> path.
+var recordResources = []struct {
+	path     string
+	resource string
+}{
+	{"a", "constellix_a_record"},
+	{"aaaa", "constellix_aaaa_record"},
+	{"aname", "constellix_aname_record"},
+	{"cname", "constellix_cname_record"},
+	{"caa", "constellix_caa_record"},
+	{"cert", "constellix_cert_record"},
+	{"hinfo", "constellix_hinfo_record"},
+	{"httpredirection", "constellix_http_redirection_record"},
+	{"mx", "constellix_mx_record"},
+	{"naptr", "constellix_naptr_record"},
+	{"ns", "constellix_ns_record"},
+	{"ptr", "constellix_ptr_record"},
+	{"rp", "constellix_rp_record"},
+	{"spf", "constellix_spf_record"},
+	{"srv", "constellix_srv_record"},
+	{"txt", "constellix_txt_record"},
+	{"tlsa", "constellix_tlsa_record"},
+	{"sshfp", "constellix_sshfp_record"},
+	{"https", "constellix_https_record"},
+	{"svcb", "constellix_svcb_record"},
+}
+
+// collections are the objects of an account other than domains, templates
+// and their records.
+var collections = []struct {
+	endpoint string
+	sonar    bool
+	resource string
+}{
+	{"v1/pools/A", false, "constellix_a_record_pool"},
+	{"v1/pools/AAAA", false, "constellix_aaaa_record_pool"},
+	{"v1/pools/CNAME", false, "constellix_cname_record_pool"},
+	{"v1/geoFilters", false, "constellix_geo_filter"},
+	{"v2/tags", false, "constellix_tags"},
+	{"v2/contactLists", false, "constellix_contact_lists"},
+	{"rest/api/http", true, "constellix_http_check"},
+	{"rest/api/tcp", true, "constellix_tcp_check"},
+	{"rest/api/dns", true, "constellix_dns_check"},
+}
+
+// computedRecordArguments are the arguments of records which the API sets
+// from where the record is, and which are left out of their configuration.
+var computedRecordArguments = map[string]bool{
+	"type":     true,
+	"parent":   true,
+	"parentid": true,
+	"source":   true,
+}
+
+// Object is an object of the account with its configuration.
+type Object struct {
+	Resource string
+	Label    string
+	// ImportID is the ID the resource is imported with.
+	ImportID string
+	// Data is the state of the object, as read by the provider.
+	Data  *schema.ResourceData
+	Block *tfconfig.Block
+}
+
+// Address returns the address of the resource of the object.
+func (o *Object) Address() string {
+	return o.Resource + "." + o.Label
+}
+
+// Exporter reads the objects of an account with the resources of a
+// configured provider.
+type Exporter struct {
+	provider *schema.Provider
+	client   *client.Client
+	labels   map[string]bool
+	// refs are the references to the exported objects, by resource and ID.
+	refs map[string]tfconfig.Expr
+	// Problems are the objects which could not be exported.
+	Problems []string
+}
+
+// New returns an exporter reading with the resources of provider, which
+// must be configured.
+func New(provider *schema.Provider) *Exporter {
+	return &Exporter{
+		provider: provider,
+		client:   provider.Meta().(*client.Client),
+		labels:   make(map[string]bool),
+		refs:     make(map[string]tfconfig.Expr),
+	}
+}
+
+// Export returns the objects of the account: each domain followed by its
+// records, each template followed by its records, then the pools, geo
+// filters, tags, contact lists and Sonar checks.
+func (e *Exporter) Export(ctx context.Context) ([]*Object, error) {
+	var objects []*Object
+	for _, parent := range []struct {
+		sourceType string
+		resource   string
+	}{
+		{"domains", "constellix_domain"},
+		{"templates", "constellix_template"},
+	} {
+		items, err := e.list(ctx, "v1/"+parent.sourceType)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			obj := e.object(ctx, parent.resource, item.id, item.name)
+			if obj == nil {
+				continue
+			}
+			objects = append(objects, obj)
+			records, err := e.records(ctx, parent.sourceType, item.id, obj.Label)
+			if err != nil {
+				return nil, err
+			}
+			objects = append(objects, records...)
+		}
+	}
+
+	for _, c := range collections {
+		endpoint := c.endpoint
+		if c.sonar {
+			endpoint = e.client.SonarEndpoint(endpoint)
+		}
+		items, err := e.list(ctx, endpoint)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			if obj := e.object(ctx, c.resource, item.id, item.name); obj != nil {
+				objects = append(objects, obj)
+			}
+		}
+	}
+
+	for _, obj := range objects {
+		obj.Block = e.block(obj)
+	}
+	return objects, nil
+}
+
+// records returns the records of the domain or template with the given ID.
+func (e *Exporter) records(ctx context.Context, sourceType, parentID, parentLabel string) ([]*Object, error) {
+	var objects []*Object
+	for _, rt := range recordResources {
+		items, err := e.list(ctx, "v1/"+sourceType+"/"+parentID+"/records/"+rt.path)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			label := parentLabel
+			if item.name != "" {
+				label += "_" + item.name
+			}
+			importID := sourceType + ":" + parentID + ":" + item.id
+			if obj := e.object(ctx, rt.resource, importID, label); obj != nil {
+				objects = append(objects, obj)
+			}
+		}
+	}
+	return objects, nil
+}
+
+// object imports and reads the object with the given import ID like
+// terraform import does. Objects which cannot be read are added to the
+// problems.
+func (e *Exporter) object(ctx context.Context, resource, importID, name string) *Object {
+	r := e.provider.ResourcesMap[resource]
+	d := r.Data(nil)
+	d.SetId(importID)
+	meta := e.provider.Meta()
+
+	if r.Importer != nil && r.Importer.StateContext != nil {
+		imported, err := r.Importer.StateContext(ctx, d, meta)
+		if err != nil {
+			e.problem(resource, importID, err)
+			return nil
+		}
+		d = imported[0]
+	}
+	if diags := r.ReadContext(ctx, d, meta); diags.HasError() {
+		for _, diag := range diags {
+			e.problem(resource, importID, fmt.Errorf("%s", diag.Summary))
+		}
+		return nil
+	}
+	if d.Id() == "" {
+		e.problem(resource, importID, fmt.Errorf("not found"))
+		return nil
+	}
+
+	label := tfconfig.Identifier(name)
+	for i, base := 2, label; e.labels[resource+"."+label]; i++ {
+		label = fmt.Sprintf("%s_%d", base, i)
+	}
+	e.labels[resource+"."+label] = true
+	e.refs[resource+"."+d.Id()] = tfconfig.Ref(resource, label, "id")
+	return &Object{Resource: resource, Label: label, ImportID: importID, Data: d}
+}
+
+func (e *Exporter) problem(resource, importID string, err error) {
+	e.Problems = append(e.Problems, fmt.Sprintf("%s %s: %s", resource, importID, err))
+}
+
+type item struct {
+	id   string
+	name string
+}
+
+// list returns the IDs and names of the objects listed at endpoint, over
+// every page of the list.
+func (e *Exporter) list(ctx context.Context, endpoint string) ([]item, error) {
+	answered, err := e.client.ListContext(ctx, endpoint)
+	if err != nil {
+		if client.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("listing %s: %w", endpoint, err)
+	}
+
+	items := make([]item, 0, len(answered))
+	for _, obj := range answered {
+		id, ok := obj["id"].(float64)
+		if !ok {
+			continue
+		}
+		name, _ := obj["name"].(string)
+		items = append(items, item{id: fmt.Sprintf("%.0f", id), name: name})
+	}
+	return items, nil
+}
+
+// leadingArguments are written first, in this order, when a resource has
+// them; the other arguments follow in alphabetical order.
+var leadingArguments = []string{"domain_id", "source_type", "name", "ttl"}
+
+// block returns the configuration of an object: the arguments of its
+// resource which are set, with the IDs of other exported objects replaced
+// by references to them.
+func (e *Exporter) block(obj *Object) *tfconfig.Block {
+	r := e.provider.ResourcesMap[obj.Resource]
+	b := tfconfig.NewBlock("resource", obj.Resource, obj.Label)
+
+	names := make([]string, 0, len(r.Schema))
+	for name := range r.Schema {
+		names = append(names, name)
+	}
+	sort.SliceStable(names, func(i, j int) bool {
+		return argumentOrder(names[i]) < argumentOrder(names[j]) ||
+			argumentOrder(names[i]) == argumentOrder(names[j]) && names[i] < names[j]
+	})
+
+	record := r.Schema["source_type"] != nil
+	values := make(map[string]interface{}, len(names))
+	for _, name := range names {
+		if name == "id" || record && computedRecordArguments[name] {
+			continue
+		}
+		value := obj.Data.Get(name)
+		if resource := references[name]; resource != nil {
+			value = e.refValue(resource(obj), value)
+		}
+		values[name] = value
+	}
+	if ref, ok := e.parentRef(obj); ok {
+		values["domain_id"] = ref
+	}
+	writeArguments(b, r.Schema, names, values)
+	return b
+}
+
+// references return the resource of the objects whose IDs an argument holds,
+// by argument.
+var references = map[string]func(obj *Object) string{
+	"pools":       func(obj *Object) string { return obj.Resource + "_pool" },
+	"contact_ids": func(*Object) string { return "constellix_contact_lists" },
+	"template":    func(*Object) string { return "constellix_template" },
+	"tags":        func(*Object) string { return "constellix_tags" },
+}
+
+// refValue replaces the IDs of value which are IDs of exported objects of
+// resource with references to them.
+func (e *Exporter) refValue(resource string, value interface{}) interface{} {
+	if list, ok := value.([]interface{}); ok {
+		refs := make([]interface{}, len(list))
+		for i, elem := range list {
+			refs[i] = e.refValue(resource, elem)
+		}
+		return refs
+	}
+	if ref, ok := e.refs[resource+"."+fmt.Sprint(value)]; ok {
+		return ref
+	}
+	return value
+}
+
+// parentRef returns the reference to the domain or template of a record.
+func (e *Exporter) parentRef(obj *Object) (tfconfig.Expr, bool) {
+	sourceType, ok := obj.Data.GetOk("source_type")
+	if !ok {
+		return "", false
+	}
+	resource := "constellix_domain"
+	if sourceType == "templates" {
+		resource = "constellix_template"
+	}
+	ref, ok := e.refs[resource+"."+obj.Data.Get("domain_id").(string)]
+	return ref, ok
+}
+
+func argumentOrder(name string) int {
+	for i, leading := range leadingArguments {
+		if name == leading {
+			return i
+		}
+	}
+	return len(leadingArguments)
+}
+
+// writeArguments adds the arguments of s named in names to b: first the
+// attributes, then the nested blocks.
+func writeArguments(b *tfconfig.Block, s map[string]*schema.Schema, names []string, values map[string]interface{}) {
+	var nested []string
+	for _, name := range names {
+		attr, value := s[name], values[name]
+		if _, ok := values[name]; !ok || !attr.Required && !attr.Optional || !isSet(attr, value) {
+			continue
+		}
+		if _, ok := attr.Elem.(*schema.Resource); ok && attr.ConfigMode != schema.SchemaConfigModeAttr {
+			nested = append(nested, name)
+			continue
+		}
+		if expr, ok := value.(tfconfig.Expr); ok {
+			b.Set(name, expr)
+			continue
+		}
+		b.Set(name, attrValue(value))
+	}
+
+	for _, name := range nested {
+		attr := s[name]
+		elemSchema := attr.Elem.(*schema.Resource).Schema
+		elemNames := make([]string, 0, len(elemSchema))
+		for elemName := range elemSchema {
+			elemNames = append(elemNames, elemName)
+		}
+		sort.Strings(elemNames)
+		for _, elem := range elems(values[name]) {
+			inner, _ := elem.(map[string]interface{})
+			writeArguments(b.Append(name), elemSchema, elemNames, inner)
+		}
+	}
+}
+
+// isSet reports whether an optional argument has a value that needs to be
+// configured: one other than its default, or than its zero value.
+func isSet(attr *schema.Schema, value interface{}) bool {
+	if _, ok := value.(tfconfig.Expr); ok || attr.Required {
+		return true
+	}
+	if attr.Default != nil {
+		return fmt.Sprint(value) != fmt.Sprint(attr.Default)
+	}
+	switch v := value.(type) {
+	case nil:
+		return false
+	case string:
+		return v != ""
+	case int:
+		return v != 0
+	case float64:
+		return v != 0
+	case bool:
+		return v
+	case map[string]interface{}:
+		return len(v) > 0
+	}
+	return len(elems(value)) > 0
+}
+
+func elems(value interface{}) []interface{} {
+	switch v := value.(type) {
+	case *schema.Set:
+		return v.List()
+	case []interface{}:
+		return v
+	}
+	return nil
+}
+
+// attrValue returns a value read from a resource as a value of an attribute.
+func attrValue(value interface{}) interface{} {
+	if set, ok := value.(*schema.Set); ok {
+		return set.List()
+	}
+	return value
+}
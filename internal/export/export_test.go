@@ -0,0 +1,264 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/terraform-providers/terraform-provider-constellix/client"
+	"github.com/terraform-providers/terraform-provider-constellix/constellix"
+	"github.com/terraform-providers/terraform-provider-constellix/internal/fakeapi"
+	"github.com/terraform-providers/terraform-provider-constellix/internal/tfconfig"
+	"github.com/terraform-providers/terraform-provider-constellix/internal/tfconfig/tfconfigtest"
+)
+
+// account is a fake API holding an object of every kind the exporter walks.
+type account struct {
+	t        *testing.T
+	server   *fakeapi.Server
+	provider *schema.Provider
+	client   *client.Client
+	ids      map[string]string
+}
+
+func newAccount(t *testing.T) *account {
+	server := fakeapi.NewServer()
+	t.Cleanup(server.Close)
+
+	provider := constellix.Provider()
+	diags := provider.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"apikey":         fakeapi.APIKey,
+		"secretkey":      fakeapi.SecretKey,
+		"api_endpoint":   server.URL,
+		"sonar_endpoint": server.URL,
+		"max_retries":    0,
+	}))
+	if diags.HasError() {
+		t.Fatalf("configuring the provider: %v", diags)
+	}
+	// Small pages make every list of the tests span several of them.
+	client.PageSize(2)(provider.Meta().(*client.Client))
+	a := &account{
+		t:        t,
+		server:   server,
+		provider: provider,
+		client:   provider.Meta().(*client.Client),
+		ids:      make(map[string]string),
+	}
+
+	domainID := a.create("domain", "v1/domains", map[string]interface{}{"names": []string{"example.com"}})
+	templateID := a.create("template", "v1/templates", map[string]interface{}{"name": []string{"web-template"}})
+	poolID := a.create("pool", "v1/pools/A", map[string]interface{}{
+		"name":                 "web-pool",
+		"numReturn":            1,
+		"minAvailableFailover": 1,
+		"values":               []interface{}{map[string]interface{}{"value": "192.0.2.10", "weight": 10, "disableFlag": false}},
+	})
+	a.create("www", "v1/domains/"+domainID+"/records/a", map[string]interface{}{
+		"name":       "www",
+		"ttl":        300,
+		"roundRobin": []interface{}{map[string]interface{}{"value": "192.0.2.1", "disableFlag": false}},
+	})
+	a.create("pooled", "v1/domains/"+domainID+"/records/a", map[string]interface{}{
+		"name":         "pooled",
+		"ttl":          300,
+		"recordOption": "pools",
+		"pools":        []interface{}{poolID},
+	})
+	a.create("api", "v1/domains/"+domainID+"/records/a", map[string]interface{}{
+		"name":       "api",
+		"ttl":        60,
+		"roundRobin": []interface{}{map[string]interface{}{"value": "192.0.2.2", "disableFlag": false}},
+	})
+	a.create("mx", "v1/domains/"+domainID+"/records/mx", map[string]interface{}{
+		"name":       "",
+		"ttl":        3600,
+		"note":       "mail",
+		"roundRobin": []interface{}{map[string]interface{}{"value": "mail.example.com.", "level": 10, "disableFlag": false}},
+	})
+	a.create("txt", "v1/domains/"+domainID+"/records/txt", map[string]interface{}{
+		"name":       "txt",
+		"ttl":        60,
+		"roundRobin": []interface{}{map[string]interface{}{"value": `"v=spf1 -all"`, "disableFlag": false}},
+	})
+	a.create("template-www", "v1/templates/"+templateID+"/records/cname", map[string]interface{}{
+		"name": "www",
+		"ttl":  300,
+		"host": "web.example.net.",
+	})
+	a.create("geo-filter", "v1/geoFilters", map[string]interface{}{
+		"name":             "europe",
+		"filterRulesLimit": 100,
+		"geoipContinents":  []interface{}{"EU"},
+	})
+	a.create("tag", "v2/tags", map[string]interface{}{"name": "production"})
+	a.create("contact-list", "v2/contactLists", map[string]interface{}{
+		"name":           "ops",
+		"emailAddresses": []interface{}{"ops@example.com"},
+	})
+	a.create("http-check", a.client.SonarEndpoint("rest/api/http"), map[string]interface{}{
+		"name":         "web",
+		"host":         "www.example.com",
+		"port":         443,
+		"protocolType": "HTTPS",
+		"ipVersion":    "IPV4",
+		"checkSites":   []interface{}{1},
+	})
+	return a
+}
+
+// create stores obj at endpoint and records its ID under key.
+func (a *account) create(key, endpoint string, obj interface{}) string {
+	resp, err := a.client.Save(obj, endpoint)
+	if err != nil {
+		a.t.Fatalf("creating %s: %s", key, err)
+	}
+	defer resp.Body.Close()
+
+	var id string
+	if location := resp.Header.Get("Location"); location != "" {
+		id = path.Base(location)
+	} else {
+		body, _ := ioutil.ReadAll(resp.Body)
+		var created interface{}
+		json.Unmarshal(body, &created)
+		if wrapped, ok := created.(map[string]interface{}); ok {
+			for _, list := range wrapped {
+				created = list
+			}
+		}
+		list, _ := created.([]interface{})
+		if len(list) == 0 {
+			a.t.Fatalf("creating %s: unexpected response %s", key, body)
+		}
+		id = fmt.Sprintf("%.0f", list[0].(map[string]interface{})["id"])
+	}
+	a.ids[key] = id
+	return id
+}
+
+func TestExport(t *testing.T) {
+	a := newAccount(t)
+	e := New(a.provider)
+	objects, err := e.Export(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(e.Problems) > 0 {
+		t.Errorf("unexpected problems %v", e.Problems)
+	}
+
+	var imports []string
+	for _, obj := range objects {
+		imports = append(imports, obj.Address()+" "+obj.ImportID)
+	}
+	expected := []string{
+		"constellix_domain.example_com " + a.ids["domain"],
+		"constellix_a_record.example_com_www domains:" + a.ids["domain"] + ":" + a.ids["www"],
+		"constellix_a_record.example_com_pooled domains:" + a.ids["domain"] + ":" + a.ids["pooled"],
+		"constellix_a_record.example_com_api domains:" + a.ids["domain"] + ":" + a.ids["api"],
+		"constellix_mx_record.example_com domains:" + a.ids["domain"] + ":" + a.ids["mx"],
+		"constellix_txt_record.example_com_txt domains:" + a.ids["domain"] + ":" + a.ids["txt"],
+		"constellix_template.web-template " + a.ids["template"],
+		"constellix_cname_record.web-template_www templates:" + a.ids["template"] + ":" + a.ids["template-www"],
+		"constellix_a_record_pool.web-pool " + a.ids["pool"],
+		"constellix_geo_filter.europe " + a.ids["geo-filter"],
+		"constellix_tags.production " + a.ids["tag"],
+		"constellix_contact_lists.ops " + a.ids["contact-list"],
+		"constellix_http_check.web " + a.ids["http-check"],
+	}
+	if !reflect.DeepEqual(imports, expected) {
+		t.Errorf("expected\n%s\ngot\n%s", strings.Join(expected, "\n"), strings.Join(imports, "\n"))
+	}
+
+	var b bytes.Buffer
+	if err := tfconfig.Write(&b, Blocks(objects, true)); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	config := b.String()
+	for _, ref := range []string{
+		"domain_id     = constellix_domain.example_com.id",
+		"domain_id     = constellix_template.web-template.id",
+		"pools         = [constellix_a_record_pool.web-pool.id]",
+	} {
+		if !strings.Contains(config, ref) {
+			t.Errorf("expected the configuration to have %q, got\n%s", ref, config)
+		}
+	}
+
+	ids := make(map[string]string)
+	for _, obj := range objects {
+		ids[obj.Address()+".id"] = obj.Data.Id()
+	}
+	resolve := func(ref string) interface{} {
+		if id, ok := ids[ref]; ok {
+			return id
+		}
+		return ref
+	}
+	blocks := tfconfigtest.Validate(t, a.provider, config, resolve)
+
+	// Planning the configuration against the imported state must show no
+	// changes.
+	states := make(map[string]*terraform.InstanceState)
+	for _, obj := range objects {
+		states[obj.Address()] = obj.Data.State()
+	}
+	for _, block := range blocks {
+		if block.Type != "resource" {
+			continue
+		}
+		address := block.Labels[0] + "." + block.Labels[1]
+		r := a.provider.ResourcesMap[block.Labels[0]]
+		diff, err := r.Diff(context.Background(), states[address], terraform.NewResourceConfigRaw(block.Config), a.provider.Meta())
+		if err != nil {
+			t.Errorf("%s: %s", address, err)
+			continue
+		}
+		if diff != nil && !diff.Empty() {
+			for attr, d := range diff.Attributes {
+				t.Errorf("%s: %s changes from %q to %q", address, attr, d.Old, d.New)
+			}
+		}
+	}
+
+	for _, block := range blocks {
+		if block.Type == "import" {
+			address, id := block.Config["to"], block.Config["id"]
+			if states[fmt.Sprint(address)] == nil || id == "" {
+				t.Errorf("unexpected import block %v", block.Config)
+			}
+		}
+	}
+}
+
+func TestExportListError(t *testing.T) {
+	a := newAccount(t)
+	a.server.Close()
+	e := New(a.provider)
+	if _, err := e.Export(context.Background()); err == nil || !strings.Contains(err.Error(), "listing v1/domains") {
+		t.Errorf("expected an error listing the domains, got %v", err)
+	}
+}
+
+// TestRecordResources checks that every record resource of the provider is
+// exported.
+func TestRecordResources(t *testing.T) {
+	exported := make(map[string]bool)
+	for _, rt := range recordResources {
+		exported[rt.resource] = true
+	}
+	for name := range constellix.Provider().ResourcesMap {
+		if strings.HasSuffix(name, "_record") && !exported[name] {
+			t.Errorf("%s is not exported", name)
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package fakeapi
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// zoneVersion is the content of a domain at one version of its history, or
+// of a snapshot of that version.
+type zoneVersion struct {
+	version   int
+	updatedTs string
+	// records are the records of the domain by record collection, e.g.
+	// "mx", and ID.
+	records map[string]map[int]map[string]interface{}
+}
+
+func (v *zoneVersion) object(domainID int) map[string]interface{} {
+	count := 0
+	for _, items := range v.records {
+		count += len(items)
+	}
+	return map[string]interface{}{
+		"domainId":    domainID,
+		"version":     v.version,
+		"updatedTs":   v.updatedTs,
+		"recordCount": count,
+	}
+}
+
+// recordVersion bumps the version of a domain and adds its current records
+// to its history, as the API does after every change of the domain.
+func (s *Server) recordVersion(domainID int) {
+	domain := s.collections["v1/domains"].items[domainID]
+	version := len(s.history[domainID]) + 1
+	domain["version"] = version
+
+	v := &zoneVersion{
+		version:   version,
+		updatedTs: time.Now().UTC().Format(time.RFC3339),
+		records:   make(map[string]map[int]map[string]interface{}),
+	}
+	for path := range recordKinds {
+		c := s.collections[fmt.Sprintf("v1/domains/%d/records/%s", domainID, path)]
+		if c == nil || len(c.items) == 0 {
+			continue
+		}
+		v.records[path] = make(map[int]map[string]interface{})
+		for id, item := range c.items {
+			v.records[path][id] = copyObject(item)
+		}
+	}
+	s.history[domainID] = append(s.history[domainID], v)
+}
+
+// changed records a version of the domain whose records rt refers to, if
+// any.
+func (s *Server) changed(rt route) {
+	if !strings.HasPrefix(rt.parent, "v1/domains/") {
+		return
+	}
+	if id, err := strconv.Atoi(strings.TrimPrefix(rt.parent, "v1/domains/")); err == nil {
+		s.recordVersion(id)
+	}
+}
+
+// serveHistory serves the history and snapshots of domains:
+//
+//	GET    v1/domains/{id}/history
+//	POST   v1/domains/{id}/history/{version}/snapshot
+//	GET    v1/domains/{id}/snapshots
+//	GET    v1/domains/{id}/snapshots/{version}
+//	DELETE v1/domains/{id}/snapshots/{version}
+//	POST   v1/domains/{id}/snapshots/{version}/apply
+//
+// It reports whether path is one of them.
+func (s *Server) serveHistory(w http.ResponseWriter, r *http.Request, path string) bool {
+	segs := strings.Split(path, "/")
+	if len(segs) < 4 || segs[0] != "v1" || segs[1] != "domains" || (segs[3] != "history" && segs[3] != "snapshots") {
+		return false
+	}
+	domainID, err := strconv.Atoi(segs[2])
+	if err != nil {
+		return false
+	}
+	if _, ok := s.findDomain(w, segs[2]); !ok {
+		return true
+	}
+
+	var version int
+	if len(segs) > 4 {
+		if version, err = strconv.Atoi(segs[4]); err != nil {
+			writeErrors(w, http.StatusNotFound, "Resource not found")
+			return true
+		}
+	}
+	snapshot := s.snapshots[domainID][version]
+
+	switch endpoint := strings.Join(segs[3:], "/"); {
+	case r.Method == http.MethodGet && endpoint == "history":
+		list := make([]interface{}, 0, len(s.history[domainID]))
+		for _, v := range s.history[domainID] {
+			list = append(list, v.object(domainID))
+		}
+		writePage(w, r, list)
+	case r.Method == http.MethodPost && len(segs) == 6 && segs[3] == "history" && segs[5] == "snapshot":
+		if version < 1 || version > len(s.history[domainID]) {
+			writeErrors(w, http.StatusNotFound, fmt.Sprintf("Version %d of the domain not found", version))
+			return true
+		}
+		if snapshot != nil {
+			writeErrors(w, http.StatusBadRequest, fmt.Sprintf("A snapshot of version %d already exists", version))
+			return true
+		}
+		if s.snapshots[domainID] == nil {
+			s.snapshots[domainID] = make(map[int]*zoneVersion)
+		}
+		v := s.history[domainID][version-1]
+		s.snapshots[domainID][version] = v
+		writeJSON(w, http.StatusOK, v.object(domainID))
+	case r.Method == http.MethodGet && endpoint == "snapshots":
+		versions := make([]int, 0, len(s.snapshots[domainID]))
+		for version := range s.snapshots[domainID] {
+			versions = append(versions, version)
+		}
+		sort.Ints(versions)
+		list := make([]interface{}, 0, len(versions))
+		for _, version := range versions {
+			list = append(list, s.snapshots[domainID][version].object(domainID))
+		}
+		writePage(w, r, list)
+	case segs[3] == "snapshots" && snapshot == nil:
+		writeErrors(w, http.StatusNotFound, "Snapshot not found")
+	case r.Method == http.MethodGet && len(segs) == 5:
+		writeJSON(w, http.StatusOK, snapshot.object(domainID))
+	case r.Method == http.MethodDelete && len(segs) == 5:
+		delete(s.snapshots[domainID], version)
+		writeJSON(w, http.StatusOK, map[string]interface{}{"success": "Snapshot deleted successfully"})
+	case r.Method == http.MethodPost && len(segs) == 6 && segs[5] == "apply":
+		s.restore(domainID, snapshot)
+		writeJSON(w, http.StatusOK, map[string]interface{}{"success": "Snapshot applied successfully"})
+	default:
+		writeErrors(w, http.StatusMethodNotAllowed, r.Method+" is not supported on "+path)
+	}
+	return true
+}
+
+// restore replaces the records of a domain with those of v, keeping their
+// IDs, and records the result as a new version.
+func (s *Server) restore(domainID int, v *zoneVersion) {
+	for path, k := range recordKinds {
+		collPath := fmt.Sprintf("v1/domains/%d/records/%s", domainID, path)
+		delete(s.collections, collPath)
+		if len(v.records[path]) == 0 {
+			continue
+		}
+		c := s.collection(collPath, k)
+		for id, item := range v.records[path] {
+			c.items[id] = copyObject(item)
+		}
+	}
+	s.recordVersion(domainID)
+}
+
+// writePage answers with the page of list the offset and limit parameters
+// select.
+func writePage(w http.ResponseWriter, r *http.Request, list []interface{}) {
+	if from, to, ok := pageBounds(w, r, len(list)); ok {
+		writeJSON(w, http.StatusOK, list[from:to])
+	}
+}
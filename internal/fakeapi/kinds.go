@@ -0,0 +1,335 @@
+package fakeapi
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type apiFamily int
+
+const (
+	dnsV1API apiFamily = iota
+	dnsV2API
+	sonarAPI
+)
+
+// kind describes how the API treats the objects of one collection.
+type kind struct {
+	api apiFamily
+	// required lists the fields a create (or replacing update) must send.
+	required []string
+	// defaults returns the fields the API fills in when they are not sent.
+	defaults func(rt route) map[string]interface{}
+	// replace makes an update replace the stored object instead of merging
+	// the sent fields into it.
+	replace bool
+	// namesField is set for collections that are created from a list of
+	// names, one object per name.
+	namesField string
+	// immutable lists fields an update cannot change; the API ignores them.
+	immutable []string
+	// single makes a v1 create answer with the created object instead of a
+	// list holding it.
+	single bool
+	// successField wraps the created objects in v2 responses.
+	successField string
+	// rename maps field names the API accepts on input to the names it
+	// answers with.
+	rename map[string]string
+}
+
+func (k *kind) validate(body map[string]interface{}) error {
+	for _, field := range k.required {
+		if _, ok := body[field]; !ok {
+			return fmt.Errorf("%s is required", field)
+		}
+	}
+	return nil
+}
+
+// normalize returns the object to store for body. existing is the stored
+// object on updates and nil on creates.
+func (k *kind) normalize(body, existing map[string]interface{}, rt route) map[string]interface{} {
+	var obj map[string]interface{}
+	if existing == nil || k.replace {
+		obj = map[string]interface{}{"name": ""}
+		if k.defaults != nil {
+			for key, value := range k.defaults(rt) {
+				obj[key] = value
+			}
+		}
+	} else {
+		obj = copyObject(existing)
+	}
+
+	for key, value := range copyObject(body) {
+		if to, ok := k.rename[key]; ok {
+			key = to
+		}
+		if existing != nil && k.isImmutable(key) {
+			continue
+		}
+		if key == k.namesField && key != "name" {
+			continue
+		}
+		if key == "name" {
+			if names, ok := value.([]interface{}); ok {
+				if len(names) == 0 {
+					continue
+				}
+				value = fmt.Sprintf("%v", names[0])
+			}
+		}
+		// Nested objects such as the SOA of a domain are merged field by
+		// field, like the API does.
+		if nested, ok := value.(map[string]interface{}); ok && !k.replace {
+			if current, ok := obj[key].(map[string]interface{}); ok {
+				for nk, nv := range nested {
+					current[nk] = nv
+				}
+				continue
+			}
+		}
+		obj[key] = value
+	}
+	coerceBools(obj)
+	return obj
+}
+
+func (k *kind) isImmutable(field string) bool {
+	for _, f := range k.immutable {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// boolFields are answered as booleans even when they are sent as the strings
+// "true" and "false", which the API accepts.
+var boolFields = map[string]bool{
+	"disableFlag": true,
+	"disabled":    true,
+	"noAnswer":    true,
+	"skipLookup":  true,
+	"drop":        true,
+}
+
+func coerceBools(v interface{}) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if s, ok := value.(string); ok && boolFields[key] {
+				if b, err := strconv.ParseBool(s); err == nil {
+					v[key] = b
+				}
+				continue
+			}
+			coerceBools(value)
+		}
+	case []interface{}:
+		for _, value := range v {
+			coerceBools(value)
+		}
+	}
+}
+
+var topLevelKinds = map[string]*kind{
+	"v1/domains": {
+		namesField: "names",
+		defaults: func(route) map[string]interface{} {
+			return map[string]interface{}{
+				"typeId":          1,
+				"status":          "ACTIVE",
+				"disabled":        false,
+				"hasGeoIP":        false,
+				"hasGtdRegions":   false,
+				"nameserverGroup": 1,
+				"nameservers":     []string{"ns11.constellix.com.", "ns21.constellix.com.", "ns31.constellix.com."},
+				"note":            "",
+				"tags":            []interface{}{},
+				"version":         0,
+				"soa": map[string]interface{}{
+					"primaryNameserver": "ns11.constellix.com.",
+					"email":             "dns.constellix.com.",
+					"ttl":               86400,
+					"serial":            2020010101,
+					"refresh":           86400,
+					"retry":             7200,
+					"expire":            3600000,
+					"negCache":          180,
+				},
+			}
+		},
+	},
+	"v1/templates": {
+		namesField: "name",
+		defaults: func(route) map[string]interface{} {
+			return map[string]interface{}{
+				"version":       1,
+				"hasGeoIP":      false,
+				"hasGtdRegions": false,
+			}
+		},
+	},
+	"v1/secondaryDomains": {
+		required: []string{"name", "masters"},
+		defaults: func(route) map[string]interface{} {
+			return map[string]interface{}{
+				"note":           "",
+				"disabled":       false,
+				"allowNotify":    []interface{}{},
+				"tsigKeyId":      nil,
+				"transferStatus": "PENDING",
+				"serial":         0,
+				"lastTransfer":   nil,
+			}
+		},
+	},
+	"v1/pools/A":     poolKind("A"),
+	"v1/pools/AAAA":  poolKind("AAAA"),
+	"v1/pools/CNAME": poolKind("CNAME"),
+	"v1/geoFilters": {
+		required: []string{"name"},
+		replace:  true,
+		rename:   map[string]string{"ipaddrs": "ipAddresses"},
+		defaults: func(route) map[string]interface{} {
+			return map[string]interface{}{
+				"regions":          []interface{}{},
+				"geoipContinents":  []interface{}{},
+				"geoipCountries":   []interface{}{},
+				"asn":              []interface{}{},
+				"filterRulesLimit": 100,
+			}
+		},
+	},
+	"v1/geoProximities": {
+		replace: true,
+	},
+	"v1/vanityNameservers": {
+		required: []string{"name", "nameserverGroup"},
+		replace:  true,
+		single:   true,
+		defaults: func(route) map[string]interface{} {
+			return map[string]interface{}{
+				"isDefault":           false,
+				"isPublic":            false,
+				"nameserverGroupName": "Default",
+			}
+		},
+	},
+	"v2/tags": {
+		api:          dnsV2API,
+		required:     []string{"name"},
+		replace:      true,
+		successField: "successTags",
+	},
+	"v2/contactLists": {
+		api:          dnsV2API,
+		required:     []string{"name", "emailAddresses"},
+		replace:      true,
+		successField: "successContactLists",
+		defaults: func(route) map[string]interface{} {
+			return map[string]interface{}{"emailAddresses": []interface{}{}}
+		},
+	},
+	"rest/api/http": checkKind("HTTP", []string{"host", "ipVersion", "protocolType"}, "host", "port", "protocolType", "checkSites"),
+	"rest/api/tcp":  checkKind("TCP", []string{"host", "ipVersion"}, "host", "port", "checkSites"),
+	"rest/api/dns":  checkKind("DNS", []string{"fqdn", "resolver"}, "fqdn", "resolver", "checkSites"),
+}
+
+func poolKind(poolType string) *kind {
+	return &kind{
+		required: []string{"name", "numReturn", "minAvailableFailover", "values"},
+		replace:  true,
+		defaults: func(route) map[string]interface{} {
+			return map[string]interface{}{
+				"type":    poolType,
+				"values":  []interface{}{},
+				"note":    "",
+				"version": 1,
+			}
+		},
+	}
+}
+
+// checkKind describes a Sonar check. Updates are merged into the stored check
+// and cannot change what is checked.
+func checkKind(checkType string, immutable []string, required ...string) *kind {
+	return &kind{
+		api:       sonarAPI,
+		required:  required,
+		immutable: immutable,
+		defaults: func(route) map[string]interface{} {
+			return map[string]interface{}{
+				"type":                  checkType,
+				"checkSites":            []interface{}{},
+				"notificationGroups":    []interface{}{},
+				"interval":              "ONEMINUTE",
+				"monitorIntervalPolicy": "PARALLEL",
+				"verificationPolicy":    "SIMPLE",
+			}
+		},
+	}
+}
+
+// recordKinds are keyed by the record type segment of
+// v1/{domains,templates}/{id}/records/{type}.
+var recordKinds = map[string]*kind{
+	"a":               recordKind("A", true, "roundRobinFailover", "pools", "contactIds"),
+	"aaaa":            recordKind("AAAA", true, "roundRobinFailover", "pools", "contactIds"),
+	"aname":           recordKind("ANAME", true, "pools", "contactIds"),
+	"cname":           recordKind("CNAME", true, "pools", "contactIds"),
+	"caa":             recordKind("CAA", false),
+	"cert":            recordKind("CERT", false),
+	"hinfo":           recordKind("HINFO", false),
+	"httpredirection": recordKind("HTTPRedirection", false),
+	"mx":              recordKind("MX", false),
+	"naptr":           recordKind("NAPTR", false),
+	"ns":              recordKind("NS", false),
+	"ptr":             recordKind("PTR", false),
+	"rp":              recordKind("RP", false),
+	"spf":             recordKind("SPF", false),
+	"srv":             recordKind("SRV", false),
+	"txt":             recordKind("TXT", false),
+	"tlsa":            recordKind("TLSA", false),
+	"sshfp":           recordKind("SSHFP", false),
+	"https":           recordKind("HTTPS", false),
+	"svcb":            recordKind("SVCB", false),
+}
+
+// recordKind describes a record type. Records with a record option default
+// to round robin; lists names the list fields the API always answers with.
+func recordKind(recordType string, withOption bool, lists ...string) *kind {
+	return &kind{
+		required: []string{"ttl"},
+		replace:  true,
+		defaults: func(rt route) map[string]interface{} {
+			segs := strings.Split(rt.collection, "/")
+			parentID, _ := strconv.Atoi(segs[2])
+			source := "Domain"
+			if segs[1] == "templates" {
+				source = "Template"
+			}
+			obj := map[string]interface{}{
+				"type":      recordType,
+				"note":      "",
+				"gtdRegion": 1,
+				"parentId":  parentID,
+				"parent":    strings.ToLower(source),
+				"source":    source,
+			}
+			if recordType != "CNAME" && recordType != "HTTPRedirection" {
+				obj["roundRobin"] = []interface{}{}
+			}
+			if withOption {
+				obj["recordOption"] = "roundRobin"
+			}
+			for _, list := range lists {
+				obj[list] = []interface{}{}
+			}
+			return obj
+		},
+	}
+}
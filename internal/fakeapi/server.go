@@ -0,0 +1,585 @@
+// Package fakeapi implements an in-memory stand-in for the Constellix DNS and
+// Sonar REST APIs. It keeps the objects it is sent, answers with the shapes the
+// real APIs use and rejects requests without a valid security token, so the
+// provider's create, read, update, import and delete logic can be exercised
+// without credentials or network access.
+package fakeapi
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// APIKey and SecretKey are the credentials the server accepts.
+const (
+	APIKey    = "fake-api-key"
+	SecretKey = "fake-secret-key"
+)
+
+// tokenMaxAge is how far the timestamp of a security token may be off from
+// the server's clock.
+const tokenMaxAge = 5 * time.Minute
+
+// Server is a fake Constellix API. Both the DNS API (v1, v2 and v4 paths) and
+// the Sonar API (rest/api paths) are served from URL.
+type Server struct {
+	*httptest.Server
+
+	mu          sync.Mutex
+	nextID      int
+	collections map[string]*collection
+	requests    []string
+	// history holds the versions of each domain by domain ID, oldest first,
+	// and snapshots the snapshots of them by domain ID and version.
+	history   map[int][]*zoneVersion
+	snapshots map[int]map[int]*zoneVersion
+	// missing holds the paths answered with 404 whatever they hold.
+	missing map[string]bool
+	// rollovers counts the key rollovers of each domain by domain ID.
+	rollovers map[int]int
+}
+
+// collection holds the objects stored under one API path, e.g. "v1/pools/A"
+// or "v1/domains/1/records/mx".
+type collection struct {
+	kind  *kind
+	items map[int]map[string]interface{}
+}
+
+// NewServer starts a fake API. The caller must Close it.
+func NewServer() *Server {
+	s := &Server{
+		nextID:      1000,
+		collections: make(map[string]*collection),
+		history:     make(map[int][]*zoneVersion),
+		snapshots:   make(map[int]map[int]*zoneVersion),
+		missing:     make(map[string]bool),
+		rollovers:   make(map[int]int),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Object returns a copy of the object stored at path, for example
+// "v1/domains/1000/records/a/1001" or "rest/api/http/1002".
+func (s *Server) Object(path string) (map[string]interface{}, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	collPath, id, ok := splitItemPath(strings.Trim(path, "/"))
+	if !ok {
+		return nil, false
+	}
+	c := s.collections[collPath]
+	if c == nil || c.items[id] == nil {
+		return nil, false
+	}
+	return copyObject(c.items[id]), true
+}
+
+// Requests returns the "METHOD path" of every request served so far,
+// including rejected ones.
+func (s *Server) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.requests...)
+}
+
+// NotFound makes the server answer 404 at path, for example
+// "v1/domains/1000/records/https", as the API does for record types it does
+// not serve for every account.
+func (s *Server) NotFound(path string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.missing[strings.Trim(path, "/")] = true
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	path := strings.Trim(r.URL.Path, "/")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = append(s.requests, r.Method+" "+path)
+
+	if err := checkToken(r.Header.Get("x-cns-security-token"), time.Now()); err != nil {
+		writeErrors(w, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	var body map[string]interface{}
+	if r.Method == http.MethodPost || r.Method == http.MethodPut {
+		if ct := r.Header.Get("Content-Type"); !strings.HasPrefix(ct, "application/json") {
+			writeErrors(w, http.StatusUnsupportedMediaType, "Content-Type must be application/json")
+			return
+		}
+		raw, _ := ioutil.ReadAll(r.Body)
+		if err := json.Unmarshal(raw, &body); err != nil {
+			writeErrors(w, http.StatusBadRequest, "Malformed JSON: "+err.Error())
+			return
+		}
+	}
+
+	if s.missing[path] {
+		writeErrors(w, http.StatusNotFound, "Resource not found")
+		return
+	}
+
+	// v4 is only used to enable and disable domains and their DNSSEC, and
+	// to roll the keys of the latter over.
+	if strings.HasPrefix(path, "v4/domains/") {
+		if idStr := strings.TrimPrefix(path, "v4/domains/"); strings.HasSuffix(idStr, "/dnssec") {
+			s.getDNSSEC(w, r, strings.TrimSuffix(idStr, "/dnssec"))
+		} else if strings.HasSuffix(idStr, "/dnssec/rollover") {
+			s.rolloverDNSSEC(w, r, strings.TrimSuffix(idStr, "/dnssec/rollover"))
+		} else {
+			s.updateDomainV4(w, r, idStr, body)
+		}
+		return
+	}
+
+	if s.serveHistory(w, r, path) {
+		return
+	}
+
+	rt, ok := s.route(path)
+	if !ok {
+		writeErrors(w, http.StatusNotFound, "Resource not found")
+		return
+	}
+	if rt.parent != "" && !s.exists(rt.parent) {
+		writeErrors(w, http.StatusNotFound, "Parent "+rt.parent+" not found")
+		return
+	}
+
+	switch {
+	case rt.hasID && r.Method == http.MethodGet:
+		s.getItem(w, rt)
+	case rt.hasID && r.Method == http.MethodPut:
+		s.updateItem(w, rt, body)
+	case rt.hasID && r.Method == http.MethodDelete:
+		s.deleteItem(w, rt)
+	case !rt.hasID && r.Method == http.MethodGet:
+		s.listItems(w, r, rt)
+	case !rt.hasID && r.Method == http.MethodPost:
+		s.createItem(w, rt, body)
+	default:
+		writeErrors(w, http.StatusMethodNotAllowed, r.Method+" is not supported on "+path)
+	}
+}
+
+// route describes the collection (and optionally the item) a path refers to.
+type route struct {
+	collection string
+	kind       *kind
+	id         int
+	hasID      bool
+	// parent is the path of the domain or template owning a record
+	// collection; it must exist.
+	parent string
+}
+
+func (s *Server) route(path string) (route, bool) {
+	collPath, k, ok := matchCollection(path)
+	if ok {
+		return route{collection: collPath, kind: k, parent: recordParent(collPath)}, true
+	}
+	collPath, id, ok := splitItemPath(path)
+	if !ok {
+		return route{}, false
+	}
+	collPath, k, ok = matchCollection(collPath)
+	if !ok {
+		return route{}, false
+	}
+	return route{collection: collPath, kind: k, id: id, hasID: true, parent: recordParent(collPath)}, true
+}
+
+// splitItemPath splits "a/b/123" into "a/b" and 123.
+func splitItemPath(path string) (string, int, bool) {
+	i := strings.LastIndex(path, "/")
+	if i < 0 {
+		return "", 0, false
+	}
+	id, err := strconv.Atoi(path[i+1:])
+	if err != nil {
+		return "", 0, false
+	}
+	return path[:i], id, true
+}
+
+// matchCollection reports whether path names a collection the fake serves
+// and returns its kind.
+func matchCollection(path string) (string, *kind, bool) {
+	if k, ok := topLevelKinds[path]; ok {
+		return path, k, true
+	}
+	segs := strings.Split(path, "/")
+	if len(segs) == 5 && segs[0] == "v1" && (segs[1] == "domains" || segs[1] == "templates") && segs[3] == "records" {
+		if _, err := strconv.Atoi(segs[2]); err != nil {
+			return "", nil, false
+		}
+		if k, ok := recordKinds[segs[4]]; ok {
+			return path, k, true
+		}
+	}
+	return "", nil, false
+}
+
+// recordParent returns the path of the domain or template a record
+// collection belongs to, or "" for other collections.
+func recordParent(collPath string) string {
+	if i := strings.Index(collPath, "/records/"); i >= 0 {
+		return collPath[:i]
+	}
+	return ""
+}
+
+func (s *Server) exists(path string) bool {
+	collPath, id, ok := splitItemPath(path)
+	if !ok {
+		return false
+	}
+	c := s.collections[collPath]
+	return c != nil && c.items[id] != nil
+}
+
+func (s *Server) collection(path string, k *kind) *collection {
+	c := s.collections[path]
+	if c == nil {
+		c = &collection{kind: k, items: make(map[int]map[string]interface{})}
+		s.collections[path] = c
+	}
+	return c
+}
+
+func (s *Server) getItem(w http.ResponseWriter, rt route) {
+	c := s.collections[rt.collection]
+	if c == nil || c.items[rt.id] == nil {
+		writeErrors(w, http.StatusNotFound, "Record not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, c.items[rt.id])
+}
+
+// listItems answers with the objects of a collection ordered by ID, or with
+// the page of them the offset and limit parameters select.
+func (s *Server) listItems(w http.ResponseWriter, r *http.Request, rt route) {
+	c := s.collection(rt.collection, rt.kind)
+	ids := make([]int, 0, len(c.items))
+	for id := range c.items {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	from, to, ok := pageBounds(w, r, len(ids))
+	if !ok {
+		return
+	}
+	list := make([]interface{}, 0, to-from)
+	for _, id := range ids[from:to] {
+		list = append(list, c.items[id])
+	}
+	writeJSON(w, http.StatusOK, list)
+}
+
+// pageBounds returns the range of a list of n objects the offset and limit
+// parameters select, the whole list without them. Invalid parameters are
+// answered with 400.
+func pageBounds(w http.ResponseWriter, r *http.Request, n int) (int, int, bool) {
+	query := r.URL.Query()
+	if query.Get("limit") == "" {
+		return 0, n, true
+	}
+	offset, err1 := strconv.Atoi(query.Get("offset"))
+	limit, err2 := strconv.Atoi(query.Get("limit"))
+	if err1 != nil || err2 != nil || offset < 0 || limit <= 0 {
+		writeErrors(w, http.StatusBadRequest, "invalid offset or limit")
+		return 0, 0, false
+	}
+	if offset > n {
+		offset = n
+	}
+	if offset+limit < n {
+		return offset, offset + limit, true
+	}
+	return offset, n, true
+}
+
+func (s *Server) createItem(w http.ResponseWriter, rt route, body map[string]interface{}) {
+	c := s.collection(rt.collection, rt.kind)
+
+	// Domains and templates are created in bulk from a list of names.
+	var objects []map[string]interface{}
+	if rt.kind.namesField != "" {
+		names, _ := body[rt.kind.namesField].([]interface{})
+		if len(names) == 0 {
+			writeErrors(w, http.StatusBadRequest, rt.kind.namesField+" is required")
+			return
+		}
+		label := "Domain"
+		if rt.collection == "v1/templates" {
+			label = "Template"
+		}
+		for _, name := range names {
+			if id, ok := findByName(c, fmt.Sprintf("%v", name)); ok {
+				writeErrors(w, http.StatusBadRequest, fmt.Sprintf("%v already exists, %s Id: %d", name, label, id))
+				return
+			}
+		}
+		for _, name := range names {
+			obj := copyObject(body)
+			delete(obj, rt.kind.namesField)
+			obj["name"] = fmt.Sprintf("%v", name)
+			objects = append(objects, obj)
+		}
+	} else {
+		if err := rt.kind.validate(body); err != nil {
+			writeErrors(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		objects = append(objects, copyObject(body))
+	}
+
+	created := make([]interface{}, 0, len(objects))
+	for _, obj := range objects {
+		s.nextID++
+		stored := rt.kind.normalize(obj, nil, rt)
+		stored["id"] = s.nextID
+		c.items[s.nextID] = stored
+		created = append(created, stored)
+		if rt.collection == "v1/domains" {
+			s.recordVersion(s.nextID)
+		}
+	}
+	s.changed(rt)
+
+	switch rt.kind.api {
+	case sonarAPI:
+		location := s.URL + "/" + rt.collection + "/" + strconv.Itoa(s.nextID)
+		w.Header().Set("Location", location)
+		w.WriteHeader(http.StatusCreated)
+	case dnsV2API:
+		writeJSON(w, http.StatusOK, map[string]interface{}{rt.kind.successField: created})
+	default:
+		if rt.kind.single {
+			writeJSON(w, http.StatusOK, created[0])
+			return
+		}
+		writeJSON(w, http.StatusOK, created)
+	}
+}
+
+func (s *Server) updateItem(w http.ResponseWriter, rt route, body map[string]interface{}) {
+	c := s.collections[rt.collection]
+	if c == nil || c.items[rt.id] == nil {
+		writeErrors(w, http.StatusNotFound, "Record not found")
+		return
+	}
+	if rt.kind.replace {
+		if err := rt.kind.validate(body); err != nil {
+			writeErrors(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+	stored := rt.kind.normalize(body, c.items[rt.id], rt)
+	stored["id"] = rt.id
+	c.items[rt.id] = stored
+	s.changed(rt)
+
+	if rt.kind.api == sonarAPI {
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"success": "Record updated successfully"})
+}
+
+func (s *Server) deleteItem(w http.ResponseWriter, rt route) {
+	c := s.collections[rt.collection]
+	if c == nil || c.items[rt.id] == nil {
+		writeErrors(w, http.StatusNotFound, "Record not found")
+		return
+	}
+	delete(c.items, rt.id)
+	s.changed(rt)
+	if rt.collection == "v1/domains" {
+		delete(s.history, rt.id)
+		delete(s.snapshots, rt.id)
+	}
+
+	// Deleting a domain or template deletes its records too.
+	prefix := rt.collection + "/" + strconv.Itoa(rt.id) + "/"
+	for path := range s.collections {
+		if strings.HasPrefix(path, prefix) {
+			delete(s.collections, path)
+		}
+	}
+
+	if rt.kind.api == sonarAPI {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"success": "Record deleted successfully"})
+}
+
+// findDomain returns the domain with the ID in idStr, or answers with 404.
+func (s *Server) findDomain(w http.ResponseWriter, idStr string) (map[string]interface{}, bool) {
+	id, err := strconv.Atoi(idStr)
+	c := s.collections["v1/domains"]
+	if err != nil || c == nil || c.items[id] == nil {
+		writeErrors(w, http.StatusNotFound, "Domain not found")
+		return nil, false
+	}
+	return c.items[id], true
+}
+
+// updateDomainV4 enables or disables a domain, with the enabled field, or
+// its DNSSEC, with the dnssec field.
+func (s *Server) updateDomainV4(w http.ResponseWriter, r *http.Request, idStr string, body map[string]interface{}) {
+	domain, ok := s.findDomain(w, idStr)
+	if !ok {
+		return
+	}
+	if r.Method != http.MethodPut {
+		writeErrors(w, http.StatusMethodNotAllowed, r.Method+" is not supported")
+		return
+	}
+	enabled, hasEnabled := body["enabled"].(bool)
+	dnssec, hasDNSSEC := body["dnssec"].(bool)
+	if !hasEnabled && !hasDNSSEC {
+		writeErrors(w, http.StatusBadRequest, "enabled or dnssec must be a boolean")
+		return
+	}
+	if hasEnabled {
+		domain["disabled"] = !enabled
+	}
+	if hasDNSSEC {
+		domain["dnssec"] = dnssec
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"data": domain})
+}
+
+// getDNSSEC answers with the DNSSEC status of a domain and, while it is
+// signed, its key signing key with the DS digest of it.
+func (s *Server) getDNSSEC(w http.ResponseWriter, r *http.Request, idStr string) {
+	domain, ok := s.findDomain(w, idStr)
+	if !ok {
+		return
+	}
+	if r.Method != http.MethodGet {
+		writeErrors(w, http.StatusMethodNotAllowed, r.Method+" is not supported")
+		return
+	}
+	enabled, _ := domain["dnssec"].(bool)
+	keys := make([]interface{}, 0, 1)
+	if enabled {
+		id, _ := strconv.Atoi(idStr)
+		// Every rollover derives another key.
+		seed := fmt.Sprintf("%v/%d", domain["name"], id)
+		if n := s.rollovers[id]; n > 0 {
+			seed += fmt.Sprintf("/%d", n)
+		}
+		digest := sha256.Sum256([]byte(seed))
+		keys = append(keys, map[string]interface{}{
+			"keyTag":    (id + s.rollovers[id]) % 65536,
+			"flags":     257,
+			"protocol":  3,
+			"algorithm": 13,
+			"publicKey": base64.StdEncoding.EncodeToString(digest[:]),
+			"ds": []interface{}{
+				map[string]interface{}{"digestType": 2, "digest": strings.ToUpper(hex.EncodeToString(digest[:]))},
+			},
+		})
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"data": map[string]interface{}{"enabled": enabled, "keys": keys}})
+}
+
+// rolloverDNSSEC replaces the key signing key of a signed domain.
+func (s *Server) rolloverDNSSEC(w http.ResponseWriter, r *http.Request, idStr string) {
+	domain, ok := s.findDomain(w, idStr)
+	if !ok {
+		return
+	}
+	if r.Method != http.MethodPost {
+		writeErrors(w, http.StatusMethodNotAllowed, r.Method+" is not supported")
+		return
+	}
+	if enabled, _ := domain["dnssec"].(bool); !enabled {
+		writeErrors(w, http.StatusBadRequest, "DNSSEC is not enabled")
+		return
+	}
+	id, _ := strconv.Atoi(idStr)
+	s.rollovers[id]++
+	writeJSON(w, http.StatusOK, map[string]interface{}{"success": "Key rollover started"})
+}
+
+func findByName(c *collection, name string) (int, bool) {
+	for id, obj := range c.items {
+		if obj["name"] == name {
+			return id, true
+		}
+	}
+	return 0, false
+}
+
+// checkToken verifies a security token of the form
+// "apiKey:base64(hmac-sha1(secretKey, timestamp)):timestamp", where timestamp
+// is the time of the request in milliseconds since the epoch.
+func checkToken(token string, now time.Time) error {
+	parts := strings.Split(token, ":")
+	if len(parts) != 3 {
+		return fmt.Errorf("Invalid security token format")
+	}
+	if parts[0] != APIKey {
+		return fmt.Errorf("Invalid API key")
+	}
+	millis, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil {
+		return fmt.Errorf("Invalid security token timestamp")
+	}
+	age := now.Sub(time.Unix(0, millis*int64(time.Millisecond)))
+	if age > tokenMaxAge || age < -tokenMaxAge {
+		return fmt.Errorf("Security token has expired")
+	}
+	mac := hmac.New(sha1.New, []byte(SecretKey))
+	mac.Write([]byte(parts[2]))
+	expected := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+	if !hmac.Equal([]byte(parts[1]), []byte(expected)) {
+		return fmt.Errorf("Invalid security token signature")
+	}
+	return nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	// The provider parses some bodies positionally, so unlike json.Encoder
+	// no trailing newline is written.
+	raw, _ := json.Marshal(v)
+	w.Write(raw)
+}
+
+func writeErrors(w http.ResponseWriter, status int, messages ...string) {
+	writeJSON(w, status, map[string]interface{}{"errors": messages})
+}
+
+// copyObject returns a deep copy of a decoded JSON object.
+func copyObject(obj map[string]interface{}) map[string]interface{} {
+	raw, _ := json.Marshal(obj)
+	var out map[string]interface{}
+	json.Unmarshal(raw, &out)
+	if out == nil {
+		out = make(map[string]interface{})
+	}
+	return out
+}
@@ -0,0 +1,300 @@
+package fakeapi
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func token(apiKey, secretKey string, at time.Time) string {
+	ts := strconv.FormatInt(at.UnixNano()/int64(time.Millisecond), 10)
+	mac := hmac.New(sha1.New, []byte(secretKey))
+	mac.Write([]byte(ts))
+	return apiKey + ":" + base64.StdEncoding.EncodeToString(mac.Sum(nil)) + ":" + ts
+}
+
+func TestCheckToken(t *testing.T) {
+	now := time.Now()
+	cases := []struct {
+		name  string
+		token string
+		ok    bool
+	}{
+		{"valid", token(APIKey, SecretKey, now), true},
+		{"wrong api key", token("other", SecretKey, now), false},
+		{"wrong secret", token(APIKey, "other", now), false},
+		{"expired", token(APIKey, SecretKey, now.Add(-time.Hour)), false},
+		{"missing parts", APIKey + ":signature", false},
+		{"bad timestamp", APIKey + ":signature:yesterday", false},
+		{"empty", "", false},
+	}
+	for _, tc := range cases {
+		err := checkToken(tc.token, now)
+		if (err == nil) != tc.ok {
+			t.Errorf("%s: checkToken returned %v", tc.name, err)
+		}
+	}
+}
+
+// do sends a request signed with the fake's credentials.
+func do(t *testing.T, s *Server, method, path, body string) (*http.Response, string) {
+	req, err := http.NewRequest(method, s.URL+"/"+path, strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("x-cns-security-token", token(APIKey, SecretKey, time.Now()))
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	defer resp.Body.Close()
+	raw, _ := ioutil.ReadAll(resp.Body)
+	return resp, string(raw)
+}
+
+func TestServerRejectsUnsignedRequests(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	resp, err := http.Get(s.URL + "/v1/domains")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("expected 401, got %d", resp.StatusCode)
+	}
+}
+
+func TestServerDomainAndRecordLifecycle(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	resp, body := do(t, s, "POST", "v1/domains", `{"names":["example.com"]}`)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", resp.StatusCode, body)
+	}
+	var created []map[string]interface{}
+	if err := json.Unmarshal([]byte(body), &created); err != nil || len(created) != 1 {
+		t.Fatalf("expected a list with one domain, got %s", body)
+	}
+	domainID := strconv.Itoa(int(created[0]["id"].(float64)))
+	if created[0]["name"] != "example.com" || created[0]["soa"] == nil {
+		t.Errorf("unexpected domain %s", body)
+	}
+
+	resp, body = do(t, s, "POST", "v1/domains", `{"names":["example.com"]}`)
+	if resp.StatusCode != http.StatusBadRequest || !strings.Contains(body, "already exists, Domain Id: "+domainID) {
+		t.Errorf("expected a duplicate domain to be refused, got %d: %s", resp.StatusCode, body)
+	}
+
+	resp, body = do(t, s, "POST", "v1/domains/"+domainID+"/records/a", `{"name":"www","ttl":300,"roundRobin":[{"value":"10.0.0.1"}]}`)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", resp.StatusCode, body)
+	}
+	json.Unmarshal([]byte(body), &created)
+	recordPath := "v1/domains/" + domainID + "/records/a/" + strconv.Itoa(int(created[0]["id"].(float64)))
+
+	record, ok := s.Object(recordPath)
+	if !ok {
+		t.Fatalf("expected %s to be stored", recordPath)
+	}
+	if record["type"] != "A" || record["recordOption"] != "roundRobin" || record["roundRobinFailover"] == nil {
+		t.Errorf("expected the API defaults to be filled in, got %v", record)
+	}
+
+	// Updates of records replace the stored record.
+	if resp, body = do(t, s, "PUT", recordPath, `{"name":"www","ttl":600,"roundRobin":[]}`); resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", resp.StatusCode, body)
+	}
+	record, _ = s.Object(recordPath)
+	if record["ttl"] != float64(600) || len(record["roundRobin"].([]interface{})) != 0 {
+		t.Errorf("unexpected record after update: %v", record)
+	}
+
+	if resp, body = do(t, s, "DELETE", "v1/domains/"+domainID, ""); resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", resp.StatusCode, body)
+	}
+	if _, ok := s.Object(recordPath); ok {
+		t.Errorf("expected the records of a deleted domain to be gone")
+	}
+	if resp, _ = do(t, s, "GET", recordPath, ""); resp.StatusCode != http.StatusNotFound {
+		t.Errorf("expected 404 for a record of a deleted domain, got %d", resp.StatusCode)
+	}
+}
+
+func TestServerChecksAnswerWithLocation(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	resp, body := do(t, s, "POST", "rest/api/tcp", `{"name":"tcp","host":"example.com","port":443,"checkSites":[1]}`)
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", resp.StatusCode, body)
+	}
+	location := resp.Header.Get("Location")
+	if !strings.HasPrefix(location, s.URL+"/rest/api/tcp/") {
+		t.Fatalf("unexpected Location %q", location)
+	}
+	if _, ok := s.Object(strings.TrimPrefix(location, s.URL)); !ok {
+		t.Errorf("expected the check to be stored at %s", location)
+	}
+
+	if resp, body = do(t, s, "POST", "rest/api/tcp", `{"name":"tcp"}`); resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("expected a check without host to be refused, got %d: %s", resp.StatusCode, body)
+	}
+}
+
+func TestServerPagesLists(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	for _, name := range []string{"a.example", "b.example", "c.example"} {
+		if resp, body := do(t, s, "POST", "v1/domains", `{"names":["`+name+`"]}`); resp.StatusCode != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", resp.StatusCode, body)
+		}
+	}
+
+	cases := map[string][]string{
+		"v1/domains":                  {"a.example", "b.example", "c.example"},
+		"v1/domains?offset=0&limit=2": {"a.example", "b.example"},
+		"v1/domains?offset=2&limit=2": {"c.example"},
+		"v1/domains?offset=4&limit=2": {},
+	}
+	for path, expected := range cases {
+		resp, body := do(t, s, "GET", path, "")
+		var domains []map[string]interface{}
+		if err := json.Unmarshal([]byte(body), &domains); resp.StatusCode != http.StatusOK || err != nil {
+			t.Errorf("%s: expected a list, got %d: %s", path, resp.StatusCode, body)
+			continue
+		}
+		names := []string{}
+		for _, domain := range domains {
+			names = append(names, domain["name"].(string))
+		}
+		if strings.Join(names, ",") != strings.Join(expected, ",") {
+			t.Errorf("%s: expected %v, got %v", path, expected, names)
+		}
+	}
+
+	if resp, body := do(t, s, "GET", "v1/domains?offset=-1&limit=2", ""); resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("expected a negative offset to be refused, got %d: %s", resp.StatusCode, body)
+	}
+}
+
+func TestServerDNSSEC(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	_, body := do(t, s, "POST", "v1/domains", `{"names":["example.com"]}`)
+	var created []map[string]interface{}
+	json.Unmarshal([]byte(body), &created)
+	domainPath := "v4/domains/" + strconv.Itoa(int(created[0]["id"].(float64)))
+
+	keys := func() []interface{} {
+		resp, body := do(t, s, "GET", domainPath+"/dnssec", "")
+		var status struct {
+			Data struct {
+				Enabled bool          `json:"enabled"`
+				Keys    []interface{} `json:"keys"`
+			} `json:"data"`
+		}
+		if err := json.Unmarshal([]byte(body), &status); resp.StatusCode != http.StatusOK || err != nil {
+			t.Fatalf("expected the DNSSEC status, got %d: %s", resp.StatusCode, body)
+		}
+		if status.Data.Enabled != (len(status.Data.Keys) > 0) {
+			t.Errorf("expected keys exactly while DNSSEC is enabled, got %s", body)
+		}
+		return status.Data.Keys
+	}
+
+	if len(keys()) != 0 {
+		t.Errorf("expected a new domain to be unsigned")
+	}
+	if resp, body := do(t, s, "PUT", domainPath, `{"dnssec":true}`); resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", resp.StatusCode, body)
+	}
+	if len(keys()) != 1 {
+		t.Errorf("expected a key signing key once DNSSEC is enabled")
+	}
+	if resp, body := do(t, s, "PUT", domainPath, `{"dnssec":false}`); resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", resp.StatusCode, body)
+	}
+	if len(keys()) != 0 {
+		t.Errorf("expected no keys once DNSSEC is disabled")
+	}
+
+	if resp, body := do(t, s, "PUT", domainPath, `{"dnssec":"yes"}`); resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("expected a non-boolean dnssec to be refused, got %d: %s", resp.StatusCode, body)
+	}
+	if resp, _ := do(t, s, "GET", "v4/domains/1/dnssec", ""); resp.StatusCode != http.StatusNotFound {
+		t.Errorf("expected 404 for an unknown domain, got %d", resp.StatusCode)
+	}
+}
+
+func TestServerDomainHistory(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	_, body := do(t, s, "POST", "v1/domains", `{"names":["example.com"]}`)
+	var created []map[string]interface{}
+	json.Unmarshal([]byte(body), &created)
+	domainPath := "v1/domains/" + strconv.Itoa(int(created[0]["id"].(float64)))
+
+	versions := func() []map[string]interface{} {
+		resp, body := do(t, s, "GET", domainPath+"/history", "")
+		var history []map[string]interface{}
+		if err := json.Unmarshal([]byte(body), &history); resp.StatusCode != http.StatusOK || err != nil {
+			t.Fatalf("expected the history, got %d: %s", resp.StatusCode, body)
+		}
+		return history
+	}
+
+	if history := versions(); len(history) != 1 || history[0]["recordCount"] != float64(0) {
+		t.Fatalf("expected the creation of the domain as its first version, got %v", history)
+	}
+	do(t, s, "POST", domainPath+"/records/a", `{"name":"www","ttl":300}`)
+	if history := versions(); len(history) != 2 || history[1]["recordCount"] != float64(1) {
+		t.Fatalf("expected a version for the new record, got %v", history)
+	}
+	if domain, _ := s.Object(domainPath); domain["version"] != float64(2) {
+		t.Errorf("expected the domain at version 2, got %v", domain["version"])
+	}
+
+	if resp, body := do(t, s, "POST", domainPath+"/history/1/snapshot", `{}`); resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", resp.StatusCode, body)
+	}
+	if resp, _ := do(t, s, "POST", domainPath+"/history/1/snapshot", `{}`); resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("expected a second snapshot of a version to be refused, got %d", resp.StatusCode)
+	}
+	if resp, _ := do(t, s, "POST", domainPath+"/history/9/snapshot", `{}`); resp.StatusCode != http.StatusNotFound {
+		t.Errorf("expected a snapshot of an unknown version to be refused, got %d", resp.StatusCode)
+	}
+
+	if resp, body := do(t, s, "POST", domainPath+"/snapshots/1/apply", `{}`); resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", resp.StatusCode, body)
+	}
+	if _, body := do(t, s, "GET", domainPath+"/records/a", ""); body != "[]" {
+		t.Errorf("expected the restore to remove the record, got %s", body)
+	}
+	if history := versions(); len(history) != 3 || history[2]["recordCount"] != float64(0) {
+		t.Errorf("expected a version for the restore, got %v", history)
+	}
+
+	if resp, _ := do(t, s, "DELETE", domainPath+"/snapshots/1", ""); resp.StatusCode != http.StatusOK {
+		t.Errorf("expected the snapshot to be deleted, got %d", resp.StatusCode)
+	}
+	if resp, _ := do(t, s, "GET", domainPath+"/snapshots/1", ""); resp.StatusCode != http.StatusNotFound {
+		t.Errorf("expected the snapshot to be gone, got %d", resp.StatusCode)
+	}
+	if resp, _ := do(t, s, "GET", "v1/domains/1/history", ""); resp.StatusCode != http.StatusNotFound {
+		t.Errorf("expected the history of an unknown domain to be 404, got %d", resp.StatusCode)
+	}
+}
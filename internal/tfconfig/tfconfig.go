@@ -0,0 +1,233 @@
+// Package tfconfig writes Terraform configuration in the layout of
+// terraform fmt.
+package tfconfig
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Expr is an expression written as is, such as a reference to another
+// resource.
+type Expr string
+
+// Ref returns the expression referring to attr of the resource typ.name.
+func Ref(typ, name, attr string) Expr {
+	return Expr(typ + "." + name + "." + attr)
+}
+
+// Block is a block of configuration, such as a resource.
+type Block struct {
+	Type   string
+	Labels []string
+	// Comments are written on the lines before the block.
+	Comments []string
+	items    []item
+}
+
+type item struct {
+	name  string
+	value interface{}
+	block *Block
+}
+
+// NewBlock returns a block with the given type and labels.
+func NewBlock(typ string, labels ...string) *Block {
+	return &Block{Type: typ, Labels: labels}
+}
+
+// Set adds an attribute to the block. value is a string, a bool, a number, an
+// Expr, or a slice or a map of those.
+func (b *Block) Set(name string, value interface{}) *Block {
+	b.items = append(b.items, item{name: name, value: value})
+	return b
+}
+
+// Append adds a nested block to the block and returns it.
+func (b *Block) Append(typ string, labels ...string) *Block {
+	nested := NewBlock(typ, labels...)
+	b.AppendBlock(nested)
+	return nested
+}
+
+// AppendBlock adds nested to the block as a nested block.
+func (b *Block) AppendBlock(nested *Block) {
+	b.items = append(b.items, item{block: nested})
+}
+
+// Write writes blocks to w, separated by blank lines.
+func Write(w io.Writer, blocks []*Block) error {
+	bw := bufio.NewWriter(w)
+	for i, b := range blocks {
+		if i > 0 {
+			bw.WriteString("\n")
+		}
+		writeBlock(bw, b, "")
+	}
+	return bw.Flush()
+}
+
+func writeBlock(w *bufio.Writer, b *Block, indent string) {
+	for _, comment := range b.Comments {
+		fmt.Fprintf(w, "%s# %s\n", indent, comment)
+	}
+	w.WriteString(indent + b.Type)
+	for _, label := range b.Labels {
+		w.WriteString(" " + quote(label))
+	}
+	if len(b.items) == 0 {
+		w.WriteString(" {}\n")
+		return
+	}
+	w.WriteString(" {\n")
+	inner := indent + "  "
+	for i := 0; i < len(b.items); {
+		if b.items[i].block != nil {
+			writeBlock(w, b.items[i].block, inner)
+			i++
+			continue
+		}
+		// Align the equals signs of consecutive single line attributes.
+		j, width := i, 0
+		for ; j < len(b.items) && b.items[j].block == nil; j++ {
+			if len(b.items[j].name) > width {
+				width = len(b.items[j].name)
+			}
+		}
+		for ; i < j; i++ {
+			fmt.Fprintf(w, "%s%-*s = %s\n", inner, width, b.items[i].name, value(b.items[i].value, inner))
+		}
+	}
+	w.WriteString(indent + "}\n")
+}
+
+func value(v interface{}, indent string) string {
+	switch v := v.(type) {
+	case Expr:
+		return string(v)
+	case string:
+		return quote(v)
+	case bool:
+		return strconv.FormatBool(v)
+	case int:
+		return strconv.Itoa(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case uint16:
+		return strconv.FormatUint(uint64(v), 10)
+	case uint32:
+		return strconv.FormatUint(uint64(v), 10)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case []interface{}:
+		elems := make([]string, len(v))
+		for i, elem := range v {
+			elems[i] = value(elem, indent)
+		}
+		return "[" + strings.Join(elems, ", ") + "]"
+	case []string:
+		elems := make([]string, len(v))
+		for i, elem := range v {
+			elems[i] = quote(elem)
+		}
+		return "[" + strings.Join(elems, ", ") + "]"
+	case []int:
+		elems := make([]string, len(v))
+		for i, elem := range v {
+			elems[i] = strconv.Itoa(elem)
+		}
+		return "[" + strings.Join(elems, ", ") + "]"
+	case []Expr:
+		elems := make([]string, len(v))
+		for i, elem := range v {
+			elems[i] = string(elem)
+		}
+		return "[" + strings.Join(elems, ", ") + "]"
+	case map[string]string:
+		m := make(map[string]interface{}, len(v))
+		for key, elem := range v {
+			m[key] = elem
+		}
+		return value(m, indent)
+	case map[string]interface{}:
+		if len(v) == 0 {
+			return "{}"
+		}
+		keys := make([]string, 0, len(v))
+		width := 0
+		for key := range v {
+			keys = append(keys, key)
+			if len(key) > width {
+				width = len(key)
+			}
+		}
+		sort.Strings(keys)
+		var b strings.Builder
+		b.WriteString("{\n")
+		for _, key := range keys {
+			fmt.Fprintf(&b, "%s  %-*s = %s\n", indent, width, key, value(v[key], indent+"  "))
+		}
+		b.WriteString(indent + "}")
+		return b.String()
+	}
+	panic(fmt.Sprintf("tfconfig: unsupported value %#v", v))
+}
+
+// quote returns s as a quoted template string, escaping the sequences that
+// would start an interpolation or a directive.
+func quote(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for i, r := range s {
+		switch {
+		case r == '"' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r == '\n':
+			b.WriteString(`\n`)
+		case r == '\r':
+			b.WriteString(`\r`)
+		case r == '\t':
+			b.WriteString(`\t`)
+		case (r == '$' || r == '%') && strings.HasPrefix(s[i+1:], "{"):
+			b.WriteRune(r)
+			b.WriteRune(r)
+		case !unicode.IsPrint(r):
+			fmt.Fprintf(&b, `\u%04x`, r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// Identifier returns s as a name that can label a resource: letters, digits,
+// dashes and underscores, not starting with a digit.
+func Identifier(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '-', r == '_':
+			b.WriteRune(r)
+		case r == '*':
+			b.WriteString("wildcard")
+		default:
+			b.WriteByte('_')
+		}
+	}
+	id := b.String()
+	for strings.Contains(id, "__") {
+		id = strings.ReplaceAll(id, "__", "_")
+	}
+	id = strings.Trim(id, "_")
+	if id == "" || (id[0] >= '0' && id[0] <= '9') || id[0] == '-' {
+		id = "_" + id
+	}
+	return id
+}
@@ -0,0 +1,68 @@
+package tfconfig
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWrite(t *testing.T) {
+	resource := NewBlock("resource", "constellix_txt_record", "txt")
+	resource.Comments = []string{"Imported from example.com."}
+	resource.Set("domain_id", Ref("constellix_domain", "example_com", "id"))
+	resource.Set("ttl", 300)
+	resource.Set("pools", []int{1, 2})
+	resource.Set("tags", map[string]string{"team": "dns", "env": "prod"})
+	resource.Append("roundrobin").Set("value", `say "hi" to ${name} and %{x}`)
+	resource.Append("lifecycle")
+	resource.Set("noanswer", false)
+
+	imp := NewBlock("import")
+	imp.Set("to", Expr("constellix_txt_record.txt"))
+	imp.Set("id", "domains:1:2")
+
+	var b bytes.Buffer
+	if err := Write(&b, []*Block{resource, imp}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expected := `# Imported from example.com.
+resource "constellix_txt_record" "txt" {
+  domain_id = constellix_domain.example_com.id
+  ttl       = 300
+  pools     = [1, 2]
+  tags      = {
+    env  = "prod"
+    team = "dns"
+  }
+  roundrobin {
+    value = "say \"hi\" to $${name} and %%{x}"
+  }
+  lifecycle {}
+  noanswer = false
+}
+
+import {
+  to = constellix_txt_record.txt
+  id = "domains:1:2"
+}
+`
+	if b.String() != expected {
+		t.Errorf("expected\n%s\ngot\n%s", expected, b.String())
+	}
+}
+
+func TestIdentifier(t *testing.T) {
+	cases := map[string]string{
+		"www":              "www",
+		"example.com":      "example_com",
+		"a__sip._tcp":      "a_sip_tcp",
+		"*.dev":            "wildcard_dev",
+		"13":               "_13",
+		"":                 "_",
+		"Mail-Server.Corp": "mail-server_corp",
+	}
+	for s, expected := range cases {
+		if id := Identifier(s); id != expected {
+			t.Errorf("%q: expected %q, got %q", s, expected, id)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+// Package tfconfigtest parses generated configuration back for tests.
+package tfconfigtest
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// Block is a top level block of a configuration, such as a resource or an
+// import block.
+type Block struct {
+	Type   string
+	Labels []string
+	// Config is the raw configuration of the block, as the provider gets
+	// it.
+	Config map[string]interface{}
+}
+
+// Parse parses config. The references to other objects, such as
+// constellix_domain.example_com.id, are replaced by what resolve returns for
+// them, or kept as strings when resolve is nil.
+func Parse(t testing.TB, config string, resolve func(ref string) interface{}) []Block {
+	t.Helper()
+	file, diags := hclsyntax.ParseConfig([]byte(config), "generated.tf", hcl.InitialPos)
+	if diags.HasErrors() {
+		t.Fatalf("invalid configuration: %s\n%s", diags, config)
+	}
+	if resolve == nil {
+		resolve = func(ref string) interface{} { return ref }
+	}
+	var blocks []Block
+	for _, block := range file.Body.(*hclsyntax.Body).Blocks {
+		blocks = append(blocks, Block{
+			Type:   block.Type,
+			Labels: block.Labels,
+			Config: bodyValues(t, block.Body, resolve),
+		})
+	}
+	return blocks
+}
+
+// Validate validates the resource blocks of config against the schemas of
+// provider, and returns them.
+func Validate(t testing.TB, provider *schema.Provider, config string, resolve func(ref string) interface{}) []Block {
+	t.Helper()
+	blocks := Parse(t, config, resolve)
+	for _, block := range blocks {
+		if block.Type != "resource" {
+			continue
+		}
+		r := provider.ResourcesMap[block.Labels[0]]
+		if r == nil {
+			t.Errorf("unknown resource %s", block.Labels[0])
+			continue
+		}
+		if diags := r.Validate(terraform.NewResourceConfigRaw(block.Config)); diags.HasError() {
+			t.Errorf("%s.%s: %v", block.Labels[0], block.Labels[1], diags)
+		}
+	}
+	return blocks
+}
+
+func bodyValues(t testing.TB, body *hclsyntax.Body, resolve func(string) interface{}) map[string]interface{} {
+	values := make(map[string]interface{})
+	for name, attr := range body.Attributes {
+		values[name] = exprValue(t, attr.Expr, resolve)
+	}
+	for _, block := range body.Blocks {
+		list, _ := values[block.Type].([]interface{})
+		values[block.Type] = append(list, bodyValues(t, block.Body, resolve))
+	}
+	return values
+}
+
+func exprValue(t testing.TB, expr hclsyntax.Expression, resolve func(string) interface{}) interface{} {
+	switch expr := expr.(type) {
+	case *hclsyntax.ScopeTraversalExpr:
+		return resolve(traversalString(expr.Traversal))
+	case *hclsyntax.TupleConsExpr:
+		list := make([]interface{}, 0, len(expr.Exprs))
+		for _, elem := range expr.Exprs {
+			list = append(list, exprValue(t, elem, resolve))
+		}
+		return list
+	}
+	value, diags := expr.Value(nil)
+	if diags.HasErrors() {
+		t.Fatalf("%s", diags)
+	}
+	return goValue(value)
+}
+
+func traversalString(traversal hcl.Traversal) string {
+	s := ""
+	for _, step := range traversal {
+		switch step := step.(type) {
+		case hcl.TraverseRoot:
+			s += step.Name
+		case hcl.TraverseAttr:
+			s += "." + step.Name
+		}
+	}
+	return s
+}
+
+func goValue(value cty.Value) interface{} {
+	switch {
+	case value.Type() == cty.String:
+		return value.AsString()
+	case value.Type() == cty.Bool:
+		return value.True()
+	case value.Type() == cty.Number:
+		f := value.AsBigFloat()
+		if f.IsInt() {
+			i, _ := f.Int(new(big.Int))
+			return int(i.Int64())
+		}
+		f64, _ := f.Float64()
+		return f64
+	case value.Type().IsObjectType() || value.Type().IsMapType():
+		m := make(map[string]interface{})
+		for key, elem := range value.AsValueMap() {
+			m[key] = goValue(elem)
+		}
+		return m
+	}
+	list := make([]interface{}, 0)
+	for _, elem := range value.AsValueSlice() {
+		list = append(list, goValue(elem))
+	}
+	return list
+}
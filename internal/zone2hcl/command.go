@@ -0,0 +1,80 @@
+package zone2hcl
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/terraform-providers/terraform-provider-constellix/internal/tfconfig"
+	"github.com/terraform-providers/terraform-provider-constellix/internal/zonefile"
+)
+
+const usage = `Usage: terraform-provider-constellix zone2hcl [-origin name] [-out file] [zone-file]
+
+Converts a BIND zone file to the configuration of a constellix_domain and its
+records. The zone is read from standard input when no file, or -, is given.
+Records that cannot be converted are reported on standard error.
+
+`
+
+// Main runs the zone2hcl command with the given arguments, and returns its
+// exit status.
+func Main(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("zone2hcl", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprint(stderr, usage)
+		flags.PrintDefaults()
+	}
+	origin := flags.String("origin", "", "origin of the zone, when the file has no $ORIGIN")
+	out := flags.String("out", "", "file to write the configuration to instead of standard output")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() > 1 {
+		flags.Usage()
+		return 2
+	}
+
+	in := stdin
+	if path := flags.Arg(0); path != "" && path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			fmt.Fprintf(stderr, "zone2hcl: %s\n", err)
+			return 1
+		}
+		defer f.Close()
+		in = f
+	}
+
+	zone, err := zonefile.Parse(in, *origin)
+	if err != nil {
+		fmt.Fprintf(stderr, "zone2hcl: %s\n", err)
+		return 1
+	}
+	blocks, problems, err := Convert(zone)
+	if err != nil {
+		fmt.Fprintf(stderr, "zone2hcl: %s; set it with -origin\n", err)
+		return 1
+	}
+	for _, p := range problems {
+		fmt.Fprintf(stderr, "zone2hcl: %s\n", p)
+	}
+
+	w := stdout
+	if *out != "" {
+		f, err := os.Create(*out)
+		if err != nil {
+			fmt.Fprintf(stderr, "zone2hcl: %s\n", err)
+			return 1
+		}
+		defer f.Close()
+		w = f
+	}
+	if err := tfconfig.Write(w, blocks); err != nil {
+		fmt.Fprintf(stderr, "zone2hcl: %s\n", err)
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,69 @@
+package zone2hcl
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMainCommand(t *testing.T) {
+	zone := "$TTL 60\n@ A 192.0.2.1\n@ LOC 52 22 23.000 N 4 53 32.000 E -2.00m\n"
+
+	var stdout, stderr bytes.Buffer
+	if status := Main([]string{"-origin", "example.com"}, strings.NewReader(zone), &stdout, &stderr); status != 0 {
+		t.Fatalf("expected status 0, got %d: %s", status, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), `resource "constellix_a_record" "a_apex" {`) {
+		t.Errorf("expected the A record in the configuration, got\n%s", stdout.String())
+	}
+	if expected := "zone2hcl: line 3: example.com. LOC: unsupported record type\n"; stderr.String() != expected {
+		t.Errorf("expected %q on standard error, got %q", expected, stderr.String())
+	}
+
+	dir, err := ioutil.TempDir("", "zone2hcl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	in := filepath.Join(dir, "example.com.zone")
+	out := filepath.Join(dir, "example.com.tf")
+	if err := ioutil.WriteFile(in, []byte(zone), 0644); err != nil {
+		t.Fatal(err)
+	}
+	stdout.Reset()
+	stderr.Reset()
+	if status := Main([]string{"-origin", "example.com.", "-out", out, in}, nil, &stdout, &stderr); status != 0 {
+		t.Fatalf("expected status 0, got %d: %s", status, stderr.String())
+	}
+	written, err := ioutil.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(written), `resource "constellix_domain" "example_com" {`) || stdout.Len() != 0 {
+		t.Errorf("expected the configuration in %s only, got\n%s", out, written)
+	}
+}
+
+func TestMainErrors(t *testing.T) {
+	cases := []struct {
+		args   []string
+		zone   string
+		status int
+		stderr string
+	}{
+		{nil, "$TTL 60\n@ A 192.0.2.1\n", 1, "zone2hcl: line 2: @ used without an origin\n"},
+		{nil, "", 1, "zone2hcl: the zone has no origin; set it with -origin\n"},
+		{[]string{"a.zone", "b.zone"}, "", 2, "Usage:"},
+		{[]string{"-unknown"}, "", 2, "flag provided but not defined"},
+	}
+	for _, tc := range cases {
+		var stdout, stderr bytes.Buffer
+		status := Main(tc.args, strings.NewReader(tc.zone), &stdout, &stderr)
+		if status != tc.status || !strings.Contains(stderr.String(), tc.stderr) {
+			t.Errorf("%v: expected status %d and %q, got %d and %q", tc.args, tc.status, tc.stderr, status, stderr.String())
+		}
+	}
+}
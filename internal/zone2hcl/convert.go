@@ -0,0 +1,656 @@
+// Package zone2hcl converts BIND zone files to the Terraform configuration of
+// a constellix_domain and its records.
+package zone2hcl
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/terraform-providers/terraform-provider-constellix/internal/tfconfig"
+	"github.com/terraform-providers/terraform-provider-constellix/internal/zonefile"
+)
+
+// Problem is a record of a zone that has no equivalent in the generated
+// configuration, or whose conversion lost information.
+type Problem struct {
+	Line   int
+	Name   string
+	Type   string
+	Reason string
+}
+
+func (p Problem) String() string {
+	return fmt.Sprintf("line %d: %s %s: %s", p.Line, p.Name, p.Type, p.Reason)
+}
+
+// attr is an argument of a resource or of one of its roundrobin blocks.
+type attr struct {
+	name  string
+	value interface{}
+}
+
+// converter converts the records of a type to arguments of a resource.
+type converter struct {
+	resource string
+	// single is set for the types which take their value as arguments of
+	// the resource rather than roundrobin blocks.
+	single bool
+	// disableFlag is the disable_flag of the roundrobin blocks of the types
+	// which require one.
+	disableFlag interface{}
+	values      func(r zonefile.Record) ([]attr, error)
+}
+
+var converters = map[string]*converter{
+	"A": {
+		resource:    "constellix_a_record",
+		disableFlag: false,
+		values:      addressValues(false),
+	},
+	"AAAA": {
+		resource:    "constellix_aaaa_record",
+		disableFlag: false,
+		values:      addressValues(true),
+	},
+	"CNAME": {
+		resource: "constellix_cname_record",
+		single:   true,
+		values: func(r zonefile.Record) ([]attr, error) {
+			d, err := data(r, 1)
+			if err != nil {
+				return nil, err
+			}
+			host, err := d.name(0)
+			return []attr{{"host", host}}, err
+		},
+	},
+	"MX": {
+		resource: "constellix_mx_record",
+		values: func(r zonefile.Record) ([]attr, error) {
+			d, err := data(r, 2)
+			if err != nil {
+				return nil, err
+			}
+			level, err := d.uint(0, 16)
+			if err != nil {
+				return nil, err
+			}
+			exchange, err := d.name(1)
+			return []attr{{"value", exchange}, {"level", strconv.Itoa(level)}}, err
+		},
+	},
+	"NS": {
+		resource: "constellix_ns_record",
+		values: func(r zonefile.Record) ([]attr, error) {
+			d, err := data(r, 1)
+			if err != nil {
+				return nil, err
+			}
+			host, err := d.name(0)
+			return []attr{{"value", host}}, err
+		},
+	},
+	"TXT": {
+		resource: "constellix_txt_record",
+		values:   textValues,
+	},
+	"SPF": {
+		resource: "constellix_spf_record",
+		values:   textValues,
+	},
+	"SRV": {
+		resource: "constellix_srv_record",
+		values: func(r zonefile.Record) ([]attr, error) {
+			d, err := data(r, 4)
+			if err != nil {
+				return nil, err
+			}
+			var numbers [3]int
+			for i := range numbers {
+				if numbers[i], err = d.uint(i, 16); err != nil {
+					return nil, err
+				}
+			}
+			target, err := d.name(3)
+			return []attr{
+				{"value", target},
+				{"port", numbers[2]},
+				{"priority", numbers[0]},
+				{"weight", numbers[1]},
+			}, err
+		},
+	},
+	"CAA": {
+		resource:    "constellix_caa_record",
+		disableFlag: false,
+		values: func(r zonefile.Record) ([]attr, error) {
+			d, err := data(r, 3)
+			if err != nil {
+				return nil, err
+			}
+			flag, err := d.uint(0, 8)
+			if err != nil {
+				return nil, err
+			}
+			tag, value := d.Data[1].Text, d.Data[2].Text
+			return []attr{
+				{"caa_provider_id", caaProviderID(tag, value)},
+				{"tag", tag},
+				{"data", value},
+				{"flag", strconv.Itoa(flag)},
+			}, nil
+		},
+	},
+	"PTR": {
+		resource: "constellix_ptr_record",
+		values: func(r zonefile.Record) ([]attr, error) {
+			d, err := data(r, 1)
+			if err != nil {
+				return nil, err
+			}
+			host, err := d.name(0)
+			return []attr{{"value", host}}, err
+		},
+	},
+	"NAPTR": {
+		resource:    "constellix_naptr_record",
+		disableFlag: false,
+		values: func(r zonefile.Record) ([]attr, error) {
+			d, err := data(r, 6)
+			if err != nil {
+				return nil, err
+			}
+			var numbers [2]int
+			for i := range numbers {
+				if numbers[i], err = d.uint(i, 16); err != nil {
+					return nil, err
+				}
+			}
+			replacement, err := d.name(5)
+			return []attr{
+				{"order", strconv.Itoa(numbers[0])},
+				{"preference", strconv.Itoa(numbers[1])},
+				{"flags", d.Data[2].Text},
+				{"service", d.Data[3].Text},
+				{"regular_expression", d.Data[4].Text},
+				{"replacement", replacement},
+			}, err
+		},
+	},
+	"RP": {
+		resource: "constellix_rp_record",
+		values: func(r zonefile.Record) ([]attr, error) {
+			d, err := data(r, 2)
+			if err != nil {
+				return nil, err
+			}
+			mailbox, err := d.name(0)
+			if err != nil {
+				return nil, err
+			}
+			txt, err := d.name(1)
+			return []attr{{"mailbox", mailbox}, {"txt", txt}}, err
+		},
+	},
+	"HINFO": {
+		resource: "constellix_hinfo_record",
+		values: func(r zonefile.Record) ([]attr, error) {
+			d, err := data(r, 2)
+			if err != nil {
+				return nil, err
+			}
+			return []attr{{"cpu", d.Data[0].Text}, {"os", d.Data[1].Text}}, nil
+		},
+	},
+	"CERT": {
+		resource: "constellix_cert_record",
+		values:   certValues,
+	},
+	"TLSA": {
+		resource: "constellix_tlsa_record",
+		values: func(r zonefile.Record) ([]attr, error) {
+			if len(r.Data) < 4 {
+				return nil, fmt.Errorf("expected at least 4 fields, got %d", len(r.Data))
+			}
+			d := rdata(r)
+			var numbers [3]int
+			var err error
+			for i := range numbers {
+				if numbers[i], err = d.uint(i, 8); err != nil {
+					return nil, err
+				}
+			}
+			return []attr{
+				{"usage", numbers[0]},
+				{"selector", numbers[1]},
+				{"matching_type", numbers[2]},
+				{"certificate", strings.ToLower(d.join(3, ""))},
+			}, nil
+		},
+	},
+	"SSHFP": {
+		resource: "constellix_sshfp_record",
+		values: func(r zonefile.Record) ([]attr, error) {
+			if len(r.Data) < 3 {
+				return nil, fmt.Errorf("expected at least 3 fields, got %d", len(r.Data))
+			}
+			d := rdata(r)
+			var numbers [2]int
+			var err error
+			for i := range numbers {
+				if numbers[i], err = d.uint(i, 8); err != nil {
+					return nil, err
+				}
+			}
+			return []attr{
+				{"algorithm", numbers[0]},
+				{"fingerprint_type", numbers[1]},
+				{"fingerprint", strings.ToLower(d.join(2, ""))},
+			}, nil
+		},
+	},
+	"HTTPS": {
+		resource: "constellix_https_record",
+		values:   serviceBindingValues,
+	},
+	"SVCB": {
+		resource: "constellix_svcb_record",
+		values:   serviceBindingValues,
+	},
+}
+
+// Convert returns the configuration of the domain of zone and of its
+// records, and the problems found converting them.
+func Convert(zone *zonefile.Zone) ([]*tfconfig.Block, []Problem, error) {
+	if zone.Origin == "" || zone.Origin == "." {
+		return nil, nil, fmt.Errorf("the zone has no origin")
+	}
+	origin := zone.Origin
+	domainName := strings.TrimSuffix(origin, ".")
+	domainLabel := tfconfig.Identifier(domainName)
+	domain := tfconfig.NewBlock("resource", "constellix_domain", domainLabel)
+	domain.Set("name", domainName)
+	domainID := tfconfig.Ref("constellix_domain", domainLabel, "id")
+
+	var problems []Problem
+	problem := func(r zonefile.Record, format string, a ...interface{}) {
+		problems = append(problems, Problem{r.Line, r.Name, r.Type, fmt.Sprintf(format, a...)})
+	}
+
+	type group struct {
+		converter *converter
+		name      string
+		records   []zonefile.Record
+		values    [][]attr
+	}
+	var groups []*group
+	byKey := make(map[string]*group)
+
+	soa := false
+	for _, r := range zone.Records {
+		if r.Name != origin && !strings.HasSuffix(r.Name, "."+origin) {
+			problem(r, "outside of %s", origin)
+			continue
+		}
+		if r.Class != "IN" {
+			problem(r, "unsupported class %s", r.Class)
+			continue
+		}
+		name := strings.TrimSuffix(strings.TrimSuffix(r.Name, origin), ".")
+
+		switch {
+		case r.Type == "SOA" && name == "" && !soa:
+			values, err := soaValues(r)
+			if err != nil {
+				problem(r, "%s", err)
+				continue
+			}
+			block := domain.Append("soa")
+			for _, a := range values {
+				block.Set(a.name, a.value)
+			}
+			soa = true
+			continue
+		case r.Type == "NS" && name == "":
+			problem(r, "skipped, the name servers of a domain are assigned by Constellix")
+			continue
+		}
+
+		c := converters[r.Type]
+		if c == nil {
+			problem(r, "unsupported record type")
+			continue
+		}
+		values, err := c.values(r)
+		if err != nil {
+			problem(r, "%s", err)
+			continue
+		}
+
+		key := r.Type + " " + name
+		g := byKey[key]
+		if g == nil {
+			g = &group{converter: c, name: name}
+			byKey[key] = g
+			groups = append(groups, g)
+		} else if c.single {
+			problem(r, "skipped, a name has a single %s record", r.Type)
+			continue
+		}
+		g.records = append(g.records, r)
+		g.values = append(g.values, values)
+	}
+
+	blocks := []*tfconfig.Block{domain}
+	labels := make(map[string]bool)
+	for _, g := range groups {
+		first := g.records[0]
+		label := tfconfig.Identifier(strings.ToLower(first.Type) + "_" + g.name)
+		if g.name == "" {
+			label = tfconfig.Identifier(strings.ToLower(first.Type) + "_apex")
+		}
+		for i, base := 2, label; labels[label]; i++ {
+			label = fmt.Sprintf("%s_%d", base, i)
+		}
+		labels[label] = true
+
+		b := tfconfig.NewBlock("resource", g.converter.resource, label)
+		b.Set("domain_id", domainID)
+		b.Set("source_type", "domains")
+		if g.name != "" {
+			b.Set("name", g.name)
+		}
+		b.Set("ttl", first.TTL)
+		for _, r := range g.records[1:] {
+			if r.TTL != first.TTL {
+				problem(r, "TTL %d differs from the TTL %d of line %d, which is used for all the records of the name", r.TTL, first.TTL, first.Line)
+			}
+		}
+
+		if g.converter.single {
+			for _, a := range g.values[0] {
+				b.Set(a.name, a.value)
+			}
+		} else {
+			for _, values := range g.values {
+				rr := b.Append("roundrobin")
+				for _, a := range values {
+					if nested, ok := a.value.(*tfconfig.Block); ok {
+						rr.AppendBlock(nested)
+					} else {
+						rr.Set(a.name, a.value)
+					}
+				}
+				if g.converter.disableFlag != nil {
+					rr.Set("disable_flag", g.converter.disableFlag)
+				}
+			}
+		}
+		blocks = append(blocks, b)
+	}
+	sort.SliceStable(problems, func(i, j int) bool {
+		return problems[i].Line < problems[j].Line
+	})
+	return blocks, problems, nil
+}
+
+func soaValues(r zonefile.Record) ([]attr, error) {
+	d, err := data(r, 7)
+	if err != nil {
+		return nil, err
+	}
+	primary, err := d.name(0)
+	if err != nil {
+		return nil, err
+	}
+	email, err := d.name(1)
+	if err != nil {
+		return nil, err
+	}
+	values := []attr{
+		{"primary_nameserver", primary},
+		{"email", email},
+		{"ttl", r.TTL},
+	}
+	// The serial, at index 2, is maintained by Constellix.
+	for i, key := range []string{"refresh", "retry", "expire", "negcache"} {
+		seconds, err := zonefile.ParseTTL(d.Data[3+i].Text)
+		if err != nil {
+			return nil, fmt.Errorf("invalid SOA %s %q", key, d.Data[3+i].Text)
+		}
+		values = append(values, attr{key, seconds})
+	}
+	return values, nil
+}
+
+func addressValues(ipv6 bool) func(r zonefile.Record) ([]attr, error) {
+	return func(r zonefile.Record) ([]attr, error) {
+		d, err := data(r, 1)
+		if err != nil {
+			return nil, err
+		}
+		if !isIP(d.Data[0].Text, ipv6) {
+			return nil, fmt.Errorf("invalid address %s", d.Data[0].Text)
+		}
+		return []attr{{"value", d.Data[0].Text}}, nil
+	}
+}
+
+// textValues converts the character strings of a TXT or SPF record to the
+// single value Constellix stores them as.
+func textValues(r zonefile.Record) ([]attr, error) {
+	if len(r.Data) == 0 {
+		return nil, fmt.Errorf("expected at least 1 field")
+	}
+	value := rdata(r).join(0, "")
+	if !utf8.ValidString(value) {
+		return nil, fmt.Errorf("value is not valid UTF-8")
+	}
+	return []attr{{"value", value}}, nil
+}
+
+var (
+	certTypes = map[string]int{
+		"PKIX": 1, "SPKI": 2, "PGP": 3, "IPKIX": 4, "ISPKI": 5, "IPGP": 6,
+		"ACPKIX": 7, "IACPKIX": 8, "URI": 253, "OID": 254,
+	}
+	dnssecAlgorithms = map[string]int{
+		"RSAMD5": 1, "DH": 2, "DSA": 3, "RSASHA1": 5, "DSA-NSEC3-SHA1": 6,
+		"RSASHA1-NSEC3-SHA1": 7, "RSASHA256": 8, "RSASHA512": 10, "ECC-GOST": 12,
+		"ECDSAP256SHA256": 13, "ECDSAP384SHA384": 14, "ED25519": 15, "ED448": 16,
+	}
+)
+
+// certValues converts a CERT record. The provider base64 encodes the
+// certificate it is given, so only certificates which decode to text can be
+// configured.
+func certValues(r zonefile.Record) ([]attr, error) {
+	if len(r.Data) < 4 {
+		return nil, fmt.Errorf("expected at least 4 fields, got %d", len(r.Data))
+	}
+	d := rdata(r)
+	certType, err := d.mnemonic(0, 16, certTypes)
+	if err != nil {
+		return nil, err
+	}
+	keyTag, err := d.uint(1, 16)
+	if err != nil {
+		return nil, err
+	}
+	algorithm, err := d.mnemonic(2, 8, dnssecAlgorithms)
+	if err != nil {
+		return nil, err
+	}
+	certificate, err := base64.StdEncoding.DecodeString(d.join(3, ""))
+	if err != nil {
+		return nil, fmt.Errorf("certificate is not base64")
+	}
+	if !isText(string(certificate)) {
+		return nil, fmt.Errorf("constellix_cert_record only takes certificates that are text")
+	}
+	return []attr{
+		{"certificate_type", certType},
+		{"key_tag", keyTag},
+		{"algorithm", algorithm},
+		{"certificate", string(certificate)},
+	}, nil
+}
+
+// serviceBindingValues converts an HTTPS or SVCB record.
+func serviceBindingValues(r zonefile.Record) ([]attr, error) {
+	if len(r.Data) < 2 {
+		return nil, fmt.Errorf("expected at least 2 fields, got %d", len(r.Data))
+	}
+	d := rdata(r)
+	priority, err := d.uint(0, 16)
+	if err != nil {
+		return nil, err
+	}
+	target := d.Data[1].Text
+	if target != "." {
+		if target, err = d.name(1); err != nil {
+			return nil, err
+		}
+	}
+	values := []attr{{"priority", priority}, {"target", target}}
+
+	// A quoted value is a separate field from its key=.
+	var params []string
+	for i := 2; i < len(d.Data); i++ {
+		param := d.Data[i].Text
+		if strings.HasSuffix(param, "=") && i+1 < len(d.Data) && d.Data[i+1].Quoted {
+			param += d.Data[i+1].Text
+			i++
+		}
+		params = append(params, param)
+	}
+	if len(params) == 0 {
+		return values, nil
+	}
+
+	svcParams := tfconfig.NewBlock("svc_params")
+	for _, param := range params {
+		key, value := param, ""
+		if i := strings.Index(param, "="); i >= 0 {
+			key, value = param[:i], param[i+1:]
+		}
+		switch key {
+		case "alpn":
+			svcParams.Set("alpn", strings.Split(value, ","))
+		case "port":
+			port, err := strconv.ParseUint(value, 10, 16)
+			if err != nil {
+				return nil, fmt.Errorf("invalid port %q", value)
+			}
+			svcParams.Set("port", int(port))
+		case "ipv4hint", "ipv6hint":
+			addresses := strings.Split(value, ",")
+			for _, address := range addresses {
+				if !isIP(address, key == "ipv6hint") {
+					return nil, fmt.Errorf("invalid %s %q", key, address)
+				}
+			}
+			svcParams.Set(key, addresses)
+		case "ech":
+			svcParams.Set("ech", value)
+		default:
+			return nil, fmt.Errorf("unsupported SvcParam %s", key)
+		}
+	}
+	return append(values, attr{"svc_params", svcParams}), nil
+}
+
+// caaProviderID returns the id Constellix lists the issuer of a CAA record
+// under, or 1 for a custom record.
+func caaProviderID(tag, value string) int {
+	if tag != "issue" && tag != "issuewild" {
+		return 1
+	}
+	providers := map[string]int{
+		";":               2,
+		"comodoca.com":    3,
+		"digicert.com":    4,
+		"entrust.net":     5,
+		"geotrust.com":    6,
+		"izenpe.com":      7,
+		"letsencrypt.org": 8,
+		"symantec.com":    9,
+		"thawte.com":      10,
+	}
+	if id, ok := providers[strings.ToLower(value)]; ok {
+		return id
+	}
+	return 1
+}
+
+// fields are the data of a record being converted.
+type fields struct {
+	zonefile.Record
+}
+
+func rdata(r zonefile.Record) fields {
+	return fields{r}
+}
+
+// data returns the data of r, which must have n fields.
+func data(r zonefile.Record, n int) (fields, error) {
+	if len(r.Data) != n {
+		return fields{}, fmt.Errorf("expected %d fields, got %d", n, len(r.Data))
+	}
+	return rdata(r), nil
+}
+
+// name returns the absolute form of the domain name at index i.
+func (f fields) name(i int) (string, error) {
+	return zonefile.Absolute(f.Data[i].Text, f.Origin)
+}
+
+// uint returns the unsigned integer of the given bit size at index i.
+func (f fields) uint(i, bits int) (int, error) {
+	n, err := strconv.ParseUint(f.Data[i].Text, 10, bits)
+	if err != nil {
+		return 0, fmt.Errorf("invalid number %q", f.Data[i].Text)
+	}
+	return int(n), nil
+}
+
+// mnemonic returns the number at index i, which may also be written as one
+// of mnemonics.
+func (f fields) mnemonic(i, bits int, mnemonics map[string]int) (int, error) {
+	if n, ok := mnemonics[strings.ToUpper(f.Data[i].Text)]; ok {
+		return n, nil
+	}
+	return f.uint(i, bits)
+}
+
+// join returns the fields from index i on, joined by sep.
+func (f fields) join(i int, sep string) string {
+	texts := make([]string, 0, len(f.Data)-i)
+	for _, field := range f.Data[i:] {
+		texts = append(texts, field.Text)
+	}
+	return strings.Join(texts, sep)
+}
+
+func isIP(s string, ipv6 bool) bool {
+	ip := net.ParseIP(s)
+	return ip != nil && (ip.To4() == nil) == ipv6 && strings.Contains(s, ":") == ipv6
+}
+
+func isText(s string) bool {
+	if !utf8.ValidString(s) {
+		return false
+	}
+	for _, r := range s {
+		if !unicode.IsPrint(r) && !unicode.IsSpace(r) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,193 @@
+package zone2hcl
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/terraform-providers/terraform-provider-constellix/constellix"
+	"github.com/terraform-providers/terraform-provider-constellix/internal/tfconfig"
+	"github.com/terraform-providers/terraform-provider-constellix/internal/tfconfig/tfconfigtest"
+	"github.com/terraform-providers/terraform-provider-constellix/internal/zonefile"
+)
+
+// testZone has a record of every type zone2hcl converts.
+const testZone = `$ORIGIN example.com.
+$TTL 3600
+@	SOA	ns1 hostmaster 2024010101 1h 15m 2w 300
+	NS	ns1.constellix.com.
+	MX	10 mail
+	MX	20 mail.example.net.
+	TXT	"v=spf1 " "include:_spf.example.net -all"
+	SPF	"v=spf1 -all"
+	CAA	0 issue "letsencrypt.org"
+	CAA	128 iodef "mailto:security@example.com"
+	RP	admin txt
+	HTTPS	1 . alpn="h2,h3" port=443 ipv4hint=192.0.2.1
+www	300	A	192.0.2.1
+www	300	A	192.0.2.2
+www	AAAA	2001:db8::1
+ftp	CNAME	www
+*.dev	A	192.0.2.9
+_sip._tcp	SRV	10 60 5060 sip
+sip	NAPTR	100 10 "S" "SIP+D2U" "" _sip._udp
+host	HINFO	"INTEL" "LINUX"
+host	SSHFP	4 2 ( 0C72AC70B745AC19998811B131D662C9
+		AC69DBDBE7CB23E5B514B56664C5D3D6 )
+_443._tcp.www	TLSA	3 1 1 0c72ac70b745ac19998811b131d662c9ac69dbdbe7cb23e5b514b56664c5d3d6
+cert	CERT	PKIX 30 RSASHA256 Y2VydGlmaWNhdGU=
+_svc	SVCB	0 svc.example.net.
+ns	NS	ns1.example.net.
+13	PTR	host
+`
+
+func convert(t *testing.T, zone string) (string, []Problem) {
+	parsed, err := zonefile.Parse(strings.NewReader(zone), "")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	blocks, problems, err := Convert(parsed)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var b bytes.Buffer
+	if err := tfconfig.Write(&b, blocks); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	return b.String(), problems
+}
+
+func TestConvert(t *testing.T) {
+	config, problems := convert(t, `$ORIGIN example.com.
+$TTL 3600
+@	SOA	ns1 hostmaster 2024010101 1h 15m 2w 300
+	NS	ns1.constellix.com.
+www	300	A	192.0.2.1
+www	600	A	192.0.2.2
+ftp	CNAME	www
+ftp	CNAME	www2
+@	DNSKEY	257 3 13 abc
+13	PTR	host.example.com.
+elsewhere.example.net.	A	192.0.2.3
+`)
+	expected := `resource "constellix_domain" "example_com" {
+  name = "example.com"
+  soa {
+    primary_nameserver = "ns1.example.com."
+    email              = "hostmaster.example.com."
+    ttl                = 3600
+    refresh            = 3600
+    retry              = 900
+    expire             = 1209600
+    negcache           = 300
+  }
+}
+
+resource "constellix_a_record" "a_www" {
+  domain_id   = constellix_domain.example_com.id
+  source_type = "domains"
+  name        = "www"
+  ttl         = 300
+  roundrobin {
+    value        = "192.0.2.1"
+    disable_flag = false
+  }
+  roundrobin {
+    value        = "192.0.2.2"
+    disable_flag = false
+  }
+}
+
+resource "constellix_cname_record" "cname_ftp" {
+  domain_id   = constellix_domain.example_com.id
+  source_type = "domains"
+  name        = "ftp"
+  ttl         = 3600
+  host        = "www.example.com."
+}
+
+resource "constellix_ptr_record" "ptr_13" {
+  domain_id   = constellix_domain.example_com.id
+  source_type = "domains"
+  name        = "13"
+  ttl         = 3600
+  roundrobin {
+    value = "host.example.com."
+  }
+}
+`
+	if config != expected {
+		t.Errorf("expected\n%s\ngot\n%s", expected, config)
+	}
+
+	var reported []string
+	for _, p := range problems {
+		reported = append(reported, p.String())
+	}
+	expectedProblems := []string{
+		"line 4: example.com. NS: skipped, the name servers of a domain are assigned by Constellix",
+		"line 6: www.example.com. A: TTL 600 differs from the TTL 300 of line 5, which is used for all the records of the name",
+		"line 8: ftp.example.com. CNAME: skipped, a name has a single CNAME record",
+		"line 9: example.com. DNSKEY: unsupported record type",
+		"line 11: elsewhere.example.net. A: outside of example.com.",
+	}
+	if !reflect.DeepEqual(reported, expectedProblems) {
+		t.Errorf("expected problems\n%s\ngot\n%s", strings.Join(expectedProblems, "\n"), strings.Join(reported, "\n"))
+	}
+}
+
+func TestConvertInvalidRecords(t *testing.T) {
+	_, problems := convert(t, `$ORIGIN example.com.
+$TTL 60
+a	A	2001:db8::1
+aaaa	AAAA	192.0.2.1
+mx	MX	mail
+srv	SRV	1 2 70000 target
+cert	CERT	PKIX 1 8 AAEC
+https	HTTPS	1 . mandatory=alpn alpn=h2
+`)
+	expected := []string{
+		"invalid address 2001:db8::1",
+		"invalid address 192.0.2.1",
+		"expected 2 fields, got 1",
+		`invalid number "70000"`,
+		"constellix_cert_record only takes certificates that are text",
+		"unsupported SvcParam mandatory",
+	}
+	if len(problems) != len(expected) {
+		t.Fatalf("expected %d problems, got %v", len(expected), problems)
+	}
+	for i, p := range problems {
+		if p.Reason != expected[i] {
+			t.Errorf("line %d: expected %q, got %q", p.Line, expected[i], p.Reason)
+		}
+	}
+}
+
+func TestConvertWithoutOrigin(t *testing.T) {
+	if _, _, err := Convert(&zonefile.Zone{}); err == nil {
+		t.Errorf("expected an error for a zone without origin")
+	}
+}
+
+// TestConvertedConfigurationIsValid parses the configuration of a zone with
+// every supported type and validates its resources against the schemas of the
+// provider.
+func TestConvertedConfigurationIsValid(t *testing.T) {
+	config, problems := convert(t, testZone)
+	if len(problems) != 1 || problems[0].Type != "NS" {
+		t.Errorf("expected only the apex NS to be reported, got %v", problems)
+	}
+
+	types := make(map[string]bool)
+	for _, block := range tfconfigtest.Validate(t, constellix.Provider(), config, nil) {
+		types[block.Labels[0]] = true
+	}
+
+	for _, c := range converters {
+		if !types[c.resource] {
+			t.Errorf("expected the test zone to have a %s", c.resource)
+		}
+	}
+}
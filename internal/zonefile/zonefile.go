@@ -0,0 +1,372 @@
+// Package zonefile parses DNS master files in the format of RFC 1035,
+// section 5, as written by BIND.
+package zonefile
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Token is a field of the data of a record.
+type Token struct {
+	Text string
+	// Quoted is set for fields written as quoted character strings.
+	Quoted bool
+}
+
+// Record is a resource record of a zone.
+type Record struct {
+	// Line is the line the record starts on.
+	Line int
+	// Name is the absolute, lower case owner name, with a trailing dot.
+	Name string
+	TTL  uint32
+	// Class is the class of the record, such as IN.
+	Class string
+	// Type is the upper case type of the record, such as A or TYPE65.
+	Type string
+	// Data holds the fields of the data of the record.
+	Data []Token
+	// Origin is the origin in effect for the record, which relative names in
+	// its data are relative to.
+	Origin string
+}
+
+// Zone is the content of a zone file.
+type Zone struct {
+	// Origin is the first origin of the file: the one it was parsed with,
+	// or the first $ORIGIN of the file.
+	Origin  string
+	Records []Record
+}
+
+// Error is a syntax error at a line of a zone file.
+type Error struct {
+	Line int
+	Msg  string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("line %d: %s", e.Line, e.Msg)
+}
+
+var classes = map[string]bool{"IN": true, "CH": true, "CS": true, "HS": true}
+
+// Parse reads a zone file. origin is used for relative names until the file
+// sets one with $ORIGIN; it may be empty when the file sets its own.
+func Parse(r io.Reader, origin string) (*Zone, error) {
+	p := &parser{
+		origin: Fqdn(origin),
+	}
+	if origin == "" {
+		p.origin = ""
+	}
+	zone := &Zone{Origin: p.origin}
+
+	entries, err := lex(r)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		record, err := p.entry(e)
+		if err != nil {
+			return nil, err
+		}
+		if zone.Origin == "" {
+			zone.Origin = p.origin
+		}
+		if record != nil {
+			zone.Records = append(zone.Records, *record)
+		}
+	}
+	return zone, nil
+}
+
+type parser struct {
+	origin     string
+	defaultTTL *uint32
+	lastTTL    *uint32
+	lastName   string
+}
+
+func (p *parser) entry(e entry) (*Record, error) {
+	tokens := e.tokens
+	if !tokens[0].Quoted && strings.HasPrefix(tokens[0].Text, "$") && !e.blankOwner {
+		return nil, p.directive(e)
+	}
+
+	record := &Record{Line: e.line, Origin: p.origin}
+	if e.blankOwner {
+		if p.lastName == "" {
+			return nil, &Error{e.line, "record without owner name"}
+		}
+		record.Name = p.lastName
+	} else {
+		name, err := p.name(tokens[0].Text, e.line)
+		if err != nil {
+			return nil, err
+		}
+		record.Name = name
+		tokens = tokens[1:]
+	}
+	p.lastName = record.Name
+
+	var ttl *uint32
+	for len(tokens) > 0 && !tokens[0].Quoted {
+		text := strings.ToUpper(tokens[0].Text)
+		if classes[text] && record.Class == "" {
+			record.Class = text
+		} else if t, err := ParseTTL(text); err == nil && ttl == nil {
+			ttl = &t
+		} else {
+			break
+		}
+		tokens = tokens[1:]
+	}
+	if len(tokens) == 0 {
+		return nil, &Error{e.line, "record without type"}
+	}
+	record.Type = strings.ToUpper(tokens[0].Text)
+	record.Data = tokens[1:]
+	if record.Class == "" {
+		record.Class = "IN"
+	}
+
+	switch {
+	case ttl != nil:
+		record.TTL = *ttl
+	case p.defaultTTL != nil:
+		record.TTL = *p.defaultTTL
+	case p.lastTTL != nil:
+		record.TTL = *p.lastTTL
+	case record.Type == "SOA" && len(record.Data) == 7:
+		// Without $TTL, the minimum of the SOA is the default (RFC 1035).
+		minimum, err := ParseTTL(record.Data[6].Text)
+		if err != nil {
+			return nil, &Error{e.line, fmt.Sprintf("invalid SOA minimum %q", record.Data[6].Text)}
+		}
+		record.TTL = minimum
+	default:
+		return nil, &Error{e.line, "no TTL for record and no $TTL before it"}
+	}
+	p.lastTTL = &record.TTL
+	if record.Type == "SOA" && p.defaultTTL == nil && len(record.Data) == 7 {
+		if minimum, err := ParseTTL(record.Data[6].Text); err == nil {
+			p.defaultTTL = &minimum
+		}
+	}
+	return record, nil
+}
+
+func (p *parser) directive(e entry) error {
+	tokens := e.tokens
+	switch strings.ToUpper(tokens[0].Text) {
+	case "$ORIGIN":
+		if len(tokens) != 2 {
+			return &Error{e.line, "$ORIGIN takes a name"}
+		}
+		origin, err := p.name(tokens[1].Text, e.line)
+		if err != nil {
+			return err
+		}
+		p.origin = origin
+	case "$TTL":
+		if len(tokens) != 2 {
+			return &Error{e.line, "$TTL takes a TTL"}
+		}
+		ttl, err := ParseTTL(tokens[1].Text)
+		if err != nil {
+			return &Error{e.line, err.Error()}
+		}
+		p.defaultTTL = &ttl
+	default:
+		return &Error{e.line, fmt.Sprintf("unsupported directive %s", tokens[0].Text)}
+	}
+	return nil
+}
+
+// name returns the absolute form of a name of the file.
+func (p *parser) name(name string, line int) (string, error) {
+	absolute, err := Absolute(name, p.origin)
+	if err != nil {
+		return "", &Error{line, err.Error()}
+	}
+	return absolute, nil
+}
+
+// Absolute returns name relative to origin as an absolute, lower case name.
+func Absolute(name, origin string) (string, error) {
+	switch {
+	case name == "@":
+		if origin == "" {
+			return "", fmt.Errorf("@ used without an origin")
+		}
+		return origin, nil
+	case strings.HasSuffix(name, ".") && !strings.HasSuffix(name, `\.`):
+		return strings.ToLower(name), nil
+	case origin == "":
+		return "", fmt.Errorf("relative name %s used without an origin", name)
+	case origin == ".":
+		return strings.ToLower(name) + ".", nil
+	}
+	return strings.ToLower(name + "." + origin), nil
+}
+
+// Fqdn returns name with a trailing dot.
+func Fqdn(name string) string {
+	if strings.HasSuffix(name, ".") {
+		return name
+	}
+	return name + "."
+}
+
+// ParseTTL parses a TTL in seconds, or in the units of BIND such as 1h30m.
+func ParseTTL(s string) (uint32, error) {
+	if s == "" {
+		return 0, fmt.Errorf("empty TTL")
+	}
+	if n, err := strconv.ParseUint(s, 10, 32); err == nil {
+		return uint32(n), nil
+	}
+	units := map[byte]uint64{'S': 1, 'M': 60, 'H': 3600, 'D': 86400, 'W': 604800}
+	var total, current uint64
+	digits := false
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c >= '0' && c <= '9':
+			current = current*10 + uint64(c-'0')
+			digits = true
+		case units[upper(c)] != 0 && digits:
+			total += current * units[upper(c)]
+			current, digits = 0, false
+		default:
+			return 0, fmt.Errorf("invalid TTL %q", s)
+		}
+		if total+current > 1<<32-1 {
+			return 0, fmt.Errorf("TTL %q out of range", s)
+		}
+	}
+	if digits {
+		return 0, fmt.Errorf("invalid TTL %q", s)
+	}
+	return uint32(total), nil
+}
+
+func upper(c byte) byte {
+	if c >= 'a' && c <= 'z' {
+		return c - 'a' + 'A'
+	}
+	return c
+}
+
+// entry is a logical line of a zone file.
+type entry struct {
+	line       int
+	blankOwner bool
+	tokens     []Token
+}
+
+// lex splits a zone file into logical lines, joining the lines enclosed in
+// parentheses and dropping comments.
+func lex(r io.Reader) ([]entry, error) {
+	var entries []entry
+	var current *entry
+	depth := 0
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := scanner.Text()
+		if depth == 0 {
+			if current != nil && len(current.tokens) > 0 {
+				entries = append(entries, *current)
+			}
+			current = &entry{
+				line:       line,
+				blankOwner: len(text) > 0 && (text[0] == ' ' || text[0] == '\t'),
+			}
+		}
+
+		for i := 0; i < len(text); {
+			c := text[i]
+			switch {
+			case c == ' ' || c == '\t' || c == '\r':
+				i++
+			case c == ';':
+				i = len(text)
+			case c == '(':
+				depth++
+				i++
+			case c == ')':
+				if depth == 0 {
+					return nil, &Error{line, "unbalanced )"}
+				}
+				depth--
+				i++
+			case c == '"':
+				s, n, err := quoted(text[i:])
+				if err != nil {
+					return nil, &Error{line, err.Error()}
+				}
+				current.tokens = append(current.tokens, Token{Text: s, Quoted: true})
+				i += n
+			default:
+				start := i
+				for i < len(text) && !strings.ContainsRune(" \t\r;()\"", rune(text[i])) {
+					if text[i] == '\\' && i+1 < len(text) {
+						i++
+					}
+					i++
+				}
+				current.tokens = append(current.tokens, Token{Text: text[start:i]})
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if depth != 0 {
+		return nil, &Error{current.line, "unbalanced ("}
+	}
+	if current != nil && len(current.tokens) > 0 {
+		entries = append(entries, *current)
+	}
+	return entries, nil
+}
+
+// quoted returns the content of the quoted string at the start of s and the
+// number of bytes it takes, resolving escapes.
+func quoted(s string) (string, int, error) {
+	var b strings.Builder
+	for i := 1; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '"':
+			return b.String(), i + 1, nil
+		case '\\':
+			if i+3 < len(s) && isDigit(s[i+1]) && isDigit(s[i+2]) && isDigit(s[i+3]) {
+				n, _ := strconv.Atoi(s[i+1 : i+4])
+				if n > 255 {
+					return "", 0, fmt.Errorf("invalid escape \\%s", s[i+1:i+4])
+				}
+				b.WriteByte(byte(n))
+				i += 3
+			} else if i+1 < len(s) {
+				b.WriteByte(s[i+1])
+				i++
+			}
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return "", 0, fmt.Errorf("unterminated quoted string")
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
@@ -0,0 +1,131 @@
+package zonefile
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testZone = `$TTL 1h
+@	IN	SOA	ns1 hostmaster (
+		2024010101	; serial
+		3600 900 1209600
+		300 )
+	NS	ns1.example.net.
+www	300	IN	A	192.0.2.1
+	IN	300	A	192.0.2.2 ; class before TTL
+Mail	MX	10 mail.example.net.
+txt	TXT	"a \"quoted\" string; not a comment" plain "\065\066"
+$ORIGIN sub.example.com.
+host	AAAA	2001:db8::1
+`
+
+func TestParse(t *testing.T) {
+	zone, err := Parse(strings.NewReader(testZone), "example.com")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if zone.Origin != "example.com." {
+		t.Errorf("expected origin example.com., got %s", zone.Origin)
+	}
+
+	type summary struct {
+		line       int
+		name       string
+		ttl        uint32
+		typ        string
+		data       []string
+		quoted     int
+		recordFrom string
+	}
+	var got []summary
+	for _, r := range zone.Records {
+		s := summary{line: r.Line, name: r.Name, ttl: r.TTL, typ: r.Type, recordFrom: r.Origin}
+		for _, token := range r.Data {
+			s.data = append(s.data, token.Text)
+			if token.Quoted {
+				s.quoted++
+			}
+		}
+		if r.Class != "IN" {
+			t.Errorf("line %d: expected class IN, got %s", r.Line, r.Class)
+		}
+		got = append(got, s)
+	}
+	expected := []summary{
+		{2, "example.com.", 3600, "SOA", []string{"ns1", "hostmaster", "2024010101", "3600", "900", "1209600", "300"}, 0, "example.com."},
+		{6, "example.com.", 3600, "NS", []string{"ns1.example.net."}, 0, "example.com."},
+		{7, "www.example.com.", 300, "A", []string{"192.0.2.1"}, 0, "example.com."},
+		{8, "www.example.com.", 300, "A", []string{"192.0.2.2"}, 0, "example.com."},
+		{9, "mail.example.com.", 3600, "MX", []string{"10", "mail.example.net."}, 0, "example.com."},
+		{10, "txt.example.com.", 3600, "TXT", []string{`a "quoted" string; not a comment`, "plain", "AB"}, 2, "example.com."},
+		{12, "host.sub.example.com.", 3600, "AAAA", []string{"2001:db8::1"}, 0, "sub.example.com."},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected\n%v\ngot\n%v", expected, got)
+	}
+}
+
+func TestParseDefaultTTL(t *testing.T) {
+	// Without $TTL, the records take the TTL of the record before them, and
+	// the first ones the minimum of the SOA.
+	zone, err := Parse(strings.NewReader(`$ORIGIN example.com.
+@ SOA ns1 hostmaster 1 3600 900 1209600 600
+a A 192.0.2.1
+b 60 A 192.0.2.2
+c A 192.0.2.3
+`), "")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	ttls := make([]uint32, 0, len(zone.Records))
+	for _, r := range zone.Records {
+		ttls = append(ttls, r.TTL)
+	}
+	if expected := []uint32{600, 600, 60, 600}; !reflect.DeepEqual(ttls, expected) {
+		t.Errorf("expected TTLs %v, got %v", expected, ttls)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	cases := map[string]string{
+		"www.example.com. A 192.0.2.1\n":              "line 1: no TTL for record and no $TTL before it",
+		"$TTL 60\n  A 192.0.2.1\n":                    "line 2: record without owner name",
+		"$TTL 60\n$INCLUDE other.zone\n":              "line 2: unsupported directive $INCLUDE",
+		"$TTL 60\nwww.example.com. 60\n":              "line 2: record without type",
+		"$TTL 60\n@ SOA ns1 hostmaster ( 1 2 3 4 5\n": "line 2: unbalanced (",
+		"$TTL 60\nwww TXT \"unterminated\n":           "line 2: unterminated quoted string",
+		"$TTL 60\nwww A 192.0.2.1\n":                  "line 2: relative name www used without an origin",
+	}
+	for zone, expected := range cases {
+		_, err := Parse(strings.NewReader(zone), "")
+		if err == nil || err.Error() != expected {
+			t.Errorf("%q: expected error %q, got %v", zone, expected, err)
+		}
+	}
+}
+
+func TestParseTTL(t *testing.T) {
+	cases := map[string]uint32{
+		"0":      0,
+		"3600":   3600,
+		"1h":     3600,
+		"1h30m":  5400,
+		"2W":     1209600,
+		"1d12h":  129600,
+		"30s":    30,
+		"1m1s":   61,
+		"86400S": 86400,
+	}
+	for s, expected := range cases {
+		ttl, err := ParseTTL(s)
+		if err != nil || ttl != expected {
+			t.Errorf("%s: expected %d, got %d, %v", s, expected, ttl, err)
+		}
+	}
+	for _, s := range []string{"", "h", "1x", "10h5", "4294967296", "9999999w"} {
+		if _, err := ParseTTL(s); err == nil {
+			t.Errorf("%s: expected an error", s)
+		}
+	}
+}
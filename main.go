@@ -1,11 +1,25 @@
 package main
 
 import (
-	"github.com/hashicorp/terraform-plugin-sdk/plugin"
+	"context"
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
 	"github.com/terraform-providers/terraform-provider-constellix/constellix"
+	"github.com/terraform-providers/terraform-provider-constellix/internal/export"
+	"github.com/terraform-providers/terraform-provider-constellix/internal/zone2hcl"
 )
 
 func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "zone2hcl":
+			os.Exit(zone2hcl.Main(os.Args[2:], os.Stdin, os.Stdout, os.Stderr))
+		case "export":
+			os.Exit(export.Main(context.Background(), constellix.Provider(), os.Args[2:], os.Stdout, os.Stderr))
+		}
+	}
+
 	plugin.Serve(&plugin.ServeOpts{
 		ProviderFunc: constellix.Provider})
 }